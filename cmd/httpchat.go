@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/bot"
+	"github.com/DanRulev/vocabot.git/internal/config"
+	"github.com/DanRulev/vocabot.git/internal/httpchat"
+
+	"go.uber.org/zap"
+)
+
+func runHTTPChat(cfg *config.Config, service bot.ServiceI, sessions bot.SessionStore, logger *zap.Logger) error {
+	if cfg.HTTP.Addr == "" {
+		return errors.New("http.addr is required for the http frontend")
+	}
+	// Senders are taken from the request body, so without a token anyone
+	// could claim an admin id.
+	if len(cfg.Admins) > 0 && cfg.HTTP.Token == "" {
+		return errors.New("http.token is required for the http frontend when admins are set")
+	}
+
+	server := httpchat.NewServer(cfg.HTTP.Token)
+	handler := bot.NewHandler(server, service, sessions)
+	handler.SetAdmins(cfg.Admins)
+	handler.SetRateLimit(cfg.Limits.User.Rate, cfg.Limits.User.Burst)
+	server.SetDispatcher(handler)
+	startWordOfDay(cfg.Wotd, handler, logger)
+
+	srv := &http.Server{
+		Addr:              cfg.HTTP.Addr,
+		Handler:           server,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.Info("http chat frontend listening", zap.String("addr", cfg.HTTP.Addr))
+
+	return srv.ListenAndServe()
+}
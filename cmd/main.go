@@ -1,15 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"time"
 
 	"github.com/DanRulev/vocabot.git/internal/bot"
 	"github.com/DanRulev/vocabot.git/internal/client"
 	"github.com/DanRulev/vocabot.git/internal/config"
 	"github.com/DanRulev/vocabot.git/internal/repository"
 	"github.com/DanRulev/vocabot.git/internal/service"
+	"github.com/DanRulev/vocabot.git/internal/simulator"
 	"github.com/DanRulev/vocabot.git/internal/storage/cache"
 	"github.com/DanRulev/vocabot.git/internal/storage/db"
+	"github.com/DanRulev/vocabot.git/migrations"
 
 	"go.uber.org/zap"
 )
@@ -24,6 +29,35 @@ func setupLogger(env string) *zap.Logger {
 	return logger
 }
 
+func setupSessions(cfg config.SessionConfig, db repository.QueryI, logger *zap.Logger) bot.SessionStore {
+	if cfg.Store == "postgres" {
+		sessions := repository.NewSessionRepository(db, cfg.TTL)
+		if cfg.CleanupInterval > 0 {
+			go func() {
+				ticker := time.NewTicker(cfg.CleanupInterval)
+				defer ticker.Stop()
+				for range ticker.C {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					if err := sessions.DeleteExpired(ctx); err != nil {
+						logger.Warn("failed to delete expired sessions", zap.Error(err))
+					}
+					cancel()
+				}
+			}()
+		}
+		return sessions
+	}
+
+	return cache.NewCache(cfg.TTL, cfg.CleanupInterval)
+}
+
+func setupAudio(cfg config.AudioConfig) service.AudioSourceI {
+	if cfg.Source == "command" {
+		return client.NewCommandAudio(cfg.Command)
+	}
+	return client.NewURLAudio()
+}
+
 func main() {
 	cfg, err := config.Init()
 	if err != nil {
@@ -33,22 +67,71 @@ func main() {
 
 	logger := setupLogger(cfg.Env)
 
-	db, err := db.InitDB(cfg.DB)
+	database, err := db.InitDB(cfg.DB)
 	if err != nil {
 		logger.Fatal("failed init db", zap.Error(err))
 	}
 
-	repos := repository.NewRepository(db)
+	migrator, err := db.NewMigrator(database, migrations.FS)
+	if err != nil {
+		logger.Fatal("failed load migrations", zap.Error(err))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(migrator, os.Args[2:], os.Stdout); err != nil {
+			logger.Fatal("migrate failed", zap.Error(err))
+		}
+		return
+	}
+
+	if cfg.DB.AutoMigrate {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		applied, err := migrator.Up(ctx)
+		cancel()
+		if err != nil {
+			logger.Fatal("failed apply migrations", zap.Error(err))
+		}
+		logger.Info("migrations applied", zap.Int("count", applied))
+	}
+
+	repos := repository.NewRepository(database)
+
+	// The simulator answers the API calls from a built-in dictionary and keeps
+	// sessions in memory, it needs no network.
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		offline := simulator.NewClients()
+		services := service.InitServices(offline, offline, repos, logger)
+		sessions := cache.NewCache(cfg.Session.TTL, cfg.Session.CleanupInterval)
+		if err := runSimulator(services, sessions, os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			logger.Fatal("simulator failed", zap.Error(err))
+		}
+		return
+	}
+
+	clients := client.InitClients(cfg.Limits.Providers)
+	services := service.InitServices(clients, setupAudio(cfg.Audio), repos, logger)
+	sessions := setupSessions(cfg.Session, database, logger)
+
+	if cfg.Frontend == "http" {
+		if err := runHTTPChat(cfg, services, sessions, logger); err != nil {
+			logger.Fatal("http chat failed", zap.Error(err))
+		}
+		return
+	}
 
-	clients := client.InitClients()
-	services := service.InitServices(clients, repos, logger)
-	cache := cache.NewCache()
+	if cfg.BotToken == "" {
+		logger.Fatal("bot_token is required, set BOT_TOKEN")
+	}
 
-	handler, err := bot.NewTelegramAPI(cfg.BotToken, cfg.Env, services, cache)
+	handler, err := bot.NewTelegramAPI(cfg.BotToken, cfg.Env, services, sessions)
 	if err != nil {
 		logger.Fatal(err.Error())
 		return
 	}
 
+	handler.Handler().SetAdmins(cfg.Admins)
+	handler.Handler().SetRateLimit(cfg.Limits.User.Rate, cfg.Limits.User.Burst)
+	startWordOfDay(cfg.Wotd, handler.Handler(), logger)
+
 	handler.Start()
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/storage/db"
+)
+
+const migrateUsage = "usage: vocabot migrate up|down [N]|status"
+
+func runMigrate(migrator *db.Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "applied %d migration(s)\n", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps %q: %s", args[1], migrateUsage)
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "reverted %d migration(s)\n", reverted)
+
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "version: %d", status.Version)
+		if status.Dirty {
+			fmt.Fprint(out, " (dirty)")
+		}
+		fmt.Fprintln(out)
+		for _, m := range status.Applied {
+			fmt.Fprintf(out, "  [x] %06d_%s\n", m.Version, m.Name)
+		}
+		for _, m := range status.Pending {
+			fmt.Fprintf(out, "  [ ] %06d_%s\n", m.Version, m.Name)
+		}
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+
+	"github.com/DanRulev/vocabot.git/internal/bot"
+	"github.com/DanRulev/vocabot.git/internal/simulator"
+)
+
+const simulateUsage = "usage: vocabot simulate [user_id]"
+
+func runSimulator(service bot.ServiceI, sessions bot.SessionStore, args []string, in io.Reader, out io.Writer) error {
+	userID := int64(1)
+	if len(args) > 0 {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid user id %q: %s", args[0], simulateUsage)
+		}
+		userID = id
+	}
+
+	// The handlers log every sent message, which would drown the REPL output.
+	log.SetOutput(io.Discard)
+
+	sim := simulator.New(out, userID)
+	handler := bot.NewHandler(sim, service, sessions)
+
+	return sim.Run(handler, in)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+
+	"github.com/DanRulev/vocabot.git/internal/bot"
+	"github.com/DanRulev/vocabot.git/internal/config"
+	"github.com/DanRulev/vocabot.git/internal/scheduler"
+
+	"go.uber.org/zap"
+)
+
+func startWordOfDay(cfg config.WotdConfig, handler *bot.Handler, logger *zap.Logger) {
+	if !cfg.Enabled {
+		return
+	}
+
+	at, err := scheduler.ParseClock(cfg.Time)
+	if err != nil {
+		logger.Fatal("invalid wotd.time", zap.Error(err))
+	}
+
+	logger.Info("word of the day scheduled", zap.String("time", cfg.Time))
+
+	go scheduler.Daily(context.Background(), at, handler.SendWordOfDay)
+}
@@ -0,0 +1,236 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+)
+
+const (
+	commandStatsGlobal = "stats_global"
+	commandBroadcast   = "broadcast"
+	commandUser        = "user"
+	commandBan         = "ban"
+	commandUnban       = "unban"
+
+	broadcastInterval = 50 * time.Millisecond
+)
+
+type AdminSI interface {
+	GlobalStats(ctx context.Context, lang string) (string, error)
+	UserInfo(ctx context.Context, userID int64, lang string) (string, error)
+	BroadcastRecipients(ctx context.Context) ([]int64, error)
+	Ban(ctx context.Context, userID, bannedBy int64, reason string) error
+	Unban(ctx context.Context, userID int64) error
+	IsBanned(ctx context.Context, userID int64) (bool, error)
+}
+
+type AdminT struct {
+	bot      BotSender
+	service  AdminSI
+	admins   map[int64]bool
+	interval time.Duration
+	// run starts the broadcast fan-out, tests replace it to wait for it.
+	run func(func())
+}
+
+func NewAdminTAPI(bot BotSender, service AdminSI) *AdminT {
+	return &AdminT{
+		bot:      bot,
+		service:  service,
+		admins:   map[int64]bool{},
+		interval: broadcastInterval,
+		run:      func(f func()) { go f() },
+	}
+}
+
+func (t *AdminT) setAdmins(ids []int64) {
+	admins := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		admins[id] = true
+	}
+	t.admins = admins
+}
+
+func (t *AdminT) isAdmin(userID int64) bool {
+	return userID != 0 && t.admins[userID]
+}
+
+// banned reports whether the event comes from a banned user. Admins are never
+// banned and a failed lookup lets the event through.
+func (t *AdminT) banned(event chat.Event) bool {
+	if event.From.ID == 0 || t.isAdmin(event.From.ID) {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	banned, err := t.service.IsBanned(ctx, event.From.ID)
+	if err != nil {
+		log.Printf("Failed to check ban for user %d: %v", event.From.ID, err)
+		return false
+	}
+
+	return banned
+}
+
+// handleCommand runs an admin command, for everyone else the command doesn't
+// exist.
+func (t *AdminT) handleCommand(event chat.Event, lang string) {
+	if !t.isAdmin(event.From.ID) {
+		log.Printf("User %d tried admin command /%s", event.From.ID, event.Command)
+		t.reply(event.ChatID, i18n.T(lang, "command.unknown"))
+		return
+	}
+
+	switch event.Command {
+	case commandStatsGlobal:
+		t.sendGlobalStats(event.ChatID, lang)
+	case commandBroadcast:
+		t.startBroadcast(event.ChatID, strings.TrimSpace(event.Args), lang)
+	case commandUser:
+		t.sendUserInfo(event.ChatID, event.Args, lang)
+	case commandBan:
+		t.ban(event.ChatID, event.From.ID, event.Args, lang)
+	case commandUnban:
+		t.unban(event.ChatID, event.Args, lang)
+	}
+}
+
+func (t *AdminT) sendGlobalStats(chatID int64, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	text, err := t.service.GlobalStats(ctx, lang)
+	if err != nil {
+		log.Printf("Failed to get global stats: %v", err)
+		text = i18n.T(lang, "admin.error")
+	}
+
+	t.reply(chatID, text)
+}
+
+func (t *AdminT) sendUserInfo(chatID int64, args, lang string) {
+	userID, ok := parseUserID(args)
+	if !ok {
+		t.reply(chatID, i18n.T(lang, "admin.usage_user"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	text, err := t.service.UserInfo(ctx, userID, lang)
+	if err != nil {
+		log.Printf("Failed to get info for user %d: %v", userID, err)
+		text = i18n.T(lang, "admin.error")
+	}
+
+	t.reply(chatID, text)
+}
+
+func (t *AdminT) ban(chatID, adminID int64, args, lang string) {
+	id, reason, _ := strings.Cut(strings.TrimSpace(args), " ")
+	userID, ok := parseUserID(id)
+	if !ok {
+		t.reply(chatID, i18n.T(lang, "admin.usage_ban"))
+		return
+	}
+	if t.isAdmin(userID) {
+		t.reply(chatID, i18n.T(lang, "admin.ban_admin"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	text := i18n.T(lang, "admin.banned", userID)
+	if err := t.service.Ban(ctx, userID, adminID, strings.TrimSpace(reason)); err != nil {
+		log.Printf("Failed to ban user %d: %v", userID, err)
+		text = i18n.T(lang, "admin.error")
+	}
+
+	t.reply(chatID, text)
+}
+
+func (t *AdminT) unban(chatID int64, args, lang string) {
+	userID, ok := parseUserID(args)
+	if !ok {
+		t.reply(chatID, i18n.T(lang, "admin.usage_unban"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	text := i18n.T(lang, "admin.unbanned", userID)
+	if err := t.service.Unban(ctx, userID); err != nil {
+		log.Printf("Failed to unban user %d: %v", userID, err)
+		text = i18n.T(lang, "admin.error")
+	}
+
+	t.reply(chatID, text)
+}
+
+// startBroadcast sends text to every user who isn't banned. The fan-out runs
+// in the background and reports back to the admin when it's done.
+func (t *AdminT) startBroadcast(chatID int64, text, lang string) {
+	if text == "" {
+		t.reply(chatID, i18n.T(lang, "admin.usage_broadcast"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	recipients, err := t.service.BroadcastRecipients(ctx)
+	if err != nil {
+		log.Printf("Failed to get broadcast recipients: %v", err)
+		t.reply(chatID, i18n.T(lang, "admin.error"))
+		return
+	}
+
+	t.reply(chatID, i18n.T(lang, "admin.broadcast_started", len(recipients)))
+
+	t.run(func() {
+		sent, failed := t.broadcast(recipients, text)
+		t.reply(chatID, i18n.T(lang, "admin.broadcast_done", sent, failed))
+	})
+}
+
+func (t *AdminT) broadcast(recipients []int64, text string) (sent, failed int) {
+	for i, userID := range recipients {
+		if i > 0 {
+			time.Sleep(t.interval)
+		}
+
+		// Private chats share the id of the user.
+		if _, err := t.bot.Send(chat.NewMessage(userID, text)); err != nil {
+			log.Printf("Failed to broadcast to %d: %v", userID, err)
+			failed++
+			continue
+		}
+		sent++
+	}
+
+	log.Printf("Broadcast finished: %d sent, %d failed", sent, failed)
+	return sent, failed
+}
+
+func (t *AdminT) reply(chatID int64, text string) {
+	sendMessage(t.bot, chat.NewMessage(chatID, text))
+}
+
+func parseUserID(s string) (int64, bool) {
+	id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,178 @@
+package bot
+
+import (
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminID = 1
+
+func newAdminHandlerMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_bot.MockServiceI)) (*Handler, *mock_bot.MockBot) {
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+		if setupMock != nil {
+			setupMock(ms)
+		}
+	})
+	h.SetAdmins([]int64{testAdminID})
+	h.admin.interval = 0
+	h.admin.run = func(f func()) { f() }
+
+	return h, mb
+}
+
+func adminCommand(from int64, command, args string) chat.Event {
+	return chat.Event{Type: chat.EventCommand, ChatID: from, From: chat.User{ID: from}, Command: command, Args: args}
+}
+
+func texts(mb *mock_bot.MockBot) []string {
+	var out []string
+	for _, m := range mb.SentMessages {
+		if msg, ok := m.(chat.Message); ok {
+			out = append(out, msg.Text)
+		}
+	}
+	return out
+}
+
+func TestHandler_adminCommands(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		event     chat.Event
+		setupMock func(*mock_bot.MockServiceI)
+		want      []string
+	}{
+		{
+			name:  "not an admin",
+			event: adminCommand(2, commandStatsGlobal, ""),
+			want:  []string{i18n.T(i18n.Default, "command.unknown")},
+		},
+		{
+			name:  "global stats",
+			event: adminCommand(testAdminID, commandStatsGlobal, ""),
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().GlobalStats(gomock.Any(), i18n.Default).Return("stats", nil)
+			},
+			want: []string{"stats"},
+		},
+		{
+			name:  "global stats error",
+			event: adminCommand(testAdminID, commandStatsGlobal, ""),
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().GlobalStats(gomock.Any(), i18n.Default).Return("", assert.AnError)
+			},
+			want: []string{i18n.T(i18n.Default, "admin.error")},
+		},
+		{
+			name:  "user info",
+			event: adminCommand(testAdminID, commandUser, " 42 "),
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UserInfo(gomock.Any(), int64(42), i18n.Default).Return("info", nil)
+			},
+			want: []string{"info"},
+		},
+		{
+			name:  "user without id",
+			event: adminCommand(testAdminID, commandUser, "bob"),
+			want:  []string{i18n.T(i18n.Default, "admin.usage_user")},
+		},
+		{
+			name:  "ban with reason",
+			event: adminCommand(testAdminID, commandBan, "42 spam links"),
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Ban(gomock.Any(), int64(42), int64(testAdminID), "spam links").Return(nil)
+			},
+			want: []string{i18n.T(i18n.Default, "admin.banned", 42)},
+		},
+		{
+			name:  "ban an admin",
+			event: adminCommand(testAdminID, commandBan, "1"),
+			want:  []string{i18n.T(i18n.Default, "admin.ban_admin")},
+		},
+		{
+			name:  "unban",
+			event: adminCommand(testAdminID, commandUnban, "42"),
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Unban(gomock.Any(), int64(42)).Return(nil)
+			},
+			want: []string{i18n.T(i18n.Default, "admin.unbanned", 42)},
+		},
+		{
+			name:  "broadcast without text",
+			event: adminCommand(testAdminID, commandBroadcast, "  "),
+			want:  []string{i18n.T(i18n.Default, "admin.usage_broadcast")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h, mb := newAdminHandlerMock(t, ctrl, tt.setupMock)
+
+			h.Handle(tt.event)
+
+			assert.Equal(t, tt.want, texts(mb))
+		})
+	}
+}
+
+func TestHandler_broadcast(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newAdminHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().BroadcastRecipients(gomock.Any()).Return([]int64{10, 20, 30}, nil)
+	})
+	mb.SendErrors = map[int64]error{20: assert.AnError}
+
+	h.Handle(adminCommand(testAdminID, commandBroadcast, "Hello *all*"))
+
+	require.Len(t, mb.SentMessages, 4)
+	assert.Equal(t, i18n.T(i18n.Default, "admin.broadcast_started", 3), mb.SentMessages[0].(chat.Message).Text)
+
+	for i, chatID := range []int64{10, 30} {
+		msg := mb.SentMessages[i+1].(chat.Message)
+		assert.Equal(t, chatID, msg.ChatID)
+		assert.Equal(t, "Hello *all*", msg.Text)
+		assert.False(t, msg.Markdown, "broadcast text is sent as is")
+	}
+
+	done := mb.SentMessages[3].(chat.Message)
+	assert.Equal(t, int64(testAdminID), done.ChatID)
+	assert.Equal(t, i18n.T(i18n.Default, "admin.broadcast_done", 2, 1), done.Text)
+}
+
+func TestHandler_bannedUser(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newAdminHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().IsBanned(gomock.Any(), int64(42)).Return(true, nil).Times(2)
+	})
+
+	h.Handle(chat.Event{Type: chat.EventCommand, ChatID: 42, From: chat.User{ID: 42}, Command: "start"})
+	h.Handle(chat.Event{Type: chat.EventCallback, ChatID: 42, MessageID: 3, From: chat.User{ID: 42}, CallbackID: "cb", Data: "new_quiz"})
+
+	assert.Empty(t, mb.SentMessages)
+	assert.Empty(t, mb.AnsweredCallbacks)
+
+	// Admins are never looked up.
+	h.Handle(chat.Event{Type: chat.EventCommand, ChatID: testAdminID, From: chat.User{ID: testAdminID}, Command: "help"})
+	assert.Len(t, mb.SentMessages, 1)
+}
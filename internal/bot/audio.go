@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+const pronounceCallback = "pronounce"
+
+type AudioSI interface {
+	Pronunciation(ctx context.Context, kind, word, url string) (models.Audio, error)
+	SaveAudioFileID(ctx context.Context, kind, word, fileID string) error
+}
+
+// sendPronunciation sends the audio of a word card. The card stays open, the
+// button can be pressed again.
+func (t *WordT) sendPronunciation(event chat.Event, lang string) {
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	key := models.SessionKey{ChatID: event.ChatID, MessageID: event.MessageID}
+	word, exists, err := t.sessions.GetWord(ctx, key)
+	if err != nil {
+		log.Printf("Failed to get word session for user %d: %v", event.From.ID, err)
+	}
+	if !exists {
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "word.not_found"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	audio, err := t.audio.Pronunciation(ctx, models.AudioCard, word.WordText, word.Audio)
+	if err != nil {
+		log.Printf("Failed to get pronunciation of %q: %v", word.WordText, err)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "audio.unavailable"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	sendAudio(ctx, t.bot, t.audio, event.ChatID, models.AudioCard, word.WordText, audio, i18n.T(lang, "audio.caption", word.WordText))
+}
+
+// sendAudio sends a word's pronunciation and remembers the file id the
+// frontend gave to a new upload under its kind.
+func sendAudio(ctx context.Context, bot BotSender, service AudioSI, chatID int64, kind, word string, audio models.Audio, caption string) error {
+	msg := chat.NewAudio(chatID, chat.File{ID: audio.FileID, Name: audio.Name, Data: audio.Data})
+	msg.Text = caption
+
+	sent, err := sendMessage(bot, msg)
+	if err != nil {
+		return err
+	}
+
+	if audio.FileID == "" && sent.FileID != "" {
+		if err := service.SaveAudioFileID(ctx, kind, word, sent.FileID); err != nil {
+			log.Printf("Failed to save audio file id of %q: %v", word, err)
+		}
+	}
+
+	return nil
+}
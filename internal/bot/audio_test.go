@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordT_sendPronunciation(t *testing.T) {
+	t.Parallel()
+
+	key := models.SessionKey{ChatID: 123, MessageID: 7}
+	card := models.WordCard{UserID: 456, WordText: "apple", Translation: "яблоко", Audio: "https://example.com/apple.mp3"}
+	event := chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 7, From: chat.User{ID: 456}, Data: pronounceCallback}
+
+	tests := []struct {
+		name       string
+		noSession  bool
+		f          func(*mock_bot.MockServiceI, *mock_bot.MockBot)
+		assertFunc func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name: "first upload saves the file id",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().Pronunciation(gomock.Any(), models.AudioCard, "apple", card.Audio).Return(models.Audio{Name: "apple.mp3", Data: []byte{1, 2}}, nil)
+				ms.EXPECT().SaveAudioFileID(gomock.Any(), models.AudioCard, "apple", "file1").Return(nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				require.NotNil(t, msg.Audio)
+				assert.Equal(t, "apple.mp3", msg.Audio.Name)
+				assert.Equal(t, []byte{1, 2}, msg.Audio.Data)
+				assert.Equal(t, "🔊 apple", msg.Text)
+			},
+		},
+		{
+			name: "cached file id is sent again",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().Pronunciation(gomock.Any(), models.AudioCard, "apple", card.Audio).Return(models.Audio{FileID: "cached"}, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				require.NotNil(t, msg.Audio)
+				assert.Equal(t, "cached", msg.Audio.ID)
+				assert.Empty(t, msg.Audio.Data)
+			},
+		},
+		{
+			name: "no audio",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().Pronunciation(gomock.Any(), models.AudioCard, "apple", card.Audio).Return(models.Audio{}, models.ErrNoAudio)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "audio.unavailable"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name:      "card already answered",
+			noSession: true,
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "word.not_found"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wordT := newWordTMock(t, ctrl, tt.f)
+			mb := wordT.bot.(*mock_bot.MockBot)
+
+			if !tt.noSession {
+				require.NoError(t, wordT.sessions.SetWord(context.Background(), key, card))
+			}
+
+			wordT.handleWordCallbackQuery(event, i18n.Default)
+
+			tt.assertFunc(t, mb)
+
+			_, exists, err := wordT.sessions.GetWord(context.Background(), key)
+			require.NoError(t, err)
+			assert.Equal(t, !tt.noSession, exists, "the card stays open")
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type ServiceI interface {
+	WordSI
+	QuizSI
+	SettingsSI
+	StatsSI
+	WotdSI
+	AdminSI
+	UserSI
+	GameSI
+	GroupSI
+	AudioSI
+}
+
+type SessionStore interface {
+	SetWord(ctx context.Context, key models.SessionKey, word models.WordCard) error
+	GetWord(ctx context.Context, key models.SessionKey) (models.WordCard, bool, error)
+	TakeWord(ctx context.Context, key models.SessionKey) (models.WordCard, bool, error)
+	SetQuiz(ctx context.Context, key models.SessionKey, quiz models.QuizCard) error
+	GetQuiz(ctx context.Context, key models.SessionKey) (models.QuizCard, bool, error)
+	TakeQuiz(ctx context.Context, key models.SessionKey) (models.QuizCard, bool, error)
+	SetChecklist(ctx context.Context, key models.SessionKey, list models.WordChecklist) error
+	GetChecklist(ctx context.Context, key models.SessionKey) (models.WordChecklist, bool, error)
+	DeleteChecklist(ctx context.Context, key models.SessionKey) error
+	SetPoll(ctx context.Context, pollID string, key models.SessionKey) error
+	GetPoll(ctx context.Context, pollID string) (models.SessionKey, bool, error)
+	DeletePoll(ctx context.Context, pollID string) error
+}
+
+// BotSender is implemented by every frontend the handlers can talk through.
+type BotSender interface {
+	Send(msg chat.Message) (chat.Sent, error)
+	Edit(edit chat.Edit) error
+	AnswerCallback(callbackID, text string) error
+	AnswerInline(queryID string, results []chat.InlineResult) error
+	DownloadFile(fileID string) ([]byte, error)
+}
+
+func sendMessage(bot BotSender, msg chat.Message) (chat.Sent, error) {
+	sent, err := bot.Send(msg)
+	if err != nil {
+		log.Printf("Failed to send message: %v", err)
+	} else {
+		log.Printf("Sent message to %d", msg.ChatID)
+	}
+	return sent, err
+}
+
+func editMessage(bot BotSender, edit chat.Edit) {
+	if err := bot.Edit(edit); err != nil {
+		log.Printf("Failed to edit message %d: %v", edit.MessageID, err)
+	} else {
+		log.Printf("Edited message in %d", edit.ChatID)
+	}
+}
+
+// escapeMarkdown escapes the characters legacy Markdown would take for
+// formatting in text that comes from the dictionaries or the user.
+func escapeMarkdown(text string) string {
+	for _, c := range []string{"_", "*", "`", "["} {
+		text = strings.ReplaceAll(text, c, "\\"+c)
+	}
+	return text
+}
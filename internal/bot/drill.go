@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+const drillType = "drill"
+
+func (t *QuizT) showHardWords(chatID, userID int64, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	text, hasWords, err := t.service.HardWords(ctx, userID, lang)
+	if err != nil {
+		log.Printf("failed to get hard words for user %d: %v", userID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, "hard_words.error"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	msg := chat.NewMessage(chatID, text)
+	msg.Markdown = true
+	if hasWords {
+		msg.Buttons = [][]chat.Button{
+			chat.Row(chat.NewButton(i18n.T(lang, "inline.drill"), "new_drill")),
+		}
+	}
+
+	sendMessage(t.bot, msg)
+}
+
+// sendDrill asks one of the user's hard words. Answers are handled like any
+// other quiz.
+func (t *QuizT) sendDrill(chatID, userID int64, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	question, options, err := t.service.NewDrill(ctx, userID)
+	if errors.Is(err, models.ErrNoHardWords) {
+		msg := chat.NewMessage(chatID, i18n.T(lang, "drill.done"))
+		sendMessage(t.bot, msg)
+		return
+	}
+	if err != nil {
+		log.Printf("failed to get drill for user %d: %v", userID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, "quiz.error"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	quiz := models.QuizCard{UserID: userID, Word: question, Type: drillType}
+	t.sendQuestion(ctx, chatID, quiz, i18n.T(lang, "quiz.question", quiz.Word), options, lang)
+}
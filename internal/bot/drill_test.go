@@ -0,0 +1,175 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuizT_showHardWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		f          func(*mock_bot.MockServiceI, *mock_bot.MockBot)
+		assertFunc func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name: "with words",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().HardWords(gomock.Any(), int64(456), i18n.Default).Return("hard words", true, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, "hard words", msg.Text)
+				require.Len(t, msg.Buttons, 1)
+				assert.Equal(t, "new_drill", msg.Buttons[0][0].Data)
+			},
+		},
+		{
+			name: "no words",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().HardWords(gomock.Any(), int64(456), i18n.Default).Return("empty", false, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Empty(t, mb.SentMessages[0].(chat.Message).Buttons)
+			},
+		},
+		{
+			name: "service error",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().HardWords(gomock.Any(), int64(456), i18n.Default).Return("", false, assert.AnError)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "hard_words.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quizT := newQuizTMock(t, ctrl, tt.f)
+			mb, _ := quizT.bot.(*mock_bot.MockBot)
+
+			quizT.showHardWords(123, 456, i18n.Default)
+
+			tt.assertFunc(t, mb)
+		})
+	}
+}
+
+func TestQuizT_sendDrill(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		f          func(*mock_bot.MockServiceI, *mock_bot.MockBot)
+		assertFunc func(*testing.T, *QuizT, *mock_bot.MockBot)
+	}{
+		{
+			name: "question",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewDrill(gomock.Any(), int64(456)).Return("apple", map[string]bool{
+					"яблоко": true, "дом": false, "кот": false, "солнце": false,
+				}, nil)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Contains(t, msg.Text, "apple")
+				require.Len(t, msg.Buttons, 2)
+
+				quiz, ok, err := quizT.sessions.GetQuiz(context.Background(), models.SessionKey{ChatID: 123, MessageID: 1})
+				require.NoError(t, err)
+				require.True(t, ok)
+				assert.Equal(t, models.QuizCard{UserID: 456, Word: "apple", Translation: "яблоко", Type: drillType}, quiz)
+			},
+		},
+		{
+			name: "all words drilled",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewDrill(gomock.Any(), int64(456)).Return("", nil, models.ErrNoHardWords)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "drill.done"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name: "service error",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewDrill(gomock.Any(), int64(456)).Return("", nil, assert.AnError)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "quiz.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quizT := newQuizTMock(t, ctrl, tt.f)
+			mb, _ := quizT.bot.(*mock_bot.MockBot)
+
+			quizT.sendDrill(123, 456, i18n.Default)
+
+			tt.assertFunc(t, quizT, mb)
+		})
+	}
+}
+
+func TestQuizT_processQuizAnswer_drill(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	quizT := newQuizTMock(t, ctrl, func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+		ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, card models.QuizCard) error {
+				assert.Equal(t, drillType, card.Type)
+				return nil
+			},
+		)
+	})
+	mb, _ := quizT.bot.(*mock_bot.MockBot)
+
+	key := models.SessionKey{ChatID: 123, MessageID: 100}
+	require.NoError(t, quizT.sessions.SetQuiz(context.Background(), key, models.QuizCard{
+		UserID: 456, Word: "apple", Translation: "яблоко", Type: drillType,
+	}))
+
+	quizT.processQuizAnswer(chat.Event{
+		Type:      chat.EventCallback,
+		From:      chat.User{ID: 456},
+		ChatID:    123,
+		MessageID: 100,
+		Data:      "quiz_right",
+	}, i18n.Default)
+
+	require.Len(t, mb.SentMessages, 1)
+	edit := mb.SentMessages[0].(chat.Edit)
+	require.Len(t, edit.Buttons, 1)
+	assert.Equal(t, "new_drill", edit.Buttons[0][0].Data)
+}
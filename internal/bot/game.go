@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type GameSI interface {
+	RecordActivity(ctx context.Context, userID int64, activity models.Activity, lang string) (string, string, error)
+	Profile(ctx context.Context, userID int64, lang string) (string, error)
+}
+
+type GameT struct {
+	bot     BotSender
+	service GameSI
+}
+
+func NewGameTAPI(bot BotSender, service GameSI) *GameT {
+	return &GameT{
+		bot:     bot,
+		service: service,
+	}
+}
+
+func (t *GameT) sendProfile(chatID, userID int64, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	text, err := t.service.Profile(ctx, userID, lang)
+	if err != nil {
+		log.Printf("Failed to get profile for user %d: %v", userID, err)
+		text = i18n.T(lang, "game.error")
+	}
+
+	sendMessage(t.bot, chat.NewMessage(chatID, text))
+}
+
+// recordActivity awards XP for an answer. It returns the progress line for
+// the answered message and what to announce afterwards, both empty on error
+// so the answer itself still goes through.
+func recordActivity(ctx context.Context, game GameSI, userID int64, activity models.Activity, lang string) (string, string) {
+	progress, announcement, err := game.RecordActivity(ctx, userID, activity, lang)
+	if err != nil {
+		log.Printf("Failed to record %s for user %d: %v", activity, userID, err)
+		return "", ""
+	}
+	return progress, announcement
+}
+
+func announce(bot BotSender, chatID int64, text string) {
+	if text == "" {
+		return
+	}
+	sendMessage(bot, chat.NewMessage(chatID, text))
+}
@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameT_sendProfile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock_bot.MockServiceI)
+		want      string
+	}{
+		{
+			name: "success",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Profile(gomock.Any(), int64(456), i18n.Default).Return("profile", nil)
+			},
+			want: "profile",
+		},
+		{
+			name: "service error",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Profile(gomock.Any(), int64(456), i18n.Default).Return("", assert.AnError)
+			},
+			want: i18n.T(i18n.Default, "game.error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_bot.NewMockServiceI(ctrl)
+			mockBot := &mock_bot.MockBot{}
+			tt.setupMock(mockService)
+
+			NewGameTAPI(mockBot, mockService).sendProfile(123, 456, i18n.Default)
+
+			require.Len(t, mockBot.SentMessages, 1)
+			msg := mockBot.SentMessages[0].(chat.Message)
+			assert.Equal(t, int64(123), msg.ChatID)
+			assert.Equal(t, tt.want, msg.Text)
+		})
+	}
+}
+
+func TestQuizT_processQuizAnswer_game(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock_bot.MockServiceI)
+		check     func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name: "progress and announcement",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).Return(nil)
+				ms.EXPECT().RecordActivity(gomock.Any(), int64(456), models.ActivityQuizRight, i18n.Default).
+					Return("⭐ progress", "🏆 unlocked", nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 2)
+				assert.Contains(t, mb.SentMessages[0].(chat.Edit).Text, "\n⭐ progress")
+				msg := mb.SentMessages[1].(chat.Message)
+				assert.Equal(t, int64(123), msg.ChatID)
+				assert.Equal(t, "🏆 unlocked", msg.Text)
+			},
+		},
+		{
+			name: "game error keeps the answer",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).Return(nil)
+				ms.EXPECT().RecordActivity(gomock.Any(), int64(456), models.ActivityQuizRight, i18n.Default).
+					Return("", "", assert.AnError)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Contains(t, mb.SentMessages[0].(chat.Edit).Text, "✅ Правильно! Привет")
+			},
+		},
+		{
+			name: "no xp when the result isn't saved",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				ms.EXPECT().RecordActivity(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("⭐ progress", "🏆 unlocked", nil).AnyTimes()
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.NotContains(t, mb.SentMessages[0].(chat.Edit).Text, "⭐ progress")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quizT := newQuizTMock(t, ctrl, func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				tt.setupMock(ms)
+			})
+			mb := quizT.bot.(*mock_bot.MockBot)
+
+			key := models.SessionKey{ChatID: 123, MessageID: 100}
+			require.NoError(t, quizT.sessions.SetQuiz(context.Background(), key, models.QuizCard{
+				UserID:      456,
+				Word:        "hello",
+				Translation: "Привет",
+				Type:        "quiz",
+			}))
+
+			quizT.processQuizAnswer(chat.Event{
+				Type:      chat.EventCallback,
+				From:      chat.User{ID: 456},
+				ChatID:    123,
+				MessageID: 100,
+				Text:      "❓ Как переводится: hello",
+				Data:      "quiz_right",
+			}, i18n.Default)
+
+			tt.check(t, mb)
+		})
+	}
+}
+
+func TestHandler_achievementsButton(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil).AnyTimes()
+		ms.EXPECT().Profile(gomock.Any(), int64(456), i18n.Default).Return("profile", nil)
+	})
+
+	handler.Handle(chat.Event{
+		Type:   chat.EventMessage,
+		ChatID: 123,
+		From:   chat.User{ID: 456},
+		Text:   i18n.T(i18n.Default, ButtonAchievements),
+	})
+
+	require.Len(t, mb.SentMessages, 1)
+	assert.Equal(t, "profile", mb.SentMessages[0].(chat.Message).Text)
+}
@@ -0,0 +1,202 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+const (
+	groupQuizPrefix = "gquiz_"
+	groupQuizRight  = groupQuizPrefix + "right"
+	groupQuizWrong  = groupQuizPrefix + "wrong"
+	groupQuizNext   = groupQuizPrefix + "next"
+)
+
+type GroupSI interface {
+	AddGroupResult(ctx context.Context, result models.QuizCard) error
+	Leaderboard(ctx context.Context, chatID int64, lang string) (string, error)
+}
+
+type GroupT struct {
+	bot      BotSender
+	sessions SessionStore
+	quiz     QuizSI
+	service  GroupSI
+
+	// mu makes checking and updating a group quiz atomic, so a question has
+	// exactly one winner.
+	mu sync.Mutex
+}
+
+func NewGroupTAPI(bot BotSender, sessions SessionStore, quiz QuizSI, service GroupSI) *GroupT {
+	return &GroupT{
+		bot:      bot,
+		sessions: sessions,
+		quiz:     quiz,
+		service:  service,
+	}
+}
+
+func (t *GroupT) sendHelp(chatID int64, lang string) {
+	msg := chat.NewMessage(chatID, i18n.T(lang, "group.help"))
+	sendMessage(t.bot, msg)
+}
+
+// sendGroupQuiz asks a question the whole chat can answer. Each member gets
+// one try, the first correct answer wins.
+func (t *GroupT) sendGroupQuiz(event chat.Event, lang string) {
+	if !event.Group {
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "group.only"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	question, options, err := t.quiz.NewQuiz(ctx, event.From.ID)
+	if err != nil {
+		log.Printf("Failed to get group quiz for chat %d: %v", event.ChatID, err)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, errorKey(err, "quiz.error")))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	quiz := models.QuizCard{ChatID: event.ChatID, Word: question, Type: models.GroupQuizType}
+
+	msg := chat.NewMessage(event.ChatID, i18n.T(lang, "group.question", quiz.Word))
+	msg.Buttons, quiz.Translation = optionButtons(options, groupQuizRight, groupQuizWrong)
+
+	sent, err := sendMessage(t.bot, msg)
+	if err != nil {
+		return
+	}
+
+	key := models.SessionKey{ChatID: event.ChatID, MessageID: sent.MessageID}
+	if err := t.sessions.SetQuiz(ctx, key, quiz); err != nil {
+		log.Printf("Failed to save group quiz session for chat %d: %v", event.ChatID, err)
+	}
+}
+
+// handleCallback answers the callback itself: the result of a group answer
+// is shown only to the member who pressed the button.
+func (t *GroupT) handleCallback(event chat.Event, lang string) {
+	var text string
+	if event.Data == groupQuizRight || event.Data == groupQuizWrong {
+		text = t.processAnswer(event, lang)
+	}
+
+	if err := t.bot.AnswerCallback(event.CallbackID, text); err != nil {
+		log.Printf("Failed to answer callback: %v", err)
+	}
+
+	if event.Data == groupQuizNext && event.MessageID != 0 {
+		// Drop the button, so the next question is asked once.
+		editMessage(t.bot, chat.NewEdit(event.ChatID, event.MessageID, event.Text))
+		t.sendGroupQuiz(event, lang)
+	}
+}
+
+func (t *GroupT) processAnswer(event chat.Event, lang string) string {
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	userID := event.From.ID
+	key := models.SessionKey{ChatID: event.ChatID, MessageID: event.MessageID}
+
+	quiz, exists, err := t.sessions.GetQuiz(ctx, key)
+	if err != nil {
+		log.Printf("Failed to get group quiz session for chat %d: %v", event.ChatID, err)
+	}
+	if !exists {
+		return i18n.T(lang, "group.finished")
+	}
+	if slices.Contains(quiz.Answered, userID) {
+		return i18n.T(lang, "group.already_answered")
+	}
+
+	result := quiz
+	result.UserID = userID
+	result.ChatID = event.ChatID
+	result.IsCorrect = event.Data == groupQuizRight
+	result.Answered = nil
+
+	if result.IsCorrect {
+		// Only the first right answer takes the quiz, a later one is too late.
+		_, exists, err = t.sessions.TakeQuiz(ctx, key)
+		if err != nil {
+			log.Printf("Failed to take group quiz session for chat %d: %v", event.ChatID, err)
+		}
+		if !exists {
+			return i18n.T(lang, "group.finished")
+		}
+	}
+
+	if err := t.service.AddGroupResult(ctx, result); err != nil {
+		log.Printf("Failed to save group quiz result for user %d: %v", userID, err)
+	}
+
+	if !result.IsCorrect {
+		quiz.Answered = append(quiz.Answered, userID)
+		if err := t.sessions.SetQuiz(ctx, key, quiz); err != nil {
+			log.Printf("Failed to update group quiz session for chat %d: %v", event.ChatID, err)
+		}
+		return i18n.T(lang, "group.wrong")
+	}
+
+	winner := i18n.T(lang, "group.winner", displayName(event.From), quiz.Translation)
+	editMsg := chat.NewEdit(event.ChatID, event.MessageID, fmt.Sprintf("%s\n\n%s", event.Text, winner))
+	editMsg.Buttons = [][]chat.Button{
+		chat.Row(chat.NewButton(i18n.T(lang, "group.next"), groupQuizNext)),
+	}
+	editMessage(t.bot, editMsg)
+
+	return i18n.T(lang, "group.correct")
+}
+
+func (t *GroupT) sendLeaderboard(event chat.Event, lang string) {
+	if !event.Group {
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "group.only"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	text, err := t.service.Leaderboard(ctx, event.ChatID, lang)
+	if err != nil {
+		log.Printf("Failed to get leaderboard for chat %d: %v", event.ChatID, err)
+		text = i18n.T(lang, "leaderboard.error")
+	}
+
+	sendMessage(t.bot, chat.NewMessage(event.ChatID, text))
+}
+
+func displayName(user chat.User) string {
+	switch {
+	case user.FirstName != "":
+		return user.FirstName
+	case user.UserName != "":
+		return "@" + user.UserName
+	default:
+		return strconv.FormatInt(user.ID, 10)
+	}
+}
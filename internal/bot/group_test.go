@@ -0,0 +1,196 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const groupChatID = -100
+
+func groupCallback(userID int64, data, text string) chat.Event {
+	return chat.Event{
+		Type:       chat.EventCallback,
+		ChatID:     groupChatID,
+		Group:      true,
+		From:       chat.User{ID: userID, FirstName: "Member"},
+		MessageID:  1,
+		Text:       text,
+		CallbackID: "cb",
+		Data:       data,
+	}
+}
+
+func TestGroupT_quizRound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var saved []models.QuizCard
+	handler, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+		ms.EXPECT().NewQuiz(gomock.Any(), int64(1)).Return("hello", map[string]bool{"привет": true, "пока": false}, nil)
+		ms.EXPECT().AddGroupResult(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, card models.QuizCard) error {
+			saved = append(saved, card)
+			return nil
+		}).Times(2)
+	})
+
+	handler.Handle(chat.Event{Type: chat.EventCommand, ChatID: groupChatID, Group: true, From: chat.User{ID: 1}, Command: "groupquiz"})
+
+	require.Len(t, mb.SentMessages, 1)
+	question := mb.SentMessages[0].(chat.Message)
+	assert.Equal(t, int64(groupChatID), question.ChatID)
+	assert.Equal(t, i18n.T(i18n.Default, "group.question", "hello"), question.Text)
+	for _, row := range question.Buttons {
+		for _, b := range row {
+			assert.Contains(t, []string{groupQuizRight, groupQuizWrong}, b.Data)
+		}
+	}
+
+	handler.Handle(groupCallback(2, groupQuizWrong, question.Text))
+	handler.Handle(groupCallback(2, groupQuizRight, question.Text))
+	handler.Handle(groupCallback(3, groupQuizRight, question.Text))
+	handler.Handle(groupCallback(4, groupQuizRight, question.Text))
+
+	assert.Equal(t, []string{
+		i18n.T(i18n.Default, "group.wrong"),
+		i18n.T(i18n.Default, "group.already_answered"),
+		i18n.T(i18n.Default, "group.correct"),
+		i18n.T(i18n.Default, "group.finished"),
+	}, mb.CallbackTexts)
+
+	require.Len(t, saved, 2)
+	assert.Equal(t, models.QuizCard{UserID: 2, ChatID: groupChatID, Word: "hello", Translation: "привет", Type: models.GroupQuizType}, saved[0])
+	assert.Equal(t, models.QuizCard{UserID: 3, ChatID: groupChatID, Word: "hello", Translation: "привет", Type: models.GroupQuizType, IsCorrect: true}, saved[1])
+
+	require.Len(t, mb.SentMessages, 2)
+	edit := mb.SentMessages[1].(chat.Edit)
+	assert.Equal(t, 1, edit.MessageID)
+	assert.Contains(t, edit.Text, i18n.T(i18n.Default, "group.winner", "Member", "привет"))
+	assert.Equal(t, groupQuizNext, edit.Buttons[0][0].Data)
+}
+
+func TestGroupT_privateChat(t *testing.T) {
+	t.Parallel()
+
+	for _, command := range []string{"groupquiz", "leaderboard"} {
+		t.Run(command, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+			})
+
+			handler.Handle(chat.Event{Type: chat.EventCommand, ChatID: 1, From: chat.User{ID: 1}, Command: command})
+
+			require.Len(t, mb.SentMessages, 1)
+			assert.Equal(t, i18n.T(i18n.Default, "group.only"), mb.SentMessages[0].(chat.Message).Text)
+		})
+	}
+}
+
+func TestGroupT_sendLeaderboard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock_bot.MockServiceI)
+		want      string
+	}{
+		{
+			name: "success",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Leaderboard(gomock.Any(), int64(groupChatID), i18n.Default).Return("board", nil)
+			},
+			want: "board",
+		},
+		{
+			name: "service error",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Leaderboard(gomock.Any(), int64(groupChatID), i18n.Default).Return("", assert.AnError)
+			},
+			want: i18n.T(i18n.Default, "leaderboard.error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_bot.NewMockServiceI(ctrl)
+			mockBot := &mock_bot.MockBot{}
+			tt.setupMock(mockService)
+
+			groupT := NewGroupTAPI(mockBot, nil, mockService, mockService)
+			groupT.sendLeaderboard(chat.Event{ChatID: groupChatID, Group: true}, i18n.Default)
+
+			require.Len(t, mockBot.SentMessages, 1)
+			assert.Equal(t, tt.want, mockBot.SentMessages[0].(chat.Message).Text)
+		})
+	}
+}
+
+func TestHandler_groupChat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		event chat.Event
+		want  []string
+	}{
+		{
+			name:  "start shows the group commands",
+			event: chat.Event{Type: chat.EventCommand, Command: "start"},
+			want:  []string{i18n.T(i18n.Default, "group.help")},
+		},
+		{
+			name:  "help shows the group commands",
+			event: chat.Event{Type: chat.EventCommand, Command: "help"},
+			want:  []string{i18n.T(i18n.Default, "group.help")},
+		},
+		{
+			name:  "chatter is ignored",
+			event: chat.Event{Type: chat.EventMessage, Text: "hi all"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+			})
+
+			event := tt.event
+			event.ChatID = groupChatID
+			event.Group = true
+			event.From = chat.User{ID: 1}
+			handler.Handle(event)
+
+			var got []string
+			for _, m := range mb.SentMessages {
+				got = append(got, m.(chat.Message).Text)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
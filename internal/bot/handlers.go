@@ -1,201 +1,356 @@
 package bot
 
 import (
+	"context"
 	"log"
 	"strings"
+	"time"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
 )
 
+// Reply keyboard buttons are catalog keys, the text shown to the user
+// depends on their locale.
 const (
-	ButtonNewWord         = "📚 Новое слово"
-	ButtonQuiz            = "🧠 Викторина"
-	ButtonMyWords         = "❗Мои слова"
-	ButtonProgress        = "📊 Мой прогресс"
-	ButtonWordProgress    = "📚 Слова"
-	ButtonQuizProgress    = "🧠 Викторины"
-	ButtonLearnedWords    = "✅ Выученные слова"
-	ButtonNotLearnedWords = "❌ Не выученные слова"
-	ButtonMainMenu        = "🏠 Главное меню"
-	ButtonBack            = "⏪ Назад"
-	ButtonHelp            = "ℹ️ Помощь"
+	ButtonNewWord         = "button.new_word"
+	ButtonQuiz            = "button.quiz"
+	ButtonMyWords         = "button.my_words"
+	ButtonProgress        = "button.progress"
+	ButtonWordProgress    = "button.word_progress"
+	ButtonQuizProgress    = "button.quiz_progress"
+	ButtonCharts          = "button.charts"
+	ButtonAchievements    = "button.achievements"
+	ButtonLearnedWords    = "button.learned_words"
+	ButtonNotLearnedWords = "button.not_learned_words"
+	ButtonHardWords       = "button.hard_words"
+	ButtonMainMenu        = "button.main_menu"
+	ButtonBack            = "button.back"
+	ButtonHelp            = "button.help"
+	ButtonLanguage        = "button.language"
 )
 
-func (t *TelegramAPI) handleCommand(message *tgbotapi.Message) {
-	switch message.Command() {
+type Handler struct {
+	bot      BotSender
+	settings SettingsSI
+	users    UserSI
+	word     *WordT
+	quiz     *QuizT
+	stats    *StatsT
+	wotd     *WotdT
+	admin    *AdminT
+	game     *GameT
+	group    *GroupT
+	inline   *InlineT
+	text     *TextT
+	limit    *userLimit
+}
+
+func NewHandler(bot BotSender, service ServiceI, sessions SessionStore) *Handler {
+	return &Handler{
+		bot:      bot,
+		settings: service,
+		users:    service,
+		word:     NewWordTAPI(bot, sessions, service, service, service, service),
+		quiz:     NewQuizTAPI(bot, sessions, service, service, service, service),
+		stats:    NewStatsTAPI(bot, service),
+		wotd:     NewWotdTAPI(bot, sessions, service),
+		admin:    NewAdminTAPI(bot, service),
+		game:     NewGameTAPI(bot, service),
+		group:    NewGroupTAPI(bot, sessions, service, service),
+		inline:   NewInlineTAPI(bot, service),
+		text:     NewTextTAPI(bot, sessions, service),
+	}
+}
+
+// SetAdmins sets the users allowed to run admin commands.
+func (h *Handler) SetAdmins(ids []int64) {
+	h.admin.setAdmins(ids)
+}
+
+func (h *Handler) Handle(event chat.Event) {
+	if h.admin.banned(event) {
+		log.Printf("Ignoring event from banned user %d", event.From.ID)
+		return
+	}
+	if h.limited(event) {
+		return
+	}
+	h.touchUser(event.From)
+
+	switch event.Type {
+	case chat.EventCommand:
+		h.handleCommand(event)
+	case chat.EventMessage:
+		h.handleMessage(event)
+	case chat.EventCallback:
+		h.handleCallbackQuery(event)
+	case chat.EventPollAnswer:
+		h.quiz.processPollAnswer(event, h.locale(event))
+	case chat.EventInline:
+		h.inline.handleQuery(event, h.locale(event))
+	default:
+		log.Printf("Unknown event type: %s", event.Type)
+	}
+}
+
+// SendWordOfDay pushes today's word to subscribers, it is run by the
+// scheduler.
+func (h *Handler) SendWordOfDay(ctx context.Context) {
+	h.wotd.broadcast(ctx)
+}
+
+// locale prefers the language the user picked with /language over the one
+// reported by the chat platform.
+func (h *Handler) locale(event chat.Event) string {
+	if event.From.ID != 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		lang, err := h.settings.Language(ctx, event.From.ID)
+		if err != nil {
+			log.Printf("Failed to get language for user %d: %v", event.From.ID, err)
+		}
+		if lang != "" {
+			return lang
+		}
+	}
+
+	return i18n.Normalize(event.From.LanguageCode)
+}
+
+func (h *Handler) handleCommand(event chat.Event) {
+	lang := h.locale(event)
+
+	switch event.Command {
 	case "start":
-		t.handleStartCommand(message)
+		h.handleStartCommand(event, lang)
 	case "help":
-		t.handleHelpCommand(message)
+		h.handleHelpCommand(event, lang)
+	case "language":
+		h.showLanguageMenu(event.ChatID, lang)
+	case "quizmode":
+		h.showQuizModeMenu(event.ChatID, lang)
+	case "pagesize":
+		h.showPageSizeMenu(event.ChatID, lang)
+	case "find":
+		h.word.findWords(event.ChatID, event.From.ID, event.Args, lang)
+	case "word":
+		h.word.sendSavedWord(event.ChatID, event.From.ID, event.Args, lang)
+	case "wotd":
+		h.wotd.sendWordOfDay(event.ChatID, event.From.ID, lang)
+	case "listen":
+		h.quiz.sendListeningQuiz(event.ChatID, event.From.ID, lang)
+	case clozeType, synonymType, definitionType:
+		h.quiz.sendWordQuiz(event.ChatID, event.From.ID, event.Command, lang)
+	case "groupquiz":
+		h.group.sendGroupQuiz(event, lang)
+	case "leaderboard":
+		h.group.sendLeaderboard(event, lang)
+	case commandStatsGlobal, commandBroadcast, commandUser, commandBan, commandUnban:
+		h.admin.handleCommand(event, lang)
 	default:
-		msg := tgbotapi.NewMessage(message.Chat.ID, "Неизвестная команда. Используй /start")
-		sendMessage(t.bot, msg)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "command.unknown"))
+		sendMessage(h.bot, msg)
 	}
 }
 
-func (t *TelegramAPI) handleStartCommand(message *tgbotapi.Message) {
-	welcomeText := "🤖 Привет! Я — бот для изучения английского языка!\n\n" +
-		"✨ Что я умею:\n" +
-		"• 📅 Показывать новое слово\n" +
-		"• 🧠 Проводить викторины\n" +
-		"• 📚 Помогать запоминать новые слова\n" +
-		"• 🔔 Напоминать учиться\n\n" +
-		"Нажми кнопку ниже, чтобы начать!"
+// handleStartCommand runs the onboarding wizard for new users, the others get
+// the main menu straight away. Groups get no menu, only the group commands.
+func (h *Handler) handleStartCommand(event chat.Event, lang string) {
+	if event.Group {
+		h.group.sendHelp(event.ChatID, lang)
+		return
+	}
+	if event.From.ID != 0 && !h.onboarded(event.From.ID) {
+		h.startOnboarding(event.ChatID, lang)
+		return
+	}
 
-	keyboard := t.generateMenuKeyboard()
+	h.showWelcome(event.ChatID, lang)
+}
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, welcomeText)
-	msg.ReplyMarkup = keyboard
+func (h *Handler) showWelcome(chatID int64, lang string) {
+	msg := chat.NewMessage(chatID, i18n.T(lang, "start.welcome"))
+	msg.Menu = h.generateMenuKeyboard(lang)
 
-	sendMessage(t.bot, msg)
+	sendMessage(h.bot, msg)
 }
 
-func (t *TelegramAPI) showMainMenu(message *tgbotapi.Message) {
-	keyboard := t.generateMenuKeyboard()
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, "🏠 Главное меню:")
-	msg.ReplyMarkup = keyboard
+func (h *Handler) showMainMenu(chatID int64, lang string) {
+	msg := chat.NewMessage(chatID, i18n.T(lang, "menu.main"))
+	msg.Menu = h.generateMenuKeyboard(lang)
 
-	sendMessage(t.bot, msg)
+	sendMessage(h.bot, msg)
 }
 
-func (t *TelegramAPI) generateMenuKeyboard() tgbotapi.ReplyKeyboardMarkup {
-	keyboard := tgbotapi.NewReplyKeyboard(
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(ButtonNewWord),
-			tgbotapi.NewKeyboardButton(ButtonQuiz),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(ButtonMyWords),
-			tgbotapi.NewKeyboardButton(ButtonProgress),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(ButtonHelp),
-		),
+func (h *Handler) generateMenuKeyboard(lang string) [][]string {
+	return menu(lang,
+		[]string{ButtonNewWord, ButtonQuiz},
+		[]string{ButtonMyWords, ButtonProgress},
+		[]string{ButtonHelp, ButtonLanguage},
 	)
+}
 
-	keyboard.ResizeKeyboard = true
-	keyboard.OneTimeKeyboard = false
-
+func menu(lang string, rows ...[]string) [][]string {
+	keyboard := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		texts := make([]string, 0, len(row))
+		for _, key := range row {
+			texts = append(texts, i18n.T(lang, key))
+		}
+		keyboard = append(keyboard, texts)
+	}
 	return keyboard
 }
 
-func (t *TelegramAPI) handleHelpCommand(message *tgbotapi.Message) {
-	helpText := `
-📚 Доступные команды:
-/start — запустить бота
-/help — это сообщение
-
-🎯 Используй кнопки:
-• "Слово дня" — новое слово каждый день
-• "Викторина" — проверь свои знания
-• "Мой прогресс" — сколько слов выучено
-• "Помощь" — подсказки и контакты
-`
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, helpText)
-	sendMessage(t.bot, msg)
+func (h *Handler) handleHelpCommand(event chat.Event, lang string) {
+	if event.Group {
+		h.group.sendHelp(event.ChatID, lang)
+		return
+	}
+	msg := chat.NewMessage(event.ChatID, i18n.T(lang, "help.text"))
+	sendMessage(h.bot, msg)
 }
 
-func (t *TelegramAPI) handleMessage(message *tgbotapi.Message) {
-	if message.From == nil {
-		log.Printf("Message without sender: %d", message.Chat.ID)
+func (h *Handler) handleMessage(event chat.Event) {
+	if event.From.ID == 0 {
+		log.Printf("Message without sender: %d", event.ChatID)
 		return
 	}
-	userID := message.From.ID
-	text := message.Text
+	userID := event.From.ID
+	lang := h.locale(event)
 
-	switch {
-	case text == ButtonNewWord:
-		t.word.sendNewWord(message, userID)
-	case text == ButtonQuiz:
-		t.quiz.sendNewQuiz(message, userID)
-	case text == ButtonMyWords:
-		t.showMyWordsMenu(message)
-	case text == ButtonProgress:
-		t.showProgressMenu(message)
-	case text == ButtonWordProgress:
-		t.word.sendWordStats(message)
-	case text == ButtonQuizProgress:
-		t.quiz.sendQuizStats(message)
-	case text == ButtonLearnedWords:
-		t.word.showWords(message, userID, 0, true)
-	case text == ButtonNotLearnedWords:
-		t.word.showWords(message, userID, 0, false)
-	case text == ButtonMainMenu || text == ButtonBack:
-		t.showMainMenu(message)
-	case text == ButtonHelp:
-		t.handleHelpCommand(message)
+	if event.Document != nil {
+		if !event.Group {
+			h.text.sendFileWords(event, lang)
+		}
+		return
+	}
+
+	button, _ := i18n.Match(event.Text)
+
+	switch button {
+	case ButtonNewWord:
+		h.word.sendNewWord(event.ChatID, userID, lang)
+	case ButtonQuiz:
+		h.quiz.sendNewQuiz(event.ChatID, userID, lang)
+	case ButtonMyWords:
+		h.showMyWordsMenu(event, lang)
+	case ButtonProgress:
+		h.stats.sendProgress(event.ChatID, userID, lang)
+		h.showProgressMenu(event, lang)
+	case ButtonWordProgress:
+		h.word.sendWordStats(event.ChatID, userID, lang)
+	case ButtonQuizProgress:
+		h.quiz.sendQuizStats(event.ChatID, userID, lang)
+	case ButtonCharts:
+		h.stats.sendCharts(event.ChatID, userID, lang)
+	case ButtonAchievements:
+		h.game.sendProfile(event.ChatID, userID, lang)
+	case ButtonLearnedWords:
+		h.word.showWords(event.ChatID, userID, models.WordList{Deck: models.DeckLearned}, lang)
+	case ButtonNotLearnedWords:
+		h.word.showWords(event.ChatID, userID, models.WordList{Deck: models.DeckLearning}, lang)
+	case ButtonHardWords:
+		h.quiz.showHardWords(event.ChatID, userID, lang)
+	case ButtonMainMenu, ButtonBack:
+		h.showMainMenu(event.ChatID, lang)
+	case ButtonHelp:
+		h.handleHelpCommand(event, lang)
+	case ButtonLanguage:
+		h.showLanguageMenu(event.ChatID, lang)
 
 	default:
-		msg := tgbotapi.NewMessage(message.Chat.ID, "Я не понял. Используй кнопки ниже.")
-		sendMessage(t.bot, msg)
+		// Members of a group talk to each other, not to the bot.
+		if event.Group {
+			return
+		}
+		if isText(event.Text) {
+			h.text.sendWords(event.ChatID, userID, event.Text, lang)
+			return
+		}
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "message.unknown"))
+		sendMessage(h.bot, msg)
 	}
 }
 
-func (t *TelegramAPI) showProgressMenu(message *tgbotapi.Message) {
-	keyboard := tgbotapi.NewReplyKeyboard(
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(ButtonWordProgress),
-			tgbotapi.NewKeyboardButton(ButtonQuizProgress),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(ButtonBack),
-		),
+func (h *Handler) showProgressMenu(event chat.Event, lang string) {
+	msg := chat.NewMessage(event.ChatID, i18n.T(lang, "menu.progress"))
+	msg.Menu = menu(lang,
+		[]string{ButtonWordProgress, ButtonQuizProgress},
+		[]string{ButtonCharts, ButtonAchievements},
+		[]string{ButtonBack},
 	)
 
-	keyboard.ResizeKeyboard = true
-	keyboard.OneTimeKeyboard = false
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, "Выбери тип статистики:")
-	msg.ReplyMarkup = keyboard
-
-	sendMessage(t.bot, msg)
+	sendMessage(h.bot, msg)
 }
 
-func (t *TelegramAPI) showMyWordsMenu(message *tgbotapi.Message) {
-	keyboard := tgbotapi.NewReplyKeyboard(
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(ButtonLearnedWords),
-			tgbotapi.NewKeyboardButton(ButtonNotLearnedWords),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton(ButtonBack),
-		),
+func (h *Handler) showMyWordsMenu(event chat.Event, lang string) {
+	msg := chat.NewMessage(event.ChatID, i18n.T(lang, "menu.my_words"))
+	msg.Menu = menu(lang,
+		[]string{ButtonLearnedWords, ButtonNotLearnedWords},
+		[]string{ButtonHardWords},
+		[]string{ButtonBack},
 	)
 
-	keyboard.ResizeKeyboard = true
-	keyboard.OneTimeKeyboard = false
+	sendMessage(h.bot, msg)
+}
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, "Выбери тип слов:")
-	msg.ReplyMarkup = keyboard
+func (h *Handler) handleCallbackQuery(event chat.Event) {
+	data := event.Data
+	lang := h.locale(event)
 
-	sendMessage(t.bot, msg)
-}
+	if strings.HasPrefix(data, groupQuizPrefix) {
+		h.group.handleCallback(event, lang)
+		return
+	}
+	if strings.HasPrefix(data, inlineSavePrefix) {
+		h.inline.saveWord(event, lang)
+		return
+	}
+	if strings.HasPrefix(data, textPrefix) {
+		h.text.handleCallback(event, lang)
+		return
+	}
 
-func (t *TelegramAPI) handleCallbackQuery(query *tgbotapi.CallbackQuery) {
-	callback := tgbotapi.NewCallback(query.ID, "")
-	callback.ShowAlert = false
-	if _, err := t.bot.Request(callback); err != nil {
+	if err := h.bot.AnswerCallback(event.CallbackID, ""); err != nil {
 		log.Printf("Failed to answer callback: %v", err)
 	}
 
-	data := query.Data
-
 	switch {
-	case data == "know" || data == "repeat" || data == "new_word":
-		t.word.handleWordCallbackQuery(query)
+	case data == "know" || data == "repeat" || data == "new_word" || data == pronounceCallback:
+		h.word.handleWordCallbackQuery(event, lang)
+
+	case isWordListCallback(data):
+		h.word.wordHandlePagination(event, lang)
+
+	case strings.HasPrefix(data, "quiz_") || data == "new_quiz" || data == "new_drill" || data == newListeningCallback || isWordQuizCallback(data):
+		h.quiz.handleQuizCallbackQuery(event, lang)
+
+	case strings.HasPrefix(data, statsPrefix):
+		h.stats.handlePeriodCallback(event, lang)
+
+	case strings.HasPrefix(data, wotdPrefix):
+		h.wotd.handleSubscriptionCallback(event, lang)
+
+	case strings.HasPrefix(data, onboardingPrefix):
+		h.handleOnboardingCallback(event, lang)
+
+	case strings.HasPrefix(data, languagePrefix):
+		h.handleLanguageCallback(event)
 
-	case strings.HasPrefix(data, "f_") || strings.HasPrefix(data, "t_"):
-		t.word.wordHandlePagination(query)
+	case strings.HasPrefix(data, quizModePrefix):
+		h.handleQuizModeCallback(event, lang)
 
-	case strings.HasPrefix(data, "quiz_") || data == "new_quiz":
-		t.quiz.handleQuizCallbackQuery(query)
+	case strings.HasPrefix(data, pageSizePrefix):
+		h.handlePageSizeCallback(event, lang)
 
 	case data == "main_menu":
-		t.showMainMenu(query.Message)
+		h.showMainMenu(event.ChatID, lang)
 
 	default:
-		log.Printf("Unknown callback data: %s from user %d", data, query.From.ID)
+		log.Printf("Unknown callback data: %s from user %d", data, event.From.ID)
 	}
 }
@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+)
+
+const (
+	inlineSavePrefix = "isave_"
+
+	// inlineDebounce is how long a query waits for the user to stop typing.
+	// Telegram sends one per keystroke, only the last one is answered.
+	inlineDebounce = 400 * time.Millisecond
+)
+
+// InlineT answers inline queries, typed after the bot's name in any chat,
+// with translations and the user's own words.
+type InlineT struct {
+	bot      BotSender
+	service  WordSI
+	debounce *debouncer
+}
+
+func NewInlineTAPI(bot BotSender, service WordSI) *InlineT {
+	return &InlineT{
+		bot:      bot,
+		service:  service,
+		debounce: newDebouncer(inlineDebounce),
+	}
+}
+
+// handleQuery answers the query once the user stops typing. It doesn't block,
+// so other updates are handled meanwhile.
+func (t *InlineT) handleQuery(event chat.Event, lang string) {
+	go func() {
+		if t.debounce.wait(event.From.ID) {
+			t.answerQuery(event, lang)
+		}
+	}()
+}
+
+func (t *InlineT) answerQuery(event chat.Event, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	found, err := t.service.LookupWord(ctx, event.From.ID, event.Text, lang)
+	if err != nil {
+		log.Printf("Failed to look up %q for user %d: %v", event.Text, event.From.ID, err)
+		return
+	}
+
+	results := make([]chat.InlineResult, 0, len(found))
+	for i, f := range found {
+		result := chat.InlineResult{
+			ID:          strconv.Itoa(i),
+			Title:       f.Card.WordText,
+			Description: f.Card.Translation,
+			Text:        f.Text,
+			Markdown:    true,
+		}
+		if f.Saved {
+			result.Title = "📚 " + result.Title
+		} else {
+			result.Buttons = [][]chat.Button{
+				chat.Row(chat.NewButton(i18n.T(lang, "inline_mode.save"), inlineSavePrefix+f.Card.WordText)),
+			}
+		}
+		results = append(results, result)
+	}
+
+	if err := t.bot.AnswerInline(event.QueryID, results); err != nil {
+		log.Printf("Failed to answer inline query of user %d: %v", event.From.ID, err)
+	}
+}
+
+// saveWord adds the word of an inline result to the list of whoever pressed
+// its button. The message was sent by the user on their own behalf, so the
+// bot can only answer with a notification.
+func (t *InlineT) saveWord(event chat.Event, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	word := strings.TrimPrefix(event.Data, inlineSavePrefix)
+
+	text := i18n.T(lang, "inline_mode.saved", word)
+	if _, err := t.service.SaveLookupWord(ctx, event.From.ID, word); err != nil {
+		log.Printf("Failed to save inline word %q for user %d: %v", word, event.From.ID, err)
+		text = i18n.T(lang, "inline_mode.error")
+	}
+
+	if err := t.bot.AnswerCallback(event.CallbackID, text); err != nil {
+		log.Printf("Failed to answer callback: %v", err)
+	}
+}
+
+// debouncer lets through only the last of the calls a user makes within the
+// delay.
+type debouncer struct {
+	delay time.Duration
+
+	mu   sync.Mutex
+	seq  uint64
+	last map[int64]uint64
+}
+
+func newDebouncer(delay time.Duration) *debouncer {
+	return &debouncer{delay: delay, last: make(map[int64]uint64)}
+}
+
+// wait sleeps for the delay and reports whether no newer call was made for
+// the user meanwhile.
+func (d *debouncer) wait(userID int64) bool {
+	d.mu.Lock()
+	d.seq++
+	seq := d.seq
+	d.last[userID] = seq
+	d.mu.Unlock()
+
+	time.Sleep(d.delay)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.last[userID] != seq {
+		return false
+	}
+	delete(d.last, userID)
+	return true
+}
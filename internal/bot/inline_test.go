@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInlineT_answerQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    func(*mock_bot.MockServiceI)
+		want []chat.InlineResult
+	}{
+		{
+			name: "translation and saved word",
+			f: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().LookupWord(gomock.Any(), int64(1), "apple", i18n.Default).Return([]models.LookupResult{
+					{Card: models.WordCard{WordText: "apple", Translation: "яблоко"}, Text: "**apple** → *яблоко*"},
+					{Card: models.WordCard{WordText: "applesauce", Translation: "яблочное пюре"}, Text: "**applesauce**", Saved: true},
+				}, nil)
+			},
+			want: []chat.InlineResult{
+				{
+					ID:          "0",
+					Title:       "apple",
+					Description: "яблоко",
+					Text:        "**apple** → *яблоко*",
+					Markdown:    true,
+					Buttons: [][]chat.Button{
+						chat.Row(chat.NewButton(i18n.T(i18n.Default, "inline_mode.save"), "isave_apple")),
+					},
+				},
+				{
+					ID:          "1",
+					Title:       "📚 applesauce",
+					Description: "яблочное пюре",
+					Text:        "**applesauce**",
+					Markdown:    true,
+				},
+			},
+		},
+		{
+			name: "nothing found",
+			f: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().LookupWord(gomock.Any(), int64(1), "apple", i18n.Default).Return(nil, nil)
+			},
+			want: []chat.InlineResult{},
+		},
+		{
+			name: "lookup error isn't answered",
+			f: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().LookupWord(gomock.Any(), int64(1), "apple", i18n.Default).Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock_bot.NewMockServiceI(ctrl)
+			tt.f(mockService)
+			mockBot := &mock_bot.MockBot{}
+
+			inlineT := NewInlineTAPI(mockBot, mockService)
+			inlineT.answerQuery(chat.Event{Type: chat.EventInline, QueryID: "q", Text: "apple", From: chat.User{ID: 1}}, i18n.Default)
+
+			got, ok := mockBot.InlineAnswers["q"]
+			if tt.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInlineT_saveWord(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+		ms.EXPECT().SaveLookupWord(gomock.Any(), int64(2), "apple").Return(models.WordCard{WordText: "apple"}, nil)
+		ms.EXPECT().SaveLookupWord(gomock.Any(), int64(2), "qwzx").Return(models.WordCard{}, assert.AnError)
+	})
+
+	// Callbacks of inline messages don't come with a chat.
+	handler.Handle(chat.Event{Type: chat.EventCallback, From: chat.User{ID: 2}, CallbackID: "cb1", Data: inlineSavePrefix + "apple"})
+	handler.Handle(chat.Event{Type: chat.EventCallback, From: chat.User{ID: 2}, CallbackID: "cb2", Data: inlineSavePrefix + "qwzx"})
+
+	assert.Equal(t, []string{"cb1", "cb2"}, mb.AnsweredCallbacks)
+	assert.Equal(t, []string{
+		i18n.T(i18n.Default, "inline_mode.saved", "apple"),
+		i18n.T(i18n.Default, "inline_mode.error"),
+	}, mb.CallbackTexts)
+	assert.Empty(t, mb.SentMessages)
+}
+
+func Test_debouncer(t *testing.T) {
+	t.Parallel()
+
+	d := newDebouncer(50 * time.Millisecond)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		passed []int
+	)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if d.wait(1) {
+				mu.Lock()
+				passed = append(passed, i)
+				mu.Unlock()
+			}
+		}(i)
+		time.Sleep(10 * time.Millisecond)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{2}, passed, "only the last call passes")
+	assert.True(t, d.wait(2), "users don't hold each other up")
+	assert.Empty(t, d.last)
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+)
+
+// userLimit keeps users from flooding the bot with messages and button
+// presses, each of which may cost several API calls. Only the first event
+// over the limit gets a reply, so the replies don't flood the chat in turn.
+type userLimit struct {
+	limiter *ratelimit.Limiter
+
+	mu     sync.Mutex
+	warned map[int64]bool
+}
+
+func newUserLimit(rate float64, burst int) *userLimit {
+	l := &userLimit{
+		limiter: ratelimit.New(rate, burst),
+		warned:  make(map[int64]bool),
+	}
+	// A dropped bucket was full, so its user is no longer over the limit.
+	l.limiter.OnDrop(func(userID int64) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.warned, userID)
+	})
+	return l
+}
+
+// allow reports whether the user may go on, and if not, whether they are to
+// be told so.
+func (l *userLimit) allow(userID int64) (ok, warn bool) {
+	if l == nil {
+		return true, false
+	}
+
+	ok = l.limiter.Allow(userID)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if ok {
+		delete(l.warned, userID)
+		return true, false
+	}
+	warn = !l.warned[userID]
+	l.warned[userID] = true
+	return false, warn
+}
+
+// SetRateLimit limits the messages, commands and button presses of every
+// user to rate per second, burst at once. Admins aren't limited.
+func (h *Handler) SetRateLimit(rate float64, burst int) {
+	h.limit = newUserLimit(rate, burst)
+}
+
+// limited tells whether the event is over the user's limit, and asks the
+// user to wait the first time it is. Poll answers and inline queries aren't
+// counted: the former only finish a quiz, the latter are debounced.
+func (h *Handler) limited(event chat.Event) bool {
+	switch event.Type {
+	case chat.EventCommand, chat.EventMessage, chat.EventCallback:
+	default:
+		return false
+	}
+	if event.From.ID == 0 || h.admin.isAdmin(event.From.ID) {
+		return false
+	}
+
+	ok, warn := h.limit.allow(event.From.ID)
+	if ok {
+		return false
+	}
+	log.Printf("Rate limiting user %d", event.From.ID)
+
+	if event.Type == chat.EventCallback {
+		// Callbacks are answered anyway, or the button keeps spinning.
+		notice := ""
+		if warn {
+			notice = i18n.T(h.locale(event), "rate_limit.wait")
+		}
+		if err := h.bot.AnswerCallback(event.CallbackID, notice); err != nil {
+			log.Printf("Failed to answer callback: %v", err)
+		}
+		return true
+	}
+
+	if warn {
+		sendMessage(h.bot, chat.NewMessage(event.ChatID, i18n.T(h.locale(event), "rate_limit.wait")))
+	}
+	return true
+}
+
+// errorKey picks the message for a failed request: the one asking to wait
+// when an external API's limit is reached, key otherwise.
+func errorKey(err error, key string) string {
+	if errors.Is(err, ratelimit.ErrLimited) {
+		return "rate_limit.wait"
+	}
+	return key
+}
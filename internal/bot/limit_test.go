@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_rateLimit(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	})
+	h.SetAdmins([]int64{testAdminID})
+	h.SetRateLimit(0.001, 2)
+
+	help := func(userID int64) {
+		h.Handle(chat.Event{Type: chat.EventCommand, ChatID: userID, From: chat.User{ID: userID}, Command: "help"})
+	}
+
+	help(5)
+	help(5)
+	help(5)
+	help(5)
+	require.Len(t, mb.SentMessages, 3, "only the first event over the limit gets a reply")
+	assert.Equal(t, i18n.T(i18n.Default, "rate_limit.wait"), mb.SentMessages[2].(chat.Message).Text)
+
+	h.Handle(chat.Event{Type: chat.EventCallback, ChatID: 5, MessageID: 1, From: chat.User{ID: 5}, CallbackID: "cb", Data: "new_quiz"})
+	assert.Equal(t, []string{""}, mb.CallbackTexts, "callbacks over the limit are answered silently")
+
+	for range 3 {
+		help(testAdminID)
+	}
+	help(6)
+	assert.Len(t, mb.SentMessages, 7, "admins and other users aren't limited")
+}
+
+func Test_userLimit_prunesWarned(t *testing.T) {
+	t.Parallel()
+
+	l := newUserLimit(100, 1)
+	l.allow(5)
+	_, warn := l.allow(5)
+	require.True(t, warn)
+
+	time.Sleep(20 * time.Millisecond)
+	for range 1000 {
+		l.allow(6)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	assert.NotContains(t, l.warned, int64(5), "dropped with the user's bucket")
+}
+
+func Test_errorKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "rate_limit.wait", errorKey(ratelimit.ErrLimited, "word.error"))
+	assert.Equal(t, "word.error", errorKey(assert.AnError, "word.error"))
+}
@@ -0,0 +1,58 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"path"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+const (
+	listeningType        = "listening"
+	newListeningCallback = "new_listening"
+)
+
+// sendListeningQuiz plays a word and asks for its translation. Answers are
+// handled like any other quiz. When the word has no audio it is asked as a
+// regular quiz instead.
+func (t *QuizT) sendListeningQuiz(chatID, userID int64, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if userID == 0 {
+		log.Printf("Message without sender: %d", chatID)
+		return
+	}
+
+	word, options, err := t.service.NewQuiz(ctx, userID)
+	if err != nil {
+		log.Printf("failed to get listening quiz for chat %d: %v", chatID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, errorKey(err, "quiz.error")))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	quiz := models.QuizCard{UserID: userID, Word: word, Type: listeningType}
+
+	audio, err := t.audio.Pronunciation(ctx, models.AudioListening, word, "")
+	if err == nil {
+		// The file name is shown as the title, it must not give the word away.
+		if audio.FileID == "" {
+			audio.Name = models.AudioListening + path.Ext(audio.Name)
+		}
+		err = sendAudio(ctx, t.bot, t.audio, chatID, models.AudioListening, word, audio, i18n.T(lang, "listening.caption"))
+	}
+	if err != nil {
+		log.Printf("no audio for listening quiz of %q, asking a regular quiz: %v", word, err)
+		quiz.Type = quizType
+		question := i18n.T(lang, "listening.no_audio") + "\n\n" + i18n.T(lang, "quiz.question", word)
+		t.sendQuestion(ctx, chatID, quiz, question, options, lang)
+		return
+	}
+
+	t.sendQuestion(ctx, chatID, quiz, i18n.T(lang, "listening.question"), options, lang)
+}
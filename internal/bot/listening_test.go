@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuizT_sendListeningQuiz(t *testing.T) {
+	t.Parallel()
+
+	options := map[string]bool{"яблоко": true, "груша": false}
+
+	tests := []struct {
+		name       string
+		f          func(*mock_bot.MockServiceI, *mock_bot.MockBot)
+		assertFunc func(*testing.T, *QuizT, *mock_bot.MockBot)
+	}{
+		{
+			name: "audio then question",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewQuiz(gomock.Any(), int64(456)).Return("apple", options, nil)
+				ms.EXPECT().Pronunciation(gomock.Any(), models.AudioListening, "apple", "").Return(models.Audio{Name: "apple.mp3", Data: []byte{1}}, nil)
+				ms.EXPECT().SaveAudioFileID(gomock.Any(), models.AudioListening, "apple", "file1").Return(nil)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 2)
+				audio := mb.SentMessages[0].(chat.Message)
+				require.NotNil(t, audio.Audio)
+				assert.NotContains(t, audio.Text, "apple")
+				assert.NotContains(t, audio.Audio.Name, "apple", "the file name is shown as the title")
+				assert.Equal(t, "listening.mp3", audio.Audio.Name)
+
+				question := mb.SentMessages[1].(chat.Message)
+				assert.Equal(t, i18n.T(i18n.Default, "listening.question"), question.Text)
+				require.Len(t, question.Buttons, 1)
+
+				quiz, exists, err := quizT.sessions.GetQuiz(context.Background(), models.SessionKey{ChatID: 123, MessageID: 2})
+				require.NoError(t, err)
+				require.True(t, exists)
+				assert.Equal(t, listeningType, quiz.Type)
+				assert.Equal(t, "яблоко", quiz.Translation)
+			},
+		},
+		{
+			name: "no audio falls back to a regular quiz",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewQuiz(gomock.Any(), int64(456)).Return("apple", options, nil)
+				ms.EXPECT().Pronunciation(gomock.Any(), models.AudioListening, "apple", "").Return(models.Audio{}, models.ErrNoAudio)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				question := mb.SentMessages[0].(chat.Message)
+				assert.Contains(t, question.Text, i18n.T(i18n.Default, "listening.no_audio"))
+				assert.Contains(t, question.Text, "apple")
+
+				quiz, exists, err := quizT.sessions.GetQuiz(context.Background(), models.SessionKey{ChatID: 123, MessageID: 1})
+				require.NoError(t, err)
+				require.True(t, exists)
+				assert.Equal(t, quizType, quiz.Type)
+			},
+		},
+		{
+			name: "quiz error",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewQuiz(gomock.Any(), int64(456)).Return("", nil, assert.AnError)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "quiz.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name: "rate limited",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewQuiz(gomock.Any(), int64(456)).Return("", nil, ratelimit.ErrLimited)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "rate_limit.wait"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quizT := newQuizTMock(t, ctrl, tt.f)
+			mb := quizT.bot.(*mock_bot.MockBot)
+
+			quizT.sendListeningQuiz(123, 456, i18n.Default)
+
+			tt.assertFunc(t, quizT, mb)
+		})
+	}
+}
+
+func TestQuizT_listeningAnswer(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	quizT := newQuizTMock(t, ctrl, func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+		ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, result models.QuizCard) error {
+			assert.Equal(t, listeningType, result.Type)
+			assert.True(t, result.IsCorrect)
+			return nil
+		})
+	})
+	mb := quizT.bot.(*mock_bot.MockBot)
+
+	key := models.SessionKey{ChatID: 123, MessageID: 2}
+	require.NoError(t, quizT.sessions.SetQuiz(context.Background(), key, models.QuizCard{UserID: 456, Word: "apple", Translation: "яблоко", Type: listeningType}))
+
+	quizT.handleQuizCallbackQuery(chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 2, From: chat.User{ID: 456}, Data: "quiz_right"}, i18n.Default)
+
+	require.Len(t, mb.SentMessages, 1)
+	edit := mb.SentMessages[0].(chat.Edit)
+	assert.Contains(t, edit.Text, i18n.T(i18n.Default, "listening.word", "apple"))
+	assert.Equal(t, newListeningCallback, edit.Buttons[0][0].Data)
+}
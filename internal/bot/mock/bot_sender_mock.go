@@ -1,14 +1,70 @@
 package mock_bot
 
-import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"fmt"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+)
 
 type MockBot struct {
-	SentMessages []tgbotapi.Chattable
+	SentMessages      []interface{}
+	AnsweredCallbacks []string
+	// CallbackTexts holds the text of each answered callback.
+	CallbackTexts []string
+	// SendErrors fails sends to the given chats.
+	SendErrors map[int64]error
+	// NoPolls makes the bot behave like a frontend that can't send polls.
+	NoPolls bool
+	// InlineAnswers holds the results of each answered inline query by its id.
+	InlineAnswers map[string][]chat.InlineResult
+	// Files holds the contents of the files users can send, by id.
+	Files map[string][]byte
+}
+
+func (m *MockBot) Send(msg chat.Message) (chat.Sent, error) {
+	if err := m.SendErrors[msg.ChatID]; err != nil {
+		return chat.Sent{}, err
+	}
+	if msg.Poll != nil && m.NoPolls {
+		return chat.Sent{}, chat.ErrUnsupported
+	}
+	m.SentMessages = append(m.SentMessages, msg)
+
+	sent := chat.Sent{MessageID: len(m.SentMessages)}
+	if msg.Poll != nil {
+		sent.PollID = fmt.Sprintf("poll%d", sent.MessageID)
+	}
+	if msg.Audio != nil && msg.Audio.ID == "" {
+		sent.FileID = fmt.Sprintf("file%d", sent.MessageID)
+	}
+	return sent, nil
+}
+
+func (m *MockBot) Edit(edit chat.Edit) error {
+	m.SentMessages = append(m.SentMessages, edit)
+	return nil
+}
+
+func (m *MockBot) AnswerCallback(callbackID, text string) error {
+	m.AnsweredCallbacks = append(m.AnsweredCallbacks, callbackID)
+	m.CallbackTexts = append(m.CallbackTexts, text)
+	return nil
+}
+
+func (m *MockBot) AnswerInline(queryID string, results []chat.InlineResult) error {
+	if m.InlineAnswers == nil {
+		m.InlineAnswers = make(map[string][]chat.InlineResult)
+	}
+	m.InlineAnswers[queryID] = results
+	return nil
 }
 
-func (m *MockBot) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
-	m.SentMessages = append(m.SentMessages, c)
-	return tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 123}}, nil
+func (m *MockBot) DownloadFile(fileID string) ([]byte, error) {
+	data, ok := m.Files[fileID]
+	if !ok {
+		return nil, fmt.Errorf("no file %q", fileID)
+	}
+	return data, nil
 }
 
 func ClearSentMessages(bot *MockBot) {
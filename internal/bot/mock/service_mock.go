@@ -35,6 +35,20 @@ func (m *MockServiceI) EXPECT() *MockServiceIMockRecorder {
 	return m.recorder
 }
 
+// AddGroupResult mocks base method.
+func (m *MockServiceI) AddGroupResult(arg0 context.Context, arg1 models.QuizCard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupResult", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGroupResult indicates an expected call of AddGroupResult.
+func (mr *MockServiceIMockRecorder) AddGroupResult(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupResult", reflect.TypeOf((*MockServiceI)(nil).AddGroupResult), arg0, arg1)
+}
+
 // AddQuizResult mocks base method.
 func (m *MockServiceI) AddQuizResult(arg0 context.Context, arg1 models.QuizCard) error {
 	m.ctrl.T.Helper()
@@ -49,6 +63,20 @@ func (mr *MockServiceIMockRecorder) AddQuizResult(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddQuizResult", reflect.TypeOf((*MockServiceI)(nil).AddQuizResult), arg0, arg1)
 }
 
+// AddTextWords mocks base method.
+func (m *MockServiceI) AddTextWords(arg0 context.Context, arg1 int64, arg2 []models.TextWord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTextWords", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTextWords indicates an expected call of AddTextWords.
+func (mr *MockServiceIMockRecorder) AddTextWords(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTextWords", reflect.TypeOf((*MockServiceI)(nil).AddTextWords), arg0, arg1, arg2)
+}
+
 // AddWord mocks base method.
 func (m *MockServiceI) AddWord(arg0 context.Context, arg1 models.WordCard) error {
 	m.ctrl.T.Helper()
@@ -63,6 +91,201 @@ func (mr *MockServiceIMockRecorder) AddWord(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWord", reflect.TypeOf((*MockServiceI)(nil).AddWord), arg0, arg1)
 }
 
+// Ban mocks base method.
+func (m *MockServiceI) Ban(arg0 context.Context, arg1, arg2 int64, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ban", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ban indicates an expected call of Ban.
+func (mr *MockServiceIMockRecorder) Ban(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ban", reflect.TypeOf((*MockServiceI)(nil).Ban), arg0, arg1, arg2, arg3)
+}
+
+// BroadcastRecipients mocks base method.
+func (m *MockServiceI) BroadcastRecipients(arg0 context.Context) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BroadcastRecipients", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BroadcastRecipients indicates an expected call of BroadcastRecipients.
+func (mr *MockServiceIMockRecorder) BroadcastRecipients(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastRecipients", reflect.TypeOf((*MockServiceI)(nil).BroadcastRecipients), arg0)
+}
+
+// Charts mocks base method.
+func (m *MockServiceI) Charts(arg0 context.Context, arg1 int64, arg2 string) ([]models.Chart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Charts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Chart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Charts indicates an expected call of Charts.
+func (mr *MockServiceIMockRecorder) Charts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Charts", reflect.TypeOf((*MockServiceI)(nil).Charts), arg0, arg1, arg2)
+}
+
+// GlobalStats mocks base method.
+func (m *MockServiceI) GlobalStats(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GlobalStats", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GlobalStats indicates an expected call of GlobalStats.
+func (mr *MockServiceIMockRecorder) GlobalStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GlobalStats", reflect.TypeOf((*MockServiceI)(nil).GlobalStats), arg0, arg1)
+}
+
+// HardWords mocks base method.
+func (m *MockServiceI) HardWords(arg0 context.Context, arg1 int64, arg2 string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardWords", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// HardWords indicates an expected call of HardWords.
+func (mr *MockServiceIMockRecorder) HardWords(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardWords", reflect.TypeOf((*MockServiceI)(nil).HardWords), arg0, arg1, arg2)
+}
+
+// IsBanned mocks base method.
+func (m *MockServiceI) IsBanned(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockServiceIMockRecorder) IsBanned(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*MockServiceI)(nil).IsBanned), arg0, arg1)
+}
+
+// Language mocks base method.
+func (m *MockServiceI) Language(arg0 context.Context, arg1 int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Language", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Language indicates an expected call of Language.
+func (mr *MockServiceIMockRecorder) Language(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Language", reflect.TypeOf((*MockServiceI)(nil).Language), arg0, arg1)
+}
+
+// Leaderboard mocks base method.
+func (m *MockServiceI) Leaderboard(arg0 context.Context, arg1 int64, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Leaderboard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Leaderboard indicates an expected call of Leaderboard.
+func (mr *MockServiceIMockRecorder) Leaderboard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leaderboard", reflect.TypeOf((*MockServiceI)(nil).Leaderboard), arg0, arg1, arg2)
+}
+
+// LookupWord mocks base method.
+func (m *MockServiceI) LookupWord(arg0 context.Context, arg1 int64, arg2, arg3 string) ([]models.LookupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupWord", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.LookupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupWord indicates an expected call of LookupWord.
+func (mr *MockServiceIMockRecorder) LookupWord(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupWord", reflect.TypeOf((*MockServiceI)(nil).LookupWord), arg0, arg1, arg2, arg3)
+}
+
+// MarkWotdSent mocks base method.
+func (m *MockServiceI) MarkWotdSent(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWotdSent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWotdSent indicates an expected call of MarkWotdSent.
+func (mr *MockServiceIMockRecorder) MarkWotdSent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWotdSent", reflect.TypeOf((*MockServiceI)(nil).MarkWotdSent), arg0, arg1)
+}
+
+// NewCloze mocks base method.
+func (m *MockServiceI) NewCloze(arg0 context.Context, arg1 int64) (models.WordQuiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewCloze", arg0, arg1)
+	ret0, _ := ret[0].(models.WordQuiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewCloze indicates an expected call of NewCloze.
+func (mr *MockServiceIMockRecorder) NewCloze(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCloze", reflect.TypeOf((*MockServiceI)(nil).NewCloze), arg0, arg1)
+}
+
+// NewDefinitionQuiz mocks base method.
+func (m *MockServiceI) NewDefinitionQuiz(arg0 context.Context, arg1 int64) (models.WordQuiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewDefinitionQuiz", arg0, arg1)
+	ret0, _ := ret[0].(models.WordQuiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewDefinitionQuiz indicates an expected call of NewDefinitionQuiz.
+func (mr *MockServiceIMockRecorder) NewDefinitionQuiz(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewDefinitionQuiz", reflect.TypeOf((*MockServiceI)(nil).NewDefinitionQuiz), arg0, arg1)
+}
+
+// NewDrill mocks base method.
+func (m *MockServiceI) NewDrill(arg0 context.Context, arg1 int64) (string, map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewDrill", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(map[string]bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NewDrill indicates an expected call of NewDrill.
+func (mr *MockServiceIMockRecorder) NewDrill(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewDrill", reflect.TypeOf((*MockServiceI)(nil).NewDrill), arg0, arg1)
+}
+
 // NewQuiz mocks base method.
 func (m *MockServiceI) NewQuiz(arg0 context.Context, arg1 int64) (string, map[string]bool, error) {
 	m.ctrl.T.Helper()
@@ -79,25 +302,85 @@ func (mr *MockServiceIMockRecorder) NewQuiz(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewQuiz", reflect.TypeOf((*MockServiceI)(nil).NewQuiz), arg0, arg1)
 }
 
+// NewSynonymQuiz mocks base method.
+func (m *MockServiceI) NewSynonymQuiz(arg0 context.Context, arg1 int64) (models.WordQuiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSynonymQuiz", arg0, arg1)
+	ret0, _ := ret[0].(models.WordQuiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewSynonymQuiz indicates an expected call of NewSynonymQuiz.
+func (mr *MockServiceIMockRecorder) NewSynonymQuiz(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSynonymQuiz", reflect.TypeOf((*MockServiceI)(nil).NewSynonymQuiz), arg0, arg1)
+}
+
+// Profile mocks base method.
+func (m *MockServiceI) Profile(arg0 context.Context, arg1 int64, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockServiceIMockRecorder) Profile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockServiceI)(nil).Profile), arg0, arg1, arg2)
+}
+
+// Progress mocks base method.
+func (m *MockServiceI) Progress(arg0 context.Context, arg1 int64, arg2 models.StatsPeriod, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Progress indicates an expected call of Progress.
+func (mr *MockServiceIMockRecorder) Progress(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockServiceI)(nil).Progress), arg0, arg1, arg2, arg3)
+}
+
+// Pronunciation mocks base method.
+func (m *MockServiceI) Pronunciation(arg0 context.Context, arg1, arg2, arg3 string) (models.Audio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pronunciation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Audio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pronunciation indicates an expected call of Pronunciation.
+func (mr *MockServiceIMockRecorder) Pronunciation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pronunciation", reflect.TypeOf((*MockServiceI)(nil).Pronunciation), arg0, arg1, arg2, arg3)
+}
+
 // QuizStats mocks base method.
-func (m *MockServiceI) QuizStats(arg0 context.Context, arg1 int64) (string, error) {
+func (m *MockServiceI) QuizStats(arg0 context.Context, arg1 int64, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QuizStats", arg0, arg1)
+	ret := m.ctrl.Call(m, "QuizStats", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QuizStats indicates an expected call of QuizStats.
-func (mr *MockServiceIMockRecorder) QuizStats(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceIMockRecorder) QuizStats(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuizStats", reflect.TypeOf((*MockServiceI)(nil).QuizStats), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuizStats", reflect.TypeOf((*MockServiceI)(nil).QuizStats), arg0, arg1, arg2)
 }
 
 // RandomWord mocks base method.
-func (m *MockServiceI) RandomWord(arg0 context.Context) (string, models.WordCard, error) {
+func (m *MockServiceI) RandomWord(arg0 context.Context, arg1 string) (string, models.WordCard, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RandomWord", arg0)
+	ret := m.ctrl.Call(m, "RandomWord", arg0, arg1)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(models.WordCard)
 	ret2, _ := ret[2].(error)
@@ -105,28 +388,234 @@ func (m *MockServiceI) RandomWord(arg0 context.Context) (string, models.WordCard
 }
 
 // RandomWord indicates an expected call of RandomWord.
-func (mr *MockServiceIMockRecorder) RandomWord(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceIMockRecorder) RandomWord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomWord", reflect.TypeOf((*MockServiceI)(nil).RandomWord), arg0, arg1)
+}
+
+// RecordActivity mocks base method.
+func (m *MockServiceI) RecordActivity(arg0 context.Context, arg1 int64, arg2 models.Activity, arg3 string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordActivity", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RecordActivity indicates an expected call of RecordActivity.
+func (mr *MockServiceIMockRecorder) RecordActivity(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordActivity", reflect.TypeOf((*MockServiceI)(nil).RecordActivity), arg0, arg1, arg2, arg3)
+}
+
+// SaveAudioFileID mocks base method.
+func (m *MockServiceI) SaveAudioFileID(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAudioFileID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAudioFileID indicates an expected call of SaveAudioFileID.
+func (mr *MockServiceIMockRecorder) SaveAudioFileID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAudioFileID", reflect.TypeOf((*MockServiceI)(nil).SaveAudioFileID), arg0, arg1, arg2, arg3)
+}
+
+// SaveLookupWord mocks base method.
+func (m *MockServiceI) SaveLookupWord(arg0 context.Context, arg1 int64, arg2 string) (models.WordCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLookupWord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.WordCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveLookupWord indicates an expected call of SaveLookupWord.
+func (mr *MockServiceIMockRecorder) SaveLookupWord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLookupWord", reflect.TypeOf((*MockServiceI)(nil).SaveLookupWord), arg0, arg1, arg2)
+}
+
+// SavedWord mocks base method.
+func (m *MockServiceI) SavedWord(arg0 context.Context, arg1 int64, arg2, arg3 string) (string, models.WordCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavedWord", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(models.WordCard)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SavedWord indicates an expected call of SavedWord.
+func (mr *MockServiceIMockRecorder) SavedWord(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavedWord", reflect.TypeOf((*MockServiceI)(nil).SavedWord), arg0, arg1, arg2, arg3)
+}
+
+// SetLanguage mocks base method.
+func (m *MockServiceI) SetLanguage(arg0 context.Context, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLanguage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLanguage indicates an expected call of SetLanguage.
+func (mr *MockServiceIMockRecorder) SetLanguage(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomWord", reflect.TypeOf((*MockServiceI)(nil).RandomWord), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLanguage", reflect.TypeOf((*MockServiceI)(nil).SetLanguage), arg0, arg1, arg2)
+}
+
+// SubscribeWotd mocks base method.
+func (m *MockServiceI) SubscribeWotd(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeWotd", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeWotd indicates an expected call of SubscribeWotd.
+func (mr *MockServiceIMockRecorder) SubscribeWotd(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeWotd", reflect.TypeOf((*MockServiceI)(nil).SubscribeWotd), arg0, arg1, arg2)
+}
+
+// TextWords mocks base method.
+func (m *MockServiceI) TextWords(arg0 context.Context, arg1 int64, arg2 string) ([]models.TextWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TextWords", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.TextWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TextWords indicates an expected call of TextWords.
+func (mr *MockServiceIMockRecorder) TextWords(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TextWords", reflect.TypeOf((*MockServiceI)(nil).TextWords), arg0, arg1, arg2)
+}
+
+// TouchUser mocks base method.
+func (m *MockServiceI) TouchUser(arg0 context.Context, arg1 models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchUser indicates an expected call of TouchUser.
+func (mr *MockServiceIMockRecorder) TouchUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchUser", reflect.TypeOf((*MockServiceI)(nil).TouchUser), arg0, arg1)
+}
+
+// Unban mocks base method.
+func (m *MockServiceI) Unban(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unban", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unban indicates an expected call of Unban.
+func (mr *MockServiceIMockRecorder) Unban(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unban", reflect.TypeOf((*MockServiceI)(nil).Unban), arg0, arg1)
+}
+
+// UnsubscribeWotd mocks base method.
+func (m *MockServiceI) UnsubscribeWotd(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeWotd", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsubscribeWotd indicates an expected call of UnsubscribeWotd.
+func (mr *MockServiceIMockRecorder) UnsubscribeWotd(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeWotd", reflect.TypeOf((*MockServiceI)(nil).UnsubscribeWotd), arg0, arg1)
+}
+
+// UpdateUserSettings mocks base method.
+func (m *MockServiceI) UpdateUserSettings(arg0 context.Context, arg1 int64, arg2 models.UserSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSettings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserSettings indicates an expected call of UpdateUserSettings.
+func (mr *MockServiceIMockRecorder) UpdateUserSettings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSettings", reflect.TypeOf((*MockServiceI)(nil).UpdateUserSettings), arg0, arg1, arg2)
+}
+
+// UserInfo mocks base method.
+func (m *MockServiceI) UserInfo(arg0 context.Context, arg1 int64, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserInfo", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserInfo indicates an expected call of UserInfo.
+func (mr *MockServiceIMockRecorder) UserInfo(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserInfo", reflect.TypeOf((*MockServiceI)(nil).UserInfo), arg0, arg1, arg2)
+}
+
+// UserSettings mocks base method.
+func (m *MockServiceI) UserSettings(arg0 context.Context, arg1 int64) (models.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSettings", arg0, arg1)
+	ret0, _ := ret[0].(models.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSettings indicates an expected call of UserSettings.
+func (mr *MockServiceIMockRecorder) UserSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSettings", reflect.TypeOf((*MockServiceI)(nil).UserSettings), arg0, arg1)
+}
+
+// WordOfDay mocks base method.
+func (m *MockServiceI) WordOfDay(arg0 context.Context, arg1 string) (string, models.WordCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WordOfDay", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(models.WordCard)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WordOfDay indicates an expected call of WordOfDay.
+func (mr *MockServiceIMockRecorder) WordOfDay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WordOfDay", reflect.TypeOf((*MockServiceI)(nil).WordOfDay), arg0, arg1)
 }
 
 // WordStat mocks base method.
-func (m *MockServiceI) WordStat(arg0 context.Context, arg1 int64) (string, error) {
+func (m *MockServiceI) WordStat(arg0 context.Context, arg1 int64, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WordStat", arg0, arg1)
+	ret := m.ctrl.Call(m, "WordStat", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // WordStat indicates an expected call of WordStat.
-func (mr *MockServiceIMockRecorder) WordStat(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceIMockRecorder) WordStat(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WordStat", reflect.TypeOf((*MockServiceI)(nil).WordStat), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WordStat", reflect.TypeOf((*MockServiceI)(nil).WordStat), arg0, arg1, arg2)
 }
 
 // Words mocks base method.
-func (m *MockServiceI) Words(arg0 context.Context, arg1 int64, arg2 int, arg3 bool) (string, bool, error) {
+func (m *MockServiceI) Words(arg0 context.Context, arg1 int64, arg2 models.WordList, arg3 string) (string, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Words", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(string)
@@ -140,3 +629,33 @@ func (mr *MockServiceIMockRecorder) Words(arg0, arg1, arg2, arg3 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Words", reflect.TypeOf((*MockServiceI)(nil).Words), arg0, arg1, arg2, arg3)
 }
+
+// WotdSubscribed mocks base method.
+func (m *MockServiceI) WotdSubscribed(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WotdSubscribed", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WotdSubscribed indicates an expected call of WotdSubscribed.
+func (mr *MockServiceIMockRecorder) WotdSubscribed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WotdSubscribed", reflect.TypeOf((*MockServiceI)(nil).WotdSubscribed), arg0, arg1)
+}
+
+// WotdSubscribers mocks base method.
+func (m *MockServiceI) WotdSubscribers(arg0 context.Context) ([]models.WotdSubscriber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WotdSubscribers", arg0)
+	ret0, _ := ret[0].([]models.WotdSubscriber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WotdSubscribers indicates an expected call of WotdSubscribers.
+func (mr *MockServiceIMockRecorder) WotdSubscribers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WotdSubscribers", reflect.TypeOf((*MockServiceI)(nil).WotdSubscribers), arg0)
+}
@@ -0,0 +1,166 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+const (
+	onboardingPrefix = "onb_"
+	onboardingTarget = onboardingPrefix + "lang_"
+	onboardingLevel  = onboardingPrefix + "level_"
+	onboardingGoal   = onboardingPrefix + "goal_"
+	// onboardingQuizGoal follows onboardingGoal for goals in quiz answers,
+	// "onb_goal_<n>" is a goal in words.
+	onboardingQuizGoal = models.GoalQuiz + "_"
+)
+
+// onboarded reports whether the user has finished the /start wizard. If the
+// settings can't be read the wizard is skipped rather than shown again.
+func (h *Handler) onboarded(userID int64) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	settings, err := h.users.UserSettings(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get settings for user %d: %v", userID, err)
+		return true
+	}
+
+	return settings.Onboarded
+}
+
+func (h *Handler) startOnboarding(chatID int64, lang string) {
+	msg := chat.NewMessage(chatID, i18n.T(lang, "onboarding.target_language"))
+	msg.Buttons = targetLanguageButtons(lang)
+
+	sendMessage(h.bot, msg)
+}
+
+// handleOnboardingCallback stores the answer to one wizard step and turns the
+// same message into the next step.
+func (h *Handler) handleOnboardingCallback(event chat.Event, lang string) {
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	var (
+		patch   models.UserSettings
+		text    string
+		buttons [][]chat.Button
+	)
+
+	data := event.Data
+	switch {
+	case strings.HasPrefix(data, onboardingTarget):
+		patch.TargetLanguage = strings.TrimPrefix(data, onboardingTarget)
+		text, buttons = i18n.T(lang, "onboarding.level"), levelButtons(lang)
+	case strings.HasPrefix(data, onboardingLevel):
+		patch.Level = strings.TrimPrefix(data, onboardingLevel)
+		text, buttons = i18n.T(lang, "onboarding.goal"), goalButtons(lang)
+	case strings.HasPrefix(data, onboardingGoal):
+		value, goalType := strings.TrimPrefix(data, onboardingGoal), models.GoalWords
+		if v, ok := strings.CutPrefix(value, onboardingQuizGoal); ok {
+			value, goalType = v, models.GoalQuiz
+		}
+		goal, err := strconv.Atoi(value)
+		if err != nil {
+			log.Printf("Invalid onboarding goal: %s", data)
+			return
+		}
+		patch.DailyGoal = goal
+		patch.GoalType = goalType
+		patch.Onboarded = true
+	default:
+		log.Printf("Unknown onboarding callback: %s", data)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.users.UpdateUserSettings(ctx, event.From.ID, patch); err != nil {
+		log.Printf("Failed to save onboarding step for user %d: %v", event.From.ID, err)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "onboarding.error"))
+		sendMessage(h.bot, msg)
+		return
+	}
+
+	if !patch.Onboarded {
+		edit := chat.NewEdit(event.ChatID, event.MessageID, text)
+		edit.Buttons = buttons
+		editMessage(h.bot, edit)
+		return
+	}
+
+	settings, err := h.users.UserSettings(ctx, event.From.ID)
+	if err != nil {
+		log.Printf("Failed to get settings for user %d: %v", event.From.ID, err)
+	}
+	editMessage(h.bot, chat.NewEdit(event.ChatID, event.MessageID, onboardingSummary(lang, settings)))
+
+	h.showWelcome(event.ChatID, lang)
+}
+
+func onboardingSummary(lang string, settings models.UserSettings) string {
+	if settings.TargetLanguage == "" || settings.Level == "" || settings.DailyGoal == 0 {
+		return i18n.T(lang, "onboarding.done_short")
+	}
+
+	key := "onboarding.done"
+	if settings.GoalType == models.GoalQuiz {
+		key = "onboarding.done_quiz"
+	}
+
+	return i18n.T(lang, key,
+		i18n.T(lang, "target."+settings.TargetLanguage),
+		i18n.T(lang, "level."+settings.Level),
+		settings.DailyGoal,
+	)
+}
+
+func targetLanguageButtons(lang string) [][]chat.Button {
+	buttons := make([][]chat.Button, 0, len(models.TargetLanguages))
+	for _, code := range models.TargetLanguages {
+		buttons = append(buttons, chat.Row(chat.NewButton(i18n.T(lang, "target."+code), onboardingTarget+code)))
+	}
+	return buttons
+}
+
+func levelButtons(lang string) [][]chat.Button {
+	buttons := make([]chat.Button, 0, len(models.Levels))
+	for _, level := range models.Levels {
+		buttons = append(buttons, chat.NewButton(i18n.T(lang, "level."+level), onboardingLevel+level))
+	}
+	return pairs(buttons)
+}
+
+// goalButtons offers the goals in words, then the goals in quiz answers.
+func goalButtons(lang string) [][]chat.Button {
+	words := make([]chat.Button, 0, len(models.DailyGoals))
+	for _, goal := range models.DailyGoals {
+		words = append(words, chat.NewButton(i18n.T(lang, "onboarding.goal_option", goal), onboardingGoal+strconv.Itoa(goal)))
+	}
+	quiz := make([]chat.Button, 0, len(models.QuizGoals))
+	for _, goal := range models.QuizGoals {
+		quiz = append(quiz, chat.NewButton(i18n.T(lang, "onboarding.goal_option_quiz", goal), onboardingGoal+onboardingQuizGoal+strconv.Itoa(goal)))
+	}
+	return append(pairs(words), pairs(quiz)...)
+}
+
+// pairs lays buttons out two per row.
+func pairs(buttons []chat.Button) [][]chat.Button {
+	rows := make([][]chat.Button, 0, (len(buttons)+1)/2)
+	for i := 0; i < len(buttons); i += 2 {
+		rows = append(rows, buttons[i:min(i+2, len(buttons))])
+	}
+	return rows
+}
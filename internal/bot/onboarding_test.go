@@ -0,0 +1,202 @@
+package bot
+
+import (
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_startCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock_bot.MockServiceI)
+		check     func(*testing.T, chat.Message)
+	}{
+		{
+			name: "new user gets the wizard",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{Level: "B1"}, nil)
+			},
+			check: func(t *testing.T, msg chat.Message) {
+				assert.Equal(t, i18n.T(i18n.Default, "onboarding.target_language"), msg.Text)
+				assert.Empty(t, msg.Menu)
+				require.Len(t, msg.Buttons, len(models.TargetLanguages))
+				assert.Equal(t, "onb_lang_en", msg.Buttons[0][0].Data)
+			},
+		},
+		{
+			name: "onboarded user gets the menu",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{Onboarded: true}, nil)
+			},
+			check: func(t *testing.T, msg chat.Message) {
+				assert.Equal(t, i18n.T(i18n.Default, "start.welcome"), msg.Text)
+				assert.NotEmpty(t, msg.Menu)
+			},
+		},
+		{
+			name: "settings error skips the wizard",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{}, assert.AnError)
+			},
+			check: func(t *testing.T, msg chat.Message) {
+				assert.NotEmpty(t, msg.Menu)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil)
+				tt.setupMock(ms)
+			})
+
+			h.Handle(chat.Event{Type: chat.EventCommand, ChatID: 123, From: chat.User{ID: 456}, Command: "start"})
+
+			require.Len(t, mb.SentMessages, 1)
+			tt.check(t, mb.SentMessages[0].(chat.Message))
+		})
+	}
+}
+
+func TestHandler_handleOnboardingCallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		data      string
+		setupMock func(*mock_bot.MockServiceI)
+		check     func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name: "target language",
+			data: "onb_lang_en",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(456), models.UserSettings{TargetLanguage: "en"}).Return(nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				edit := mb.SentMessages[0].(chat.Edit)
+				assert.Equal(t, 7, edit.MessageID)
+				assert.Equal(t, i18n.T(i18n.Default, "onboarding.level"), edit.Text)
+				require.Len(t, edit.Buttons, 3)
+				assert.Equal(t, "onb_level_A1", edit.Buttons[0][0].Data)
+				assert.Equal(t, "onb_level_C2", edit.Buttons[2][1].Data)
+			},
+		},
+		{
+			name: "level",
+			data: "onb_level_B2",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(456), models.UserSettings{Level: "B2"}).Return(nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				edit := mb.SentMessages[0].(chat.Edit)
+				assert.Equal(t, i18n.T(i18n.Default, "onboarding.goal"), edit.Text)
+				assert.Equal(t, "onb_goal_5", edit.Buttons[0][0].Data)
+				assert.Equal(t, "onb_goal_quiz_10", edit.Buttons[2][0].Data)
+			},
+		},
+		{
+			name: "daily goal finishes the wizard",
+			data: "onb_goal_20",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(456), models.UserSettings{DailyGoal: 20, GoalType: models.GoalWords, Onboarded: true}).Return(nil)
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{TargetLanguage: "en", Level: "B2", DailyGoal: 20, Onboarded: true}, nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 2)
+				edit := mb.SentMessages[0].(chat.Edit)
+				want := i18n.T(i18n.Default, "onboarding.done", i18n.T(i18n.Default, "target.en"), i18n.T(i18n.Default, "level.B2"), 20)
+				assert.Equal(t, want, edit.Text)
+				assert.Empty(t, edit.Buttons)
+
+				menu := mb.SentMessages[1].(chat.Message)
+				assert.Equal(t, i18n.T(i18n.Default, "start.welcome"), menu.Text)
+				assert.Equal(t, i18n.T(i18n.Default, ButtonNewWord), menu.Menu[0][0])
+			},
+		},
+		{
+			name: "quiz goal",
+			data: "onb_goal_quiz_50",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(456), models.UserSettings{DailyGoal: 50, GoalType: models.GoalQuiz, Onboarded: true}).Return(nil)
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{TargetLanguage: "en", Level: "B2", DailyGoal: 50, GoalType: models.GoalQuiz, Onboarded: true}, nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 2)
+				want := i18n.T(i18n.Default, "onboarding.done_quiz", i18n.T(i18n.Default, "target.en"), i18n.T(i18n.Default, "level.B2"), 50)
+				assert.Equal(t, want, mb.SentMessages[0].(chat.Edit).Text)
+			},
+		},
+		{
+			name: "invalid goal",
+			data: "onb_goal_x",
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
+		{
+			name: "save fails",
+			data: "onb_level_B2",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(456), models.UserSettings{Level: "B2"}).Return(assert.AnError)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "onboarding.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil)
+				if tt.setupMock != nil {
+					tt.setupMock(ms)
+				}
+			})
+
+			h.Handle(chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 7, From: chat.User{ID: 456}, CallbackID: "cb", Data: tt.data})
+
+			tt.check(t, mb)
+		})
+	}
+}
+
+func TestHandler_touchUser(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().TouchUser(gomock.Any(), models.User{ID: 456, UserName: "bob", LanguageCode: "en"}).Return(assert.AnError)
+		ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil)
+	})
+
+	h.Handle(chat.Event{Type: chat.EventCommand, ChatID: 123, From: chat.User{ID: 456, UserName: "bob", LanguageCode: "en"}, Command: "help"})
+
+	require.Len(t, mb.SentMessages, 1, "a failed registry update doesn't stop the command")
+}
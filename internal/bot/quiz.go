@@ -2,78 +2,195 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
 	"github.com/DanRulev/vocabot.git/internal/models"
-	"github.com/DanRulev/vocabot.git/internal/storage/cache"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+const quizType = "quiz"
+
+// maxPollOption and maxPollQuestion are the longest answer and question
+// Telegram accepts in a poll.
+const (
+	maxPollOption   = 100
+	maxPollQuestion = 300
+)
+
+// maxPairedOption is the longest answer shown two buttons to a row, longer
+// ones like definitions get a row each.
+const maxPairedOption = 24
+
 type QuizSI interface {
 	NewQuiz(ctx context.Context, userID int64) (string, map[string]bool, error)
 	AddQuizResult(ctx context.Context, result models.QuizCard) error
-	QuizStats(ctx context.Context, userID int64) (string, error)
+	QuizStats(ctx context.Context, userID int64, lang string) (string, error)
+	HardWords(ctx context.Context, userID int64, lang string) (string, bool, error)
+	NewDrill(ctx context.Context, userID int64) (string, map[string]bool, error)
+	NewCloze(ctx context.Context, userID int64) (models.WordQuiz, error)
+	NewSynonymQuiz(ctx context.Context, userID int64) (models.WordQuiz, error)
+	NewDefinitionQuiz(ctx context.Context, userID int64) (models.WordQuiz, error)
 }
 
 type QuizT struct {
-	bot     BotSender
-	cache   *cache.Cache
-	service QuizSI
+	bot      BotSender
+	sessions SessionStore
+	service  QuizSI
+	game     GameSI
+	users    UserSI
+	audio    AudioSI
 }
 
-func NewQuizTAPI(bot BotSender, cache *cache.Cache, service QuizSI) *QuizT {
+func NewQuizTAPI(bot BotSender, sessions SessionStore, service QuizSI, game GameSI, users UserSI, audio AudioSI) *QuizT {
 	return &QuizT{
-		bot:     bot,
-		cache:   cache,
-		service: service,
+		bot:      bot,
+		sessions: sessions,
+		service:  service,
+		game:     game,
+		users:    users,
+		audio:    audio,
 	}
 }
 
-func (t *QuizT) sendNewQuiz(message *tgbotapi.Message, userID int64) {
+func (t *QuizT) sendNewQuiz(chatID, userID int64, lang string) {
 	ctx, canceled := context.WithTimeout(context.Background(), 10*time.Second)
 	defer canceled()
 
-	if message.From == nil {
-		log.Printf("Message without sender: %d", message.Chat.ID)
+	if userID == 0 {
+		log.Printf("Message without sender: %d", chatID)
 		return
 	}
 
 	question, options, err := t.service.NewQuiz(ctx, userID)
 	if err != nil {
-		log.Printf("failed to get new quiz for chat: %d :%v", message.Chat.ID, err)
-		msg := tgbotapi.NewMessage(message.Chat.ID, "❌ Ошибка при получении викторины. Попробуй позже.")
+		log.Printf("failed to get new quiz for chat: %d :%v", chatID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, errorKey(err, "quiz.error")))
 		sendMessage(t.bot, msg)
 		return
 	}
 
-	word := models.QuizCard{
-		UserID: userID,
-		Word:   question,
-		Type:   "quiz",
+	quiz := models.QuizCard{UserID: userID, Word: question, Type: quizType}
+	t.sendQuestion(ctx, chatID, quiz, i18n.T(lang, "quiz.question", quiz.Word), options, lang)
+}
+
+// sendQuestion shows the options as inline buttons, or as a quiz poll if the
+// user prefers it, and remembers the quiz for the answer. Unless the quiz has
+// its translation set, the correct option is taken as one.
+func (t *QuizT) sendQuestion(ctx context.Context, chatID int64, quiz models.QuizCard, question string, options map[string]bool, lang string) {
+	if t.quizMode(ctx, quiz.UserID) == models.QuizModePoll && t.sendPoll(ctx, chatID, quiz, question, options, lang) {
+		return
+	}
+
+	buttons, correct := optionButtons(options, "quiz_right", "quiz_wrong")
+	if quiz.Translation == "" {
+		quiz.Translation = correct
+	}
+
+	// The question is plain text, a poll shows it as it is.
+	msg := chat.NewMessage(chatID, escapeMarkdown(question))
+	msg.Markdown = true
+	msg.Buttons = buttons
+
+	sent, err := sendMessage(t.bot, msg)
+	if err != nil {
+		return
+	}
+
+	key := models.SessionKey{ChatID: chatID, MessageID: sent.MessageID}
+	if err := t.sessions.SetQuiz(ctx, key, quiz); err != nil {
+		log.Printf("failed to save quiz session for chat %d: %v", chatID, err)
+	}
+}
+
+func (t *QuizT) quizMode(ctx context.Context, userID int64) string {
+	settings, err := t.users.UserSettings(ctx, userID)
+	if err != nil {
+		log.Printf("failed to get settings for user %d: %v", userID, err)
+		return models.QuizModeButtons
+	}
+	return settings.QuizMode
+}
+
+// sendPoll asks the question as a native quiz poll. It reports false when the
+// frontend can't send polls or the question or an answer is too long for one,
+// the question is then asked with buttons.
+func (t *QuizT) sendPoll(ctx context.Context, chatID int64, quiz models.QuizCard, question string, options map[string]bool, lang string) bool {
+	if utf8.RuneCountInString(question) > maxPollQuestion {
+		return false
+	}
+
+	poll := chat.Poll{Question: question}
+	for answer, isCorrect := range options {
+		if utf8.RuneCountInString(answer) > maxPollOption {
+			return false
+		}
+		if isCorrect {
+			poll.CorrectOption = len(poll.Options)
+			if quiz.Translation == "" {
+				quiz.Translation = answer
+			}
+		}
+		poll.Options = append(poll.Options, answer)
+	}
+	poll.Explanation = i18n.T(lang, "quiz.explanation", quiz.Word, quiz.Translation)
+	quiz.CorrectOption = poll.CorrectOption
+
+	sent, err := t.bot.Send(chat.NewPoll(chatID, poll))
+	if err != nil {
+		if !errors.Is(err, chat.ErrUnsupported) {
+			log.Printf("failed to send quiz poll to chat %d: %v", chatID, err)
+		}
+		return false
+	}
+
+	key := models.SessionKey{ChatID: chatID, MessageID: sent.MessageID}
+	if err := t.sessions.SetQuiz(ctx, key, quiz); err != nil {
+		log.Printf("failed to save quiz session for chat %d: %v", chatID, err)
+	}
+	if err := t.sessions.SetPoll(ctx, sent.PollID, key); err != nil {
+		log.Printf("failed to save poll session for chat %d: %v", chatID, err)
 	}
 
-	var buttons [][]tgbotapi.InlineKeyboardButton
+	return true
+}
 
-	row := make([]tgbotapi.InlineKeyboardButton, 0, 2)
+// optionButtons lays the answers out two per row and returns them with the
+// correct one.
+func optionButtons(options map[string]bool, right, wrong string) ([][]chat.Button, string) {
+	var (
+		buttons [][]chat.Button
+		correct string
+	)
+
+	perRow := 2
+	for answer := range options {
+		if utf8.RuneCountInString(answer) > maxPairedOption {
+			perRow = 1
+		}
+	}
+
+	row := make([]chat.Button, 0, perRow)
 	i := 0
 
 	for answer, isCorrect := range options {
-		callbackData := "quiz_wrong"
+		callbackData := wrong
 		if isCorrect {
-			word.Translation = answer
-			callbackData = "quiz_right"
+			correct = answer
+			callbackData = right
 		}
 
-		button := tgbotapi.NewInlineKeyboardButtonData(answer, callbackData)
-		row = append(row, button)
+		row = append(row, chat.NewButton(answer, callbackData))
 		i++
 
-		if i%2 == 0 {
+		if i%perRow == 0 {
 			buttons = append(buttons, row)
-			row = make([]tgbotapi.InlineKeyboardButton, 0, 2)
+			row = make([]chat.Button, 0, perRow)
 		}
 	}
 
@@ -81,97 +198,221 @@ func (t *QuizT) sendNewQuiz(message *tgbotapi.Message, userID int64) {
 		buttons = append(buttons, row)
 	}
 
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, "❓ Как переводится: "+question)
-	msg.ParseMode = "markdown"
-	msg.ReplyMarkup = &keyboard
-
-	t.cache.SetQuiz(userID, word)
-
-	sendMessage(t.bot, msg)
+	return buttons, correct
 }
 
-func (t *QuizT) sendQuizStats(message *tgbotapi.Message) {
-	userID := message.From.ID
-	chatID := message.Chat.ID
+func (t *QuizT) sendQuizStats(chatID, userID int64, lang string) {
 	ctx, canceled := context.WithTimeout(context.Background(), 5*time.Second)
 	defer canceled()
 
-	stats, err := t.service.QuizStats(ctx, userID)
+	stats, err := t.service.QuizStats(ctx, userID, lang)
 	if err != nil {
 		log.Printf("failed to get quiz stats for user %d: %v", userID, err)
-		msg := tgbotapi.NewMessage(chatID, "❌ Ошибка получения статистики")
+		msg := chat.NewMessage(chatID, i18n.T(lang, "quiz.error_stats"))
 		sendMessage(t.bot, msg)
 		return
 	}
 
-	msg := tgbotapi.NewMessage(chatID, stats)
-	msg.ParseMode = "markdown"
+	msg := chat.NewMessage(chatID, stats)
+	msg.Markdown = true
 
 	sendMessage(t.bot, msg)
 }
 
-func (t *QuizT) handleQuizCallbackQuery(query *tgbotapi.CallbackQuery) {
-	data := query.Data
+func (t *QuizT) handleQuizCallbackQuery(event chat.Event, lang string) {
+	data := event.Data
 
 	switch {
 	case data == "new_quiz":
-		if query.Message == nil {
-			log.Printf("CallbackQuery without message: %v", query.ID)
+		if event.MessageID == 0 {
+			log.Printf("CallbackQuery without message: %v", event.CallbackID)
+			return
+		}
+		t.sendNewQuiz(event.ChatID, event.From.ID, lang)
+	case data == "new_drill":
+		if event.MessageID == 0 {
+			log.Printf("CallbackQuery without message: %v", event.CallbackID)
 			return
 		}
-		t.sendNewQuiz(query.Message, query.From.ID)
+		t.sendDrill(event.ChatID, event.From.ID, lang)
+	case data == newListeningCallback:
+		if event.MessageID == 0 {
+			log.Printf("CallbackQuery without message: %v", event.CallbackID)
+			return
+		}
+		t.sendListeningQuiz(event.ChatID, event.From.ID, lang)
+	case isWordQuizCallback(data):
+		if event.MessageID == 0 {
+			log.Printf("CallbackQuery without message: %v", event.CallbackID)
+			return
+		}
+		t.sendWordQuiz(event.ChatID, event.From.ID, strings.TrimPrefix(data, newWordQuizPrefix), lang)
 	case strings.HasPrefix(data, "quiz_"):
-		t.processQuizAnswer(query)
+		t.processQuizAnswer(event, lang)
 	default:
-		log.Printf("Unknown callback data: %s", query.Data)
-		msg := tgbotapi.NewMessage(query.Message.Chat.ID, "❌ НЕИЗВЕСТНАЯ КОМАНДА")
-		msg.ParseMode = "markdown"
+		log.Printf("Unknown callback data: %s", event.Data)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "error.unknown_callback"))
+		msg.Markdown = true
 		sendMessage(t.bot, msg)
 	}
 }
 
-func (t *QuizT) processQuizAnswer(query *tgbotapi.CallbackQuery) {
-	userID := query.From.ID
-	data := query.Data
+func (t *QuizT) processQuizAnswer(event chat.Event, lang string) {
+	userID := event.From.ID
+	data := event.Data
+
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	ctx, canceled := context.WithTimeout(context.Background(), 5*time.Second)
+	defer canceled()
 
-	quiz, exists := t.cache.GetQuiz(userID)
+	key := models.SessionKey{ChatID: event.ChatID, MessageID: event.MessageID}
+	quiz, exists, err := t.sessions.GetQuiz(ctx, key)
+	if err != nil {
+		log.Printf("failed to get quiz session for user %d: %v", userID, err)
+	}
 	if !exists {
-		log.Printf("failed to get quiz from cache for user %d", userID)
-		msg := tgbotapi.NewMessage(userID, "❌ Не удалось определить викторину.")
+		log.Printf("failed to get quiz from session store for user %d", userID)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "quiz.not_found"))
 		sendMessage(t.bot, msg)
 		return
 	}
+	// In a group anyone can press the buttons of someone else's quiz.
+	if quiz.UserID != 0 && quiz.UserID != userID {
+		log.Printf("user %d answered the quiz of user %d, ignoring", userID, quiz.UserID)
+		return
+	}
 
-	t.cache.DeleteQuiz(userID)
+	// A double tap answers the quiz twice, only the first press takes it.
+	quiz, exists, err = t.sessions.TakeQuiz(ctx, key)
+	if err != nil {
+		log.Printf("failed to take quiz session for user %d: %v", userID, err)
+	}
+	if !exists {
+		return
+	}
 
+	quiz.ChatID = event.ChatID
 	quiz.IsCorrect = (data == "quiz_right")
 
-	statusText := "✅ Правильно! " + quiz.Translation
-	if !quiz.IsCorrect {
-		statusText = "❌ Неправильно. Повтори слово."
+	statusText, announcement := t.saveAnswer(ctx, quiz, lang)
+
+	// The text of the message comes back without its formatting.
+	fullText := fmt.Sprintf("%s\n\n%s", escapeMarkdown(event.Text), statusText)
+	editMsg := chat.NewEdit(event.ChatID, event.MessageID, fullText)
+	editMsg.Markdown = true
+	editMsg.Buttons = [][]chat.Button{chat.Row(nextQuizButton(quiz, lang))}
+
+	editMessage(t.bot, editMsg)
+	announce(t.bot, event.ChatID, announcement)
+}
+
+// processPollAnswer records an answer to a quiz sent as a poll. Telegram
+// already shows whether it was right, the bot replies with the progress and
+// the next question button.
+func (t *QuizT) processPollAnswer(event chat.Event, lang string) {
+	userID := event.From.ID
+
+	// A retracted vote has no options.
+	if len(event.Options) == 0 {
+		return
 	}
 
 	ctx, canceled := context.WithTimeout(context.Background(), 5*time.Second)
 	defer canceled()
 
-	err := t.service.AddQuizResult(ctx, quiz)
+	key, exists, err := t.sessions.GetPoll(ctx, event.PollID)
 	if err != nil {
-		log.Printf("failed to save quiz result for user %d: %v", userID, err)
+		log.Printf("failed to get poll session for user %d: %v", userID, err)
+	}
+	if !exists {
+		log.Printf("unknown poll %s answered by user %d", event.PollID, userID)
+		return
 	}
 
-	fullText := fmt.Sprintf("%s\n\n%s", query.Message.Text, statusText)
-	editMsg := tgbotapi.NewEditMessageText(
-		query.Message.Chat.ID,
-		query.Message.MessageID,
-		fullText,
-	)
-	editMsg.ParseMode = "markdown"
-	var buttons [][]tgbotapi.InlineKeyboardButton
-	buttons = append(buttons, []tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardButtonData("❓ НОВАЯ ВИКТОРИНА", "new_quiz")})
+	quiz, exists, err := t.sessions.GetQuiz(ctx, key)
+	if err != nil {
+		log.Printf("failed to get quiz session for user %d: %v", userID, err)
+	}
+	if !exists {
+		log.Printf("failed to get quiz of poll %s for user %d", event.PollID, userID)
+		return
+	}
+	// Everyone in a group can vote in someone else's poll.
+	if quiz.UserID != 0 && quiz.UserID != userID {
+		log.Printf("user %d answered the quiz of user %d, ignoring", userID, quiz.UserID)
+		return
+	}
+
+	quiz, exists, err = t.sessions.TakeQuiz(ctx, key)
+	if err != nil {
+		log.Printf("failed to take quiz session for user %d: %v", userID, err)
+	}
+	if !exists {
+		return
+	}
+	if err := t.sessions.DeletePoll(ctx, event.PollID); err != nil {
+		log.Printf("failed to delete poll session for user %d: %v", userID, err)
+	}
 
-	editMsg.ReplyMarkup = &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: buttons}
+	quiz.ChatID = key.ChatID
+	quiz.IsCorrect = event.Options[0] == quiz.CorrectOption
 
-	sendMessage(t.bot, editMsg)
+	statusText, announcement := t.saveAnswer(ctx, quiz, lang)
+
+	msg := chat.NewMessage(key.ChatID, statusText)
+	msg.Markdown = true
+	msg.Buttons = [][]chat.Button{chat.Row(nextQuizButton(quiz, lang))}
+
+	sendMessage(t.bot, msg)
+	announce(t.bot, key.ChatID, announcement)
+}
+
+// saveAnswer stores the result and returns the text telling the user how
+// they did, along with the announcement of anything they unlocked.
+func (t *QuizT) saveAnswer(ctx context.Context, quiz models.QuizCard, lang string) (string, string) {
+	statusText := i18n.T(lang, "quiz.right", quiz.Translation)
+	activity := models.ActivityQuizRight
+	if !quiz.IsCorrect {
+		statusText = i18n.T(lang, "quiz.wrong")
+		activity = models.ActivityQuizWrong
+	}
+	switch quiz.Type {
+	// The word of a listening quiz is only heard, show how it's spelled.
+	case listeningType:
+		statusText = i18n.T(lang, "listening.word", quiz.Word) + "\n" + statusText
+	case clozeType:
+		statusText = i18n.T(lang, "cloze.word", escapeMarkdown(quiz.Word)) + "\n" + statusText
+	case synonymType:
+		statusText = i18n.T(lang, "synonym.answer", escapeMarkdown(quiz.Word), escapeMarkdown(quiz.Answer)) + "\n" + statusText
+	case definitionType:
+		statusText = i18n.T(lang, "definition.answer", escapeMarkdown(quiz.Word), escapeMarkdown(quiz.Answer)) + "\n" + statusText
+	}
+
+	if err := t.service.AddQuizResult(ctx, quiz); err != nil {
+		log.Printf("failed to save quiz result for user %d: %v", quiz.UserID, err)
+		return statusText, ""
+	}
+
+	progress, announcement := recordActivity(ctx, t.game, quiz.UserID, activity, lang)
+	if progress != "" {
+		statusText += "\n" + progress
+	}
+
+	return statusText, announcement
+}
+
+func nextQuizButton(quiz models.QuizCard, lang string) chat.Button {
+	switch quiz.Type {
+	case drillType:
+		return chat.NewButton(i18n.T(lang, "inline.next_drill"), "new_drill")
+	case listeningType:
+		return chat.NewButton(i18n.T(lang, "inline.next_listening"), newListeningCallback)
+	case clozeType, synonymType, definitionType:
+		return chat.NewButton(i18n.T(lang, "inline.next_"+quiz.Type), newWordQuizPrefix+quiz.Type)
+	}
+	return chat.NewButton(i18n.T(lang, "inline.new_quiz"), "new_quiz")
 }
@@ -2,12 +2,15 @@ package bot
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
 	"github.com/DanRulev/vocabot.git/internal/models"
 	"github.com/DanRulev/vocabot.git/internal/storage/cache"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,22 +18,24 @@ import (
 
 func newQuizTMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_bot.MockServiceI, *mock_bot.MockBot)) *QuizT {
 	mockService := mock_bot.NewMockServiceI(ctrl)
-	cache := cache.NewCache()
+	sessions := cache.NewCache(time.Hour, 0)
 	mockBot := &mock_bot.MockBot{}
 
 	if setupMock != nil {
 		setupMock(mockService, mockBot)
 	}
+	mockService.EXPECT().RecordActivity(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+	mockService.EXPECT().UserSettings(gomock.Any(), gomock.Any()).Return(models.UserSettings{}, nil).AnyTimes()
 
-	return NewQuizTAPI(mockBot, cache, mockService)
+	return NewQuizTAPI(mockBot, sessions, mockService, mockService, mockService, mockService)
 }
 
 func TestQuizT_sendNewQuiz(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		message *tgbotapi.Message
-		userID  int64
+		chatID int64
+		userID int64
 	}
 	tests := []struct {
 		name       string
@@ -41,10 +46,7 @@ func TestQuizT_sendNewQuiz(t *testing.T) {
 		{
 			name: "success: sends quiz with options",
 			args: args{
-				message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: 123},
-					From: &tgbotapi.User{ID: 456},
-				},
+				chatID: 123,
 				userID: 456,
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
@@ -58,20 +60,75 @@ func TestQuizT_sendNewQuiz(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg, ok := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg, ok := mb.SentMessages[0].(chat.Message)
 				require.True(t, ok)
 				assert.Equal(t, "❓ Как переводится: hello", msg.Text)
-				assert.Equal(t, "markdown", msg.ParseMode)
-				assert.NotNil(t, msg.ReplyMarkup)
+				assert.True(t, msg.Markdown)
+				require.Len(t, msg.Buttons, 2)
+				assert.Len(t, msg.Buttons[0], 2)
+			},
+		},
+		{
+			name: "poll mode: sends a quiz poll",
+			args: args{
+				chatID: 123,
+				userID: 456,
+			},
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{QuizMode: models.QuizModePoll}, nil)
+				ms.EXPECT().NewQuiz(gomock.Any(), int64(456)).Return("hello", map[string]bool{"Привет": true, "Пока": false}, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Equal(t, 1, len(mb.SentMessages))
+				msg, ok := mb.SentMessages[0].(chat.Message)
+				require.True(t, ok)
+				require.NotNil(t, msg.Poll)
+				assert.Equal(t, "❓ Как переводится: hello", msg.Poll.Question)
+				require.Len(t, msg.Poll.Options, 2)
+				assert.Equal(t, "Привет", msg.Poll.Options[msg.Poll.CorrectOption])
+				assert.Equal(t, "hello — Привет", msg.Poll.Explanation)
+				assert.Empty(t, msg.Buttons)
+			},
+		},
+		{
+			name: "poll mode: unsupported frontend falls back to buttons",
+			args: args{
+				chatID: 123,
+				userID: 456,
+			},
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				mb.NoPolls = true
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{QuizMode: models.QuizModePoll}, nil)
+				ms.EXPECT().NewQuiz(gomock.Any(), int64(456)).Return("hello", map[string]bool{"Привет": true, "Пока": false}, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Equal(t, 1, len(mb.SentMessages))
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Nil(t, msg.Poll)
+				require.Len(t, msg.Buttons, 1)
+			},
+		},
+		{
+			name: "poll mode: long option falls back to buttons",
+			args: args{
+				chatID: 123,
+				userID: 456,
+			},
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{QuizMode: models.QuizModePoll}, nil)
+				ms.EXPECT().NewQuiz(gomock.Any(), int64(456)).Return("hello", map[string]bool{"Привет": true, strings.Repeat("я", maxPollOption+1): false}, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Equal(t, 1, len(mb.SentMessages))
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Nil(t, msg.Poll)
+				require.Len(t, msg.Buttons, 2, "a long option gets a row of its own")
 			},
 		},
 		{
 			name: "error: NewQuiz fails",
 			args: args{
-				message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: 123},
-					From: &tgbotapi.User{ID: 456},
-				},
+				chatID: 123,
 				userID: 456,
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
@@ -79,18 +136,15 @@ func TestQuizT_sendNewQuiz(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "❌ Ошибка при получении викторины. Попробуй позже.", msg.Text)
 			},
 		},
 		{
-			name: "nil From in message",
+			name: "no sender",
 			args: args{
-				message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: 123},
-					From: nil,
-				},
-				userID: 456,
+				chatID: 123,
+				userID: 0,
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				assert.Empty(t, mb.SentMessages)
@@ -108,7 +162,7 @@ func TestQuizT_sendNewQuiz(t *testing.T) {
 			mb, _ := quizT.bot.(*mock_bot.MockBot)
 
 			mock_bot.ClearSentMessages(mb)
-			quizT.sendNewQuiz(tt.args.message, tt.args.userID)
+			quizT.sendNewQuiz(tt.args.chatID, tt.args.userID, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -120,7 +174,7 @@ func TestQuizT_processQuizAnswer(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		query *tgbotapi.CallbackQuery
+		event chat.Event
 	}
 
 	tests := []struct {
@@ -132,14 +186,13 @@ func TestQuizT_processQuizAnswer(t *testing.T) {
 		{
 			name: "correct answer: quiz_right",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat:      &tgbotapi.Chat{ID: 123},
-						MessageID: 100,
-						Text:      "❓ Как переводится: hello",
-					},
-					Data: "quiz_right",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Text:      "❓ Как переводится: hello",
+					Data:      "quiz_right",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
@@ -153,48 +206,63 @@ func TestQuizT_processQuizAnswer(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				editMsg := mb.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+				editMsg := mb.SentMessages[0].(chat.Edit)
 				assert.Contains(t, editMsg.Text, "✅ Правильно! ")
-				assert.NotNil(t, editMsg.ReplyMarkup)
-				kb := editMsg.ReplyMarkup
-				assert.Equal(t, "❓ НОВАЯ ВИКТОРИНА", kb.InlineKeyboard[0][0].Text)
+				require.NotNil(t, editMsg.Buttons)
+				assert.Equal(t, "❓ НОВАЯ ВИКТОРИНА", editMsg.Buttons[0][0].Text)
 			},
 		},
 		{
 			name: "wrong answer: quiz_wrong",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat:      &tgbotapi.Chat{ID: 123},
-						MessageID: 100,
-						Text:      "❓ Как переводится: hello",
-					},
-					Data: "quiz_wrong",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Text:      "❓ Как переводится: hello",
+					Data:      "quiz_wrong",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
 				ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				editMsg := mb.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+				editMsg := mb.SentMessages[0].(chat.Edit)
 				assert.Contains(t, editMsg.Text, "❌ Неправильно. Повтори слово.")
 			},
 		},
+		{
+			name: "answer from another group member is ignored",
+			args: args{
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 789},
+					ChatID:    123,
+					Group:     true,
+					MessageID: 100,
+					Data:      "quiz_right",
+				},
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
 		{
 			name: "no quiz in cache",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat: &tgbotapi.Chat{ID: 123},
-					},
-					Data: "quiz_right",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 101,
+					Data:      "quiz_right",
 				},
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, int64(123), msg.ChatID, "replies go to the chat, not the user")
 				assert.Equal(t, "❌ Не удалось определить викторину.", msg.Text)
 			},
 		},
@@ -211,16 +279,18 @@ func TestQuizT_processQuizAnswer(t *testing.T) {
 			mb, _ := quizT.bot.(*mock_bot.MockBot)
 
 			if tt.name != "no quiz in cache" {
-				quizT.cache.SetQuiz(456, models.QuizCard{
+				key := models.SessionKey{ChatID: 123, MessageID: 100}
+				err := quizT.sessions.SetQuiz(context.Background(), key, models.QuizCard{
 					UserID:      456,
 					Word:        "hello",
 					Translation: "Привет",
 					Type:        "quiz",
 				})
+				require.NoError(t, err)
 			}
 
 			mock_bot.ClearSentMessages(mb)
-			quizT.processQuizAnswer(tt.args.query)
+			quizT.processQuizAnswer(tt.args.event, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -229,13 +299,128 @@ func TestQuizT_processQuizAnswer(t *testing.T) {
 	}
 }
 
-func TestQuizT_sendQuizStats(t *testing.T) {
+func TestQuizT_processPollAnswer(t *testing.T) {
 	t.Parallel()
 
-	message := &tgbotapi.Message{
-		Chat: &tgbotapi.Chat{ID: 123},
-		From: &tgbotapi.User{ID: 456},
+	key := models.SessionKey{ChatID: 123, MessageID: 7}
+	quiz := models.QuizCard{UserID: 456, Word: "hello", Translation: "привет", Type: quizType, CorrectOption: 1}
+
+	tests := []struct {
+		name       string
+		event      chat.Event
+		f          func(*mock_bot.MockServiceI, *mock_bot.MockBot)
+		assertFunc func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name:  "correct answer",
+			event: chat.Event{Type: chat.EventPollAnswer, From: chat.User{ID: 456}, PollID: "poll", Options: []int{1}},
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, result models.QuizCard) error {
+					assert.True(t, result.IsCorrect)
+					assert.Equal(t, int64(123), result.ChatID)
+					assert.Equal(t, "hello", result.Word)
+					return nil
+				})
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, int64(123), msg.ChatID)
+				assert.Equal(t, "✅ Правильно! привет", msg.Text)
+				require.Len(t, msg.Buttons, 1)
+				assert.Equal(t, "new_quiz", msg.Buttons[0][0].Data)
+			},
+		},
+		{
+			name:  "wrong answer",
+			event: chat.Event{Type: chat.EventPollAnswer, From: chat.User{ID: 456}, PollID: "poll", Options: []int{0}},
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, result models.QuizCard) error {
+					assert.False(t, result.IsCorrect)
+					return nil
+				})
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, "❌ Неправильно. Повтори слово.", mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name:  "someone else's poll",
+			event: chat.Event{Type: chat.EventPollAnswer, From: chat.User{ID: 789}, PollID: "poll", Options: []int{1}},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
+		{
+			name:  "unknown poll",
+			event: chat.Event{Type: chat.EventPollAnswer, From: chat.User{ID: 456}, PollID: "other", Options: []int{1}},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
+		{
+			name:  "retracted vote",
+			event: chat.Event{Type: chat.EventPollAnswer, From: chat.User{ID: 456}, PollID: "poll"},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quizT := newQuizTMock(t, ctrl, tt.f)
+			mb, _ := quizT.bot.(*mock_bot.MockBot)
+
+			ctx := context.Background()
+			require.NoError(t, quizT.sessions.SetQuiz(ctx, key, quiz))
+			require.NoError(t, quizT.sessions.SetPoll(ctx, "poll", key))
+
+			quizT.processPollAnswer(tt.event, i18n.Default)
+
+			tt.assertFunc(t, mb)
+		})
+	}
+}
+
+func Test_optionButtons(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		options  map[string]bool
+		wantRows int
+	}{
+		{
+			name:     "two short answers to a row",
+			options:  map[string]bool{"дом": true, "кот": false, "стол": false, "лес": false},
+			wantRows: 2,
+		},
+		{
+			name:     "a row for each long answer",
+			options:  map[string]bool{"to move fast on foot": true, "to make music with the voice": false, "big": false},
+			wantRows: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buttons, correct := optionButtons(tt.options, "quiz_right", "quiz_wrong")
+			assert.Len(t, buttons, tt.wantRows)
+			assert.True(t, tt.options[correct])
+		})
+	}
+}
+
+func TestQuizT_sendQuizStats(t *testing.T) {
+	t.Parallel()
 
 	tests := []struct {
 		name       string
@@ -245,22 +430,22 @@ func TestQuizT_sendQuizStats(t *testing.T) {
 		{
 			name: "success: sends stats",
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().QuizStats(gomock.Any(), int64(456)).Return("📊 Викторин пройдено: 10\n✅ Правильно: 8", nil)
+				ms.EXPECT().QuizStats(gomock.Any(), int64(456), gomock.Any()).Return("📊 Викторин пройдено: 10\n✅ Правильно: 8", nil)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "📊 Викторин пройдено: 10\n✅ Правильно: 8", msg.Text)
-				assert.Equal(t, "markdown", msg.ParseMode)
+				assert.True(t, msg.Markdown)
 			},
 		},
 		{
 			name: "error: failed to get stats",
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().QuizStats(gomock.Any(), int64(456)).Return("", assert.AnError)
+				ms.EXPECT().QuizStats(gomock.Any(), int64(456), gomock.Any()).Return("", assert.AnError)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "❌ Ошибка получения статистики", msg.Text)
 			},
 		},
@@ -277,7 +462,7 @@ func TestQuizT_sendQuizStats(t *testing.T) {
 			mb, _ := quizT.bot.(*mock_bot.MockBot)
 
 			mock_bot.ClearSentMessages(mb)
-			quizT.sendQuizStats(message)
+			quizT.sendQuizStats(123, 456, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -290,7 +475,7 @@ func TestQuizT_handleQuizCallbackQuery(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		query *tgbotapi.CallbackQuery
+		event chat.Event
 	}
 
 	tests := []struct {
@@ -302,13 +487,12 @@ func TestQuizT_handleQuizCallbackQuery(t *testing.T) {
 		{
 			name: "new_quiz: triggers sendNewQuiz",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat: &tgbotapi.Chat{ID: 123},
-						From: &tgbotapi.User{ID: 456}, // ✅ Добавь это!
-					},
-					Data: "new_quiz",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Data:      "new_quiz",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
@@ -316,20 +500,34 @@ func TestQuizT_handleQuizCallbackQuery(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Contains(t, msg.Text, "Как переводится: hello")
 			},
 		},
+		{
+			name: "nil From in message",
+			args: args{
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					ChatID:    123,
+					MessageID: 100,
+					Data:      "new_quiz",
+				},
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
 		{
 			name: "quiz_right: processes answer",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat: &tgbotapi.Chat{ID: 123},
-						Text: "❓ Как переводится: hello",
-					},
-					Data: "quiz_right",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Text:      "❓ Как переводится: hello",
+					Data:      "quiz_right",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
@@ -337,23 +535,22 @@ func TestQuizT_handleQuizCallbackQuery(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				editMsg := mb.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+				editMsg := mb.SentMessages[0].(chat.Edit)
 				assert.Contains(t, editMsg.Text, "✅ Правильно!")
 			},
 		},
 		{
 			name: "unknown command",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat: &tgbotapi.Chat{ID: 123},
-					},
-					Data: "unknown_data",
+				event: chat.Event{
+					Type:   chat.EventCallback,
+					From:   chat.User{ID: 456},
+					ChatID: 123,
+					Data:   "unknown_data",
 				},
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "❌ НЕИЗВЕСТНАЯ КОМАНДА", msg.Text)
 			},
 		},
@@ -369,16 +566,18 @@ func TestQuizT_handleQuizCallbackQuery(t *testing.T) {
 			quizT := newQuizTMock(t, ctrl, tt.f)
 			mb, _ := quizT.bot.(*mock_bot.MockBot)
 
-			if tt.args.query.Data == "quiz_right" {
-				quizT.cache.SetQuiz(456, models.QuizCard{
+			if tt.args.event.Data == "quiz_right" {
+				key := models.SessionKey{ChatID: 123, MessageID: tt.args.event.MessageID}
+				err := quizT.sessions.SetQuiz(context.Background(), key, models.QuizCard{
 					UserID:      456,
 					Word:        "hello",
 					Translation: "Привет",
 				})
+				require.NoError(t, err)
 			}
 
 			mock_bot.ClearSentMessages(mb)
-			quizT.handleQuizCallbackQuery(tt.args.query)
+			quizT.handleQuizCallbackQuery(tt.args.event, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+const (
+	languagePrefix = "lang_"
+	quizModePrefix = "quizmode_"
+	pageSizePrefix = "pagesize_"
+)
+
+type SettingsSI interface {
+	Language(ctx context.Context, userID int64) (string, error)
+	SetLanguage(ctx context.Context, userID int64, lang string) error
+}
+
+func (h *Handler) showLanguageMenu(chatID int64, lang string) {
+	var buttons [][]chat.Button
+	for _, code := range i18n.Languages() {
+		buttons = append(buttons, chat.Row(chat.NewButton(i18n.T(code, "language.name"), languagePrefix+code)))
+	}
+
+	msg := chat.NewMessage(chatID, i18n.T(lang, "language.choose"))
+	msg.Buttons = buttons
+
+	sendMessage(h.bot, msg)
+}
+
+func (h *Handler) handleLanguageCallback(event chat.Event) {
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	lang := strings.TrimPrefix(event.Data, languagePrefix)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.settings.SetLanguage(ctx, event.From.ID, lang); err != nil {
+		log.Printf("Failed to set language for user %d: %v", event.From.ID, err)
+		msg := chat.NewMessage(event.ChatID, i18n.T(h.locale(event), "language.error"))
+		sendMessage(h.bot, msg)
+		return
+	}
+
+	editMessage(h.bot, chat.NewEdit(event.ChatID, event.MessageID, i18n.T(lang, "language.changed")))
+
+	// The reply keyboard only changes when a new message carries it.
+	h.showMainMenu(event.ChatID, lang)
+}
+
+func (h *Handler) showQuizModeMenu(chatID int64, lang string) {
+	var buttons [][]chat.Button
+	for _, mode := range models.QuizModes {
+		buttons = append(buttons, chat.Row(chat.NewButton(i18n.T(lang, "quiz_mode."+mode), quizModePrefix+mode)))
+	}
+
+	msg := chat.NewMessage(chatID, i18n.T(lang, "quiz_mode.choose"))
+	msg.Buttons = buttons
+
+	sendMessage(h.bot, msg)
+}
+
+func (h *Handler) handleQuizModeCallback(event chat.Event, lang string) {
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	mode := strings.TrimPrefix(event.Data, quizModePrefix)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.users.UpdateUserSettings(ctx, event.From.ID, models.UserSettings{QuizMode: mode}); err != nil {
+		log.Printf("Failed to set quiz mode for user %d: %v", event.From.ID, err)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "quiz_mode.error"))
+		sendMessage(h.bot, msg)
+		return
+	}
+
+	text := i18n.T(lang, "quiz_mode.changed", i18n.T(lang, "quiz_mode."+mode))
+	editMessage(h.bot, chat.NewEdit(event.ChatID, event.MessageID, text))
+}
+
+func (h *Handler) showPageSizeMenu(chatID int64, lang string) {
+	row := make([]chat.Button, 0, len(models.PageSizes))
+	for _, size := range models.PageSizes {
+		row = append(row, chat.NewButton(strconv.Itoa(size), pageSizePrefix+strconv.Itoa(size)))
+	}
+
+	msg := chat.NewMessage(chatID, i18n.T(lang, "page_size.choose"))
+	msg.Buttons = [][]chat.Button{row}
+
+	sendMessage(h.bot, msg)
+}
+
+func (h *Handler) handlePageSizeCallback(event chat.Event, lang string) {
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	size, err := strconv.Atoi(strings.TrimPrefix(event.Data, pageSizePrefix))
+	if err != nil || !slices.Contains(models.PageSizes, size) {
+		log.Printf("Invalid page size %q from user %d", event.Data, event.From.ID)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.users.UpdateUserSettings(ctx, event.From.ID, models.UserSettings{PageSize: size}); err != nil {
+		log.Printf("Failed to set page size for user %d: %v", event.From.ID, err)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "page_size.error"))
+		sendMessage(h.bot, msg)
+		return
+	}
+
+	editMessage(h.bot, chat.NewEdit(event.ChatID, event.MessageID, i18n.T(lang, "page_size.changed", size)))
+}
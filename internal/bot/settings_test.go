@@ -0,0 +1,289 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/storage/cache"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHandlerMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_bot.MockServiceI)) (*Handler, *mock_bot.MockBot) {
+	mockService := mock_bot.NewMockServiceI(ctrl)
+	sessions := cache.NewCache(time.Hour, 0)
+	t.Cleanup(sessions.Close)
+	mockBot := &mock_bot.MockBot{}
+
+	if setupMock != nil {
+		setupMock(mockService)
+	}
+	mockService.EXPECT().IsBanned(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	mockService.EXPECT().TouchUser(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockService.EXPECT().RecordActivity(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+	mockService.EXPECT().UserSettings(gomock.Any(), gomock.Any()).Return(models.UserSettings{}, nil).AnyTimes()
+
+	return NewHandler(mockBot, mockService, sessions), mockBot
+}
+
+func TestHandler_locale(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		from      chat.User
+		setupMock func(*mock_bot.MockServiceI)
+		want      string
+	}{
+		{
+			name: "stored language wins",
+			from: chat.User{ID: 1, LanguageCode: "en"},
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), int64(1)).Return("uk", nil)
+			},
+			want: "uk",
+		},
+		{
+			name: "platform language code",
+			from: chat.User{ID: 1, LanguageCode: "en-GB"},
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), int64(1)).Return("", nil)
+			},
+			want: "en",
+		},
+		{
+			name: "settings error falls back",
+			from: chat.User{ID: 1, LanguageCode: "uk"},
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), int64(1)).Return("", assert.AnError)
+			},
+			want: "uk",
+		},
+		{
+			name: "no sender",
+			from: chat.User{},
+			want: i18n.Default,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h, _ := newHandlerMock(t, ctrl, tt.setupMock)
+
+			assert.Equal(t, tt.want, h.locale(chat.Event{From: tt.from}))
+		})
+	}
+}
+
+func TestHandler_localizedButtons(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil).AnyTimes()
+		ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{Onboarded: true}, nil)
+		ms.EXPECT().WordStat(gomock.Any(), int64(456), "en").Return("stats", nil)
+	})
+
+	from := chat.User{ID: 456, LanguageCode: "en"}
+
+	h.Handle(chat.Event{Type: chat.EventCommand, ChatID: 123, From: from, Command: "start"})
+	require.Len(t, mb.SentMessages, 1)
+	msg := mb.SentMessages[0].(chat.Message)
+	assert.Equal(t, "📚 New word", msg.Menu[0][0])
+
+	// A keyboard sent in another language still works.
+	h.Handle(chat.Event{Type: chat.EventMessage, ChatID: 123, From: from, Text: "📚 Слова"})
+	require.Len(t, mb.SentMessages, 2)
+	assert.Equal(t, "stats", mb.SentMessages[1].(chat.Message).Text)
+
+	h.Handle(chat.Event{Type: chat.EventMessage, ChatID: 123, From: from, Text: "hello"})
+	require.Len(t, mb.SentMessages, 3)
+	assert.Equal(t, i18n.T("en", "message.unknown"), mb.SentMessages[2].(chat.Message).Text)
+}
+
+func TestHandler_languageCommand(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil)
+	})
+
+	h.Handle(chat.Event{Type: chat.EventCommand, ChatID: 123, From: chat.User{ID: 456}, Command: "language"})
+
+	require.Len(t, mb.SentMessages, 1)
+	msg := mb.SentMessages[0].(chat.Message)
+	assert.Equal(t, i18n.T(i18n.Default, "language.choose"), msg.Text)
+	require.Len(t, msg.Buttons, len(i18n.Languages()))
+	assert.Equal(t, "lang_en", msg.Buttons[0][0].Data)
+}
+
+func TestHandler_handleLanguageCallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		event     chat.Event
+		setupMock func(*mock_bot.MockServiceI)
+		check     func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name:  "success",
+			event: chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 7, From: chat.User{ID: 456}, Data: "lang_en"},
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil)
+				ms.EXPECT().SetLanguage(gomock.Any(), int64(456), "en").Return(nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 2)
+
+				edit := mb.SentMessages[0].(chat.Edit)
+				assert.Equal(t, 7, edit.MessageID)
+				assert.Equal(t, i18n.T("en", "language.changed"), edit.Text)
+
+				menu := mb.SentMessages[1].(chat.Message)
+				assert.Equal(t, "🏠 Main menu:", menu.Text)
+				assert.Equal(t, "📚 New word", menu.Menu[0][0])
+			},
+		},
+		{
+			name:  "set language fails",
+			event: chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 7, From: chat.User{ID: 456}, Data: "lang_xx"},
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil).Times(2)
+				ms.EXPECT().SetLanguage(gomock.Any(), int64(456), "xx").Return(assert.AnError)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "language.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name:  "no message",
+			event: chat.Event{Type: chat.EventCallback, ChatID: 123, From: chat.User{ID: 456}, Data: "lang_en"},
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h, mb := newHandlerMock(t, ctrl, tt.setupMock)
+
+			h.Handle(tt.event)
+
+			tt.check(t, mb)
+		})
+	}
+}
+
+func TestHandler_quizMode(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil).Times(2)
+		ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(456), models.UserSettings{QuizMode: models.QuizModePoll}).Return(nil)
+	})
+
+	h.Handle(chat.Event{Type: chat.EventCommand, ChatID: 123, From: chat.User{ID: 456}, Command: "quizmode"})
+
+	require.Len(t, mb.SentMessages, 1)
+	msg := mb.SentMessages[0].(chat.Message)
+	assert.Equal(t, i18n.T(i18n.Default, "quiz_mode.choose"), msg.Text)
+	require.Len(t, msg.Buttons, len(models.QuizModes))
+	assert.Equal(t, "quizmode_poll", msg.Buttons[1][0].Data)
+
+	h.Handle(chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 1, From: chat.User{ID: 456}, Data: "quizmode_poll"})
+
+	require.Len(t, mb.SentMessages, 2)
+	edit := mb.SentMessages[1].(chat.Edit)
+	assert.Equal(t, "✅ Вопросы викторины: 📊 Опросами-викторинами Telegram", edit.Text)
+}
+
+func TestHandler_pageSize(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil).Times(3)
+		ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(456), models.UserSettings{PageSize: 20}).Return(nil)
+	})
+
+	h.Handle(chat.Event{Type: chat.EventCommand, ChatID: 123, From: chat.User{ID: 456}, Command: "pagesize"})
+
+	require.Len(t, mb.SentMessages, 1)
+	msg := mb.SentMessages[0].(chat.Message)
+	assert.Equal(t, i18n.T(i18n.Default, "page_size.choose"), msg.Text)
+	require.Len(t, msg.Buttons, 1)
+	require.Len(t, msg.Buttons[0], len(models.PageSizes))
+	assert.Equal(t, "pagesize_20", msg.Buttons[0][2].Data)
+
+	h.Handle(chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 1, From: chat.User{ID: 456}, Data: "pagesize_20"})
+
+	require.Len(t, mb.SentMessages, 2)
+	edit := mb.SentMessages[1].(chat.Edit)
+	assert.Equal(t, "✅ Слов на странице: 20", edit.Text)
+
+	// Sizes that aren't offered are ignored.
+	h.Handle(chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 1, From: chat.User{ID: 456}, Data: "pagesize_1000"})
+	assert.Len(t, mb.SentMessages, 2)
+}
+
+func TestHandler_pollAnswer(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil).Times(2)
+		ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{QuizMode: models.QuizModePoll}, nil)
+		ms.EXPECT().NewQuiz(gomock.Any(), int64(456)).Return("hello", map[string]bool{"привет": true, "пока": false}, nil)
+		ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, result models.QuizCard) error {
+			assert.True(t, result.IsCorrect)
+			assert.Equal(t, int64(456), result.UserID)
+			return nil
+		})
+	})
+
+	h.Handle(chat.Event{Type: chat.EventMessage, ChatID: 456, From: chat.User{ID: 456}, Text: "🧠 Викторина"})
+
+	require.Len(t, mb.SentMessages, 1)
+	poll := mb.SentMessages[0].(chat.Message).Poll
+	require.NotNil(t, poll)
+
+	h.Handle(chat.Event{Type: chat.EventPollAnswer, From: chat.User{ID: 456}, PollID: "poll1", Options: []int{poll.CorrectOption}})
+
+	require.Len(t, mb.SentMessages, 2)
+	assert.Equal(t, "✅ Правильно! привет", mb.SentMessages[1].(chat.Message).Text)
+}
@@ -0,0 +1,112 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+const statsPrefix = "stats_"
+
+type StatsSI interface {
+	Progress(ctx context.Context, userID int64, period models.StatsPeriod, lang string) (string, error)
+	Charts(ctx context.Context, userID int64, lang string) ([]models.Chart, error)
+}
+
+type StatsT struct {
+	bot     BotSender
+	service StatsSI
+}
+
+func NewStatsTAPI(bot BotSender, service StatsSI) *StatsT {
+	return &StatsT{
+		bot:     bot,
+		service: service,
+	}
+}
+
+func (t *StatsT) sendProgress(chatID, userID int64, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	text, err := t.service.Progress(ctx, userID, models.PeriodWeek, lang)
+	if err != nil {
+		log.Printf("Failed to get progress for user %d: %v", userID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, "stats.error"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	msg := chat.NewMessage(chatID, text)
+	msg.Markdown = true
+	msg.Buttons = t.periodKeyboard(models.PeriodWeek, lang)
+
+	sendMessage(t.bot, msg)
+}
+
+func (t *StatsT) sendCharts(chatID, userID int64, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	charts, err := t.service.Charts(ctx, userID, lang)
+	if err != nil {
+		log.Printf("Failed to render charts for user %d: %v", userID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, "chart.error"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	for _, c := range charts {
+		msg := chat.NewPhoto(chatID, c.Name, c.PNG)
+		msg.Text = c.Caption
+		sendMessage(t.bot, msg)
+	}
+}
+
+func (t *StatsT) handlePeriodCallback(event chat.Event, lang string) {
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	period, ok := models.ParseStatsPeriod(strings.TrimPrefix(event.Data, statsPrefix))
+	if !ok {
+		log.Printf("Unknown stats period: %s", event.Data)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	text, err := t.service.Progress(ctx, event.From.ID, period, lang)
+	if err != nil {
+		log.Printf("Failed to get progress for user %d: %v", event.From.ID, err)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "stats.error"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	editMsg := chat.NewEdit(event.ChatID, event.MessageID, text)
+	editMsg.Markdown = true
+	editMsg.Buttons = t.periodKeyboard(period, lang)
+
+	editMessage(t.bot, editMsg)
+}
+
+func (t *StatsT) periodKeyboard(current models.StatsPeriod, lang string) [][]chat.Button {
+	row := make([]chat.Button, 0, len(models.StatsPeriods))
+	for _, period := range models.StatsPeriods {
+		text := i18n.T(lang, "stats.period_"+string(period))
+		if period == current {
+			text = "• " + text + " •"
+		}
+		row = append(row, chat.NewButton(text, statsPrefix+string(period)))
+	}
+
+	return [][]chat.Button{row}
+}
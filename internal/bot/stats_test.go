@@ -0,0 +1,198 @@
+package bot
+
+import (
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatsTMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_bot.MockServiceI)) (*StatsT, *mock_bot.MockBot) {
+	mockService := mock_bot.NewMockServiceI(ctrl)
+	mockBot := &mock_bot.MockBot{}
+
+	if setupMock != nil {
+		setupMock(mockService)
+	}
+
+	return NewStatsTAPI(mockBot, mockService), mockBot
+}
+
+func TestStatsT_sendProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock_bot.MockServiceI)
+		check     func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name: "success",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Progress(gomock.Any(), int64(456), models.PeriodWeek, i18n.Default).Return("progress", nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, "progress", msg.Text)
+				assert.True(t, msg.Markdown)
+				require.Len(t, msg.Buttons, 1)
+				require.Len(t, msg.Buttons[0], 3)
+				assert.Equal(t, "• 7 дней •", msg.Buttons[0][0].Text)
+				assert.Equal(t, "stats_30d", msg.Buttons[0][1].Data)
+			},
+		},
+		{
+			name: "service error",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Progress(gomock.Any(), int64(456), models.PeriodWeek, i18n.Default).Return("", assert.AnError)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "stats.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			statsT, mb := newStatsTMock(t, ctrl, tt.setupMock)
+
+			statsT.sendProgress(123, 456, i18n.Default)
+
+			tt.check(t, mb)
+		})
+	}
+}
+
+func TestStatsT_sendCharts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock_bot.MockServiceI)
+		check     func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name: "success",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Charts(gomock.Any(), int64(456), i18n.Default).Return([]models.Chart{
+					{Name: "accuracy.png", Caption: "accuracy", PNG: []byte{1}},
+					{Name: "learned.png", Caption: "learned", PNG: []byte{2}},
+				}, nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 2)
+				msg := mb.SentMessages[1].(chat.Message)
+				assert.Equal(t, int64(123), msg.ChatID)
+				assert.Equal(t, "learned", msg.Text)
+				require.NotNil(t, msg.Photo)
+				assert.Equal(t, "learned.png", msg.Photo.Name)
+				assert.Equal(t, []byte{2}, msg.Photo.Data)
+			},
+		},
+		{
+			name: "service error",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Charts(gomock.Any(), int64(456), i18n.Default).Return(nil, assert.AnError)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Nil(t, msg.Photo)
+				assert.Equal(t, i18n.T(i18n.Default, "chart.error"), msg.Text)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			statsT, mb := newStatsTMock(t, ctrl, tt.setupMock)
+
+			statsT.sendCharts(123, 456, i18n.Default)
+
+			tt.check(t, mb)
+		})
+	}
+}
+
+func TestStatsT_handlePeriodCallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		event     chat.Event
+		setupMock func(*mock_bot.MockServiceI)
+		check     func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name:  "switch period",
+			event: chat.Event{ChatID: 123, MessageID: 5, From: chat.User{ID: 456}, Data: "stats_all"},
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Progress(gomock.Any(), int64(456), models.PeriodAll, i18n.Default).Return("all time", nil)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				edit := mb.SentMessages[0].(chat.Edit)
+				assert.Equal(t, 5, edit.MessageID)
+				assert.Equal(t, "all time", edit.Text)
+				assert.Equal(t, "• всё время •", edit.Buttons[0][2].Text)
+			},
+		},
+		{
+			name:  "unknown period",
+			event: chat.Event{ChatID: 123, MessageID: 5, From: chat.User{ID: 456}, Data: "stats_1y"},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
+		{
+			name:  "no message",
+			event: chat.Event{ChatID: 123, From: chat.User{ID: 456}, Data: "stats_7d"},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
+		{
+			name:  "service error",
+			event: chat.Event{ChatID: 123, MessageID: 5, From: chat.User{ID: 456}, Data: "stats_30d"},
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Progress(gomock.Any(), int64(456), models.PeriodMonth, i18n.Default).Return("", assert.AnError)
+			},
+			check: func(t *testing.T, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "stats.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			statsT, mb := newStatsTMock(t, ctrl, tt.setupMock)
+
+			statsT.handlePeriodCallback(tt.event, i18n.Default)
+
+			tt.check(t, mb)
+		})
+	}
+}
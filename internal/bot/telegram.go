@@ -1,28 +1,35 @@
 package bot
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
 
-	"github.com/DanRulev/vocabot.git/internal/storage/cache"
+	"github.com/DanRulev/vocabot.git/internal/chat"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-type ServiceI interface {
-	WordSI
-	QuizSI
-}
+const (
+	// inlineCacheTime is how many seconds Telegram may reuse the answer to an
+	// inline query. Answers are personal, they include the user's own words.
+	inlineCacheTime = 300
 
-type BotSender interface {
-	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
-}
+	// maxDownloadSize caps the files users send, they are read whole.
+	maxDownloadSize = 1 << 20
+)
 
 type TelegramAPI struct {
-	bot  *tgbotapi.BotAPI
-	word *WordT
-	quiz *QuizT
+	bot     *tgbotapi.BotAPI
+	handler *Handler
 }
 
-func NewTelegramAPI(botToken, env string, service ServiceI, cache *cache.Cache) (*TelegramAPI, error) {
+func NewTelegramAPI(botToken, env string, service ServiceI, sessions SessionStore) (*TelegramAPI, error) {
 	bot, err := tgbotapi.NewBotAPI(botToken)
 	if err != nil {
 		return nil, err
@@ -34,11 +41,14 @@ func NewTelegramAPI(botToken, env string, service ServiceI, cache *cache.Cache)
 		bot.Debug = false
 	}
 
-	return &TelegramAPI{
-		bot:  bot,
-		word: NewWordTAPI(bot, cache, service),
-		quiz: NewQuizTAPI(bot, cache, service),
-	}, nil
+	t := &TelegramAPI{bot: bot}
+	t.handler = NewHandler(t, service, sessions)
+
+	return t, nil
+}
+
+func (t *TelegramAPI) Handler() *Handler {
+	return t.handler
 }
 
 func (t *TelegramAPI) Start() {
@@ -48,26 +58,298 @@ func (t *TelegramAPI) Start() {
 	updates := t.bot.GetUpdatesChan(u)
 
 	for update := range updates {
-		if update.Message != nil {
-			if update.Message.IsCommand() {
-				t.handleCommand(update.Message)
-			} else {
-				t.handleMessage(update.Message)
-			}
-			continue
+		if event, ok := telegramEvent(update); ok {
+			t.handler.Handle(event)
 		}
+	}
+}
 
-		if update.CallbackQuery != nil {
-			t.handleCallbackQuery(update.CallbackQuery)
-		}
+func (t *TelegramAPI) Send(msg chat.Message) (chat.Sent, error) {
+	var c tgbotapi.Chattable = telegramMessage(msg)
+	switch {
+	case msg.Poll != nil:
+		c = telegramPoll(msg)
+	case msg.Photo != nil:
+		c = telegramPhoto(msg)
+	case msg.Audio != nil:
+		c = telegramAudio(msg)
+	}
+
+	sent, err := t.bot.Send(c)
+	if err != nil {
+		return chat.Sent{}, err
+	}
+
+	result := chat.Sent{MessageID: sent.MessageID}
+	switch {
+	case sent.Poll != nil:
+		result.PollID = sent.Poll.ID
+	case sent.Audio != nil:
+		result.FileID = sent.Audio.FileID
+	case sent.Document != nil:
+		// Telegram keeps files it can't play as documents.
+		result.FileID = sent.Document.FileID
 	}
+	return result, nil
+}
+
+func (t *TelegramAPI) Edit(edit chat.Edit) error {
+	_, err := t.bot.Send(telegramEdit(edit))
+	return err
 }
 
-func sendMessage(bot BotSender, msg tgbotapi.Chattable) {
-	sentMsg, err := bot.Send(msg)
+func (t *TelegramAPI) AnswerCallback(callbackID, text string) error {
+	callback := tgbotapi.NewCallback(callbackID, text)
+	callback.ShowAlert = false
+	_, err := t.bot.Request(callback)
+	return err
+}
+
+func (t *TelegramAPI) AnswerInline(queryID string, results []chat.InlineResult) error {
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: queryID,
+		Results:       make([]interface{}, 0, len(results)),
+		CacheTime:     inlineCacheTime,
+		IsPersonal:    true,
+	}
+	for _, result := range results {
+		answer.Results = append(answer.Results, telegramArticle(result))
+	}
+
+	_, err := t.bot.Request(answer)
+	return err
+}
+
+func (t *TelegramAPI) DownloadFile(fileID string) ([]byte, error) {
+	file, err := t.bot.GetFile(tgbotapi.FileConfig{FileID: fileID})
 	if err != nil {
-		log.Printf("Failed to send message: %v", err)
-	} else {
-		log.Printf("Sent message to %d", sentMsg.Chat.ID)
+		return nil, err
+	}
+	if file.FileSize > maxDownloadSize {
+		return nil, chat.ErrFileTooLarge
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.Link(t.bot.Token), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The link holds the bot token, keep it out of the logs.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDownloadSize {
+		return nil, chat.ErrFileTooLarge
+	}
+
+	return data, nil
+}
+
+func telegramEvent(update tgbotapi.Update) (chat.Event, bool) {
+	if message := update.Message; message != nil {
+		event := chat.Event{
+			Type:      chat.EventMessage,
+			ChatID:    message.Chat.ID,
+			Group:     isGroup(message.Chat),
+			MessageID: message.MessageID,
+			Text:      message.Text,
+			From:      telegramUser(message.From),
+		}
+		if doc := message.Document; doc != nil {
+			event.Document = &chat.File{ID: doc.FileID, Name: doc.FileName}
+			event.Text = message.Caption
+		}
+		if message.IsCommand() {
+			event.Type = chat.EventCommand
+			event.Command = message.Command()
+			event.Args = message.CommandArguments()
+		}
+		return event, true
+	}
+
+	if query := update.CallbackQuery; query != nil {
+		event := chat.Event{
+			Type:       chat.EventCallback,
+			CallbackID: query.ID,
+			Data:       query.Data,
+			From:       telegramUser(query.From),
+			ChatID:     query.From.ID,
+		}
+		if query.Message != nil {
+			event.ChatID = query.Message.Chat.ID
+			event.Group = isGroup(query.Message.Chat)
+			event.MessageID = query.Message.MessageID
+			event.Text = query.Message.Text
+		}
+		return event, true
 	}
+
+	if answer := update.PollAnswer; answer != nil {
+		return chat.Event{
+			Type:    chat.EventPollAnswer,
+			From:    telegramUser(&answer.User),
+			PollID:  answer.PollID,
+			Options: answer.OptionIDs,
+		}, true
+	}
+
+	if query := update.InlineQuery; query != nil {
+		return chat.Event{
+			Type:    chat.EventInline,
+			QueryID: query.ID,
+			Text:    query.Query,
+			From:    telegramUser(query.From),
+			ChatID:  query.From.ID,
+		}, true
+	}
+
+	return chat.Event{}, false
+}
+
+func isGroup(c *tgbotapi.Chat) bool {
+	return c != nil && (c.IsGroup() || c.IsSuperGroup())
+}
+
+func telegramUser(user *tgbotapi.User) chat.User {
+	if user == nil {
+		return chat.User{}
+	}
+	return chat.User{
+		ID:           user.ID,
+		UserName:     user.UserName,
+		FirstName:    user.FirstName,
+		LanguageCode: user.LanguageCode,
+	}
+}
+
+func telegramMessage(msg chat.Message) tgbotapi.MessageConfig {
+	m := tgbotapi.NewMessage(msg.ChatID, msg.Text)
+	if msg.Markdown {
+		m.ParseMode = "markdown"
+	}
+
+	if len(msg.Buttons) > 0 {
+		m.ReplyMarkup = telegramInlineKeyboard(msg.Buttons)
+	} else if len(msg.Menu) > 0 {
+		m.ReplyMarkup = telegramMenu(msg.Menu)
+	}
+
+	return m
+}
+
+func telegramPhoto(msg chat.Message) tgbotapi.PhotoConfig {
+	p := tgbotapi.NewPhoto(msg.ChatID, tgbotapi.FileBytes{Name: msg.Photo.Name, Bytes: msg.Photo.Data})
+	p.Caption = msg.Text
+	if msg.Markdown {
+		p.ParseMode = "markdown"
+	}
+
+	if len(msg.Buttons) > 0 {
+		p.ReplyMarkup = telegramInlineKeyboard(msg.Buttons)
+	} else if len(msg.Menu) > 0 {
+		p.ReplyMarkup = telegramMenu(msg.Menu)
+	}
+
+	return p
+}
+
+func telegramAudio(msg chat.Message) tgbotapi.AudioConfig {
+	var file tgbotapi.RequestFileData = tgbotapi.FileBytes{Name: msg.Audio.Name, Bytes: msg.Audio.Data}
+	if msg.Audio.ID != "" {
+		file = tgbotapi.FileID(msg.Audio.ID)
+	}
+
+	a := tgbotapi.NewAudio(msg.ChatID, file)
+	// The title is taken from the file name, not from the tags in the file.
+	if msg.Audio.ID == "" {
+		a.Title = strings.TrimSuffix(msg.Audio.Name, path.Ext(msg.Audio.Name))
+	}
+	a.Caption = msg.Text
+	if msg.Markdown {
+		a.ParseMode = "markdown"
+	}
+
+	return a
+}
+
+// telegramPoll sends a quiz poll. It isn't anonymous, otherwise Telegram
+// doesn't report the answers.
+func telegramPoll(msg chat.Message) tgbotapi.SendPollConfig {
+	p := tgbotapi.NewPoll(msg.ChatID, msg.Poll.Question, msg.Poll.Options...)
+	p.Type = "quiz"
+	p.IsAnonymous = false
+	p.CorrectOptionID = int64(msg.Poll.CorrectOption)
+	p.Explanation = msg.Poll.Explanation
+
+	return p
+}
+
+func telegramArticle(result chat.InlineResult) tgbotapi.InlineQueryResultArticle {
+	a := tgbotapi.NewInlineQueryResultArticle(result.ID, result.Title, result.Text)
+	if result.Markdown {
+		a = tgbotapi.NewInlineQueryResultArticleMarkdown(result.ID, result.Title, result.Text)
+	}
+	a.Description = result.Description
+	if len(result.Buttons) > 0 {
+		a.ReplyMarkup = telegramInlineKeyboard(result.Buttons)
+	}
+
+	return a
+}
+
+func telegramEdit(edit chat.Edit) tgbotapi.EditMessageTextConfig {
+	e := tgbotapi.NewEditMessageText(edit.ChatID, edit.MessageID, edit.Text)
+	if edit.Markdown {
+		e.ParseMode = "markdown"
+	}
+	if len(edit.Buttons) > 0 {
+		e.ReplyMarkup = telegramInlineKeyboard(edit.Buttons)
+	}
+	return e
+}
+
+func telegramInlineKeyboard(buttons [][]chat.Button) *tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(buttons))
+	for _, row := range buttons {
+		r := make([]tgbotapi.InlineKeyboardButton, 0, len(row))
+		for _, b := range row {
+			r = append(r, tgbotapi.NewInlineKeyboardButtonData(b.Text, b.Data))
+		}
+		rows = append(rows, r)
+	}
+	return &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func telegramMenu(menu [][]string) tgbotapi.ReplyKeyboardMarkup {
+	rows := make([][]tgbotapi.KeyboardButton, 0, len(menu))
+	for _, row := range menu {
+		r := make([]tgbotapi.KeyboardButton, 0, len(row))
+		for _, text := range row {
+			r = append(r, tgbotapi.NewKeyboardButton(text))
+		}
+		rows = append(rows, r)
+	}
+
+	keyboard := tgbotapi.NewReplyKeyboard(rows...)
+	keyboard.ResizeKeyboard = true
+	keyboard.OneTimeKeyboard = false
+
+	return keyboard
 }
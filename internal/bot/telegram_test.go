@@ -0,0 +1,247 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		update tgbotapi.Update
+		want   chat.Event
+		wantOk bool
+	}{
+		{
+			name: "text message",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{
+				MessageID: 7,
+				Chat:      &tgbotapi.Chat{ID: 123},
+				From:      &tgbotapi.User{ID: 456, UserName: "bob", LanguageCode: "en"},
+				Text:      ButtonQuiz,
+			}},
+			want: chat.Event{
+				Type:      chat.EventMessage,
+				ChatID:    123,
+				MessageID: 7,
+				Text:      ButtonQuiz,
+				From:      chat.User{ID: 456, UserName: "bob", LanguageCode: "en"},
+			},
+			wantOk: true,
+		},
+		{
+			name: "document with caption",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{
+				MessageID: 9,
+				Chat:      &tgbotapi.Chat{ID: 123},
+				From:      &tgbotapi.User{ID: 456},
+				Document:  &tgbotapi.Document{FileID: "file", FileName: "story.txt"},
+				Caption:   "chapter one",
+			}},
+			want: chat.Event{
+				Type:      chat.EventMessage,
+				ChatID:    123,
+				MessageID: 9,
+				Text:      "chapter one",
+				Document:  &chat.File{ID: "file", Name: "story.txt"},
+				From:      chat.User{ID: 456},
+			},
+			wantOk: true,
+		},
+		{
+			name: "command with arguments",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{
+				MessageID: 8,
+				Chat:      &tgbotapi.Chat{ID: 123},
+				From:      &tgbotapi.User{ID: 456},
+				Text:      "/start ref",
+				Entities:  []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 6}},
+			}},
+			want: chat.Event{
+				Type:      chat.EventCommand,
+				ChatID:    123,
+				MessageID: 8,
+				Text:      "/start ref",
+				Command:   "start",
+				Args:      "ref",
+				From:      chat.User{ID: 456},
+			},
+			wantOk: true,
+		},
+		{
+			name: "command addressed to the bot in a group",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{
+				MessageID: 10,
+				Chat:      &tgbotapi.Chat{ID: -100123, Type: "supergroup"},
+				From:      &tgbotapi.User{ID: 456},
+				Text:      "/groupquiz@vocabot",
+				Entities:  []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 18}},
+			}},
+			want: chat.Event{
+				Type:      chat.EventCommand,
+				ChatID:    -100123,
+				Group:     true,
+				MessageID: 10,
+				Text:      "/groupquiz@vocabot",
+				Command:   "groupquiz",
+				From:      chat.User{ID: 456},
+			},
+			wantOk: true,
+		},
+		{
+			name: "callback",
+			update: tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+				ID:   "cb",
+				From: &tgbotapi.User{ID: 456},
+				Message: &tgbotapi.Message{
+					MessageID: 9,
+					Chat:      &tgbotapi.Chat{ID: 123},
+					Text:      "card",
+				},
+				Data: "know",
+			}},
+			want: chat.Event{
+				Type:       chat.EventCallback,
+				ChatID:     123,
+				MessageID:  9,
+				Text:       "card",
+				CallbackID: "cb",
+				Data:       "know",
+				From:       chat.User{ID: 456},
+			},
+			wantOk: true,
+		},
+		{
+			name: "poll answer",
+			update: tgbotapi.Update{PollAnswer: &tgbotapi.PollAnswer{
+				PollID:    "poll",
+				User:      tgbotapi.User{ID: 456, LanguageCode: "en"},
+				OptionIDs: []int{2},
+			}},
+			want: chat.Event{
+				Type:    chat.EventPollAnswer,
+				PollID:  "poll",
+				Options: []int{2},
+				From:    chat.User{ID: 456, LanguageCode: "en"},
+			},
+			wantOk: true,
+		},
+		{
+			name: "inline query",
+			update: tgbotapi.Update{InlineQuery: &tgbotapi.InlineQuery{
+				ID:    "query",
+				From:  &tgbotapi.User{ID: 456, LanguageCode: "en"},
+				Query: "apple",
+			}},
+			want: chat.Event{
+				Type:    chat.EventInline,
+				ChatID:  456,
+				QueryID: "query",
+				Text:    "apple",
+				From:    chat.User{ID: 456, LanguageCode: "en"},
+			},
+			wantOk: true,
+		},
+		{
+			name:   "unsupported update",
+			update: tgbotapi.Update{EditedMessage: &tgbotapi.Message{}},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := telegramEvent(tt.update)
+			assert.Equal(t, tt.wantOk, ok)
+			if ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTelegramMessage(t *testing.T) {
+	t.Parallel()
+
+	msg := chat.NewMessage(123, "text")
+	msg.Markdown = true
+	msg.Buttons = [][]chat.Button{chat.Row(chat.NewButton("A", "a"), chat.NewButton("B", "b"))}
+
+	got := telegramMessage(msg)
+	assert.Equal(t, int64(123), got.ChatID)
+	assert.Equal(t, "markdown", got.ParseMode)
+	kb, ok := got.ReplyMarkup.(*tgbotapi.InlineKeyboardMarkup)
+	require.True(t, ok)
+	assert.Equal(t, "B", kb.InlineKeyboard[0][1].Text)
+	assert.Equal(t, "b", *kb.InlineKeyboard[0][1].CallbackData)
+
+	menu := chat.NewMessage(123, "menu")
+	menu.Menu = [][]string{{ButtonNewWord, ButtonQuiz}}
+
+	got = telegramMessage(menu)
+	assert.Empty(t, got.ParseMode)
+	reply, ok := got.ReplyMarkup.(tgbotapi.ReplyKeyboardMarkup)
+	require.True(t, ok)
+	assert.True(t, reply.ResizeKeyboard)
+	assert.Equal(t, ButtonQuiz, reply.Keyboard[0][1].Text)
+
+	photo := telegramPhoto(chat.NewPhoto(123, "chart.png", []byte{1, 2, 3}))
+	assert.Equal(t, int64(123), photo.ChatID)
+	file, ok := photo.File.(tgbotapi.FileBytes)
+	require.True(t, ok)
+	assert.Equal(t, "chart.png", file.Name)
+	assert.Equal(t, []byte{1, 2, 3}, file.Bytes)
+
+	audio := telegramAudio(chat.NewAudio(123, chat.File{Name: "apple.mp3", Data: []byte{1}}))
+	upload, ok := audio.File.(tgbotapi.FileBytes)
+	require.True(t, ok)
+	assert.Equal(t, "apple.mp3", upload.Name)
+	assert.Equal(t, "apple", audio.Title)
+
+	audio = telegramAudio(chat.NewAudio(123, chat.File{ID: "cached"}))
+	assert.Equal(t, tgbotapi.FileID("cached"), audio.File)
+
+	poll := telegramPoll(chat.NewPoll(123, chat.Poll{
+		Question:      "hello?",
+		Options:       []string{"пока", "привет"},
+		CorrectOption: 1,
+		Explanation:   "hello — привет",
+	}))
+	assert.Equal(t, "quiz", poll.Type)
+	assert.False(t, poll.IsAnonymous)
+	assert.Equal(t, int64(1), poll.CorrectOptionID)
+	assert.Equal(t, []string{"пока", "привет"}, poll.Options)
+	assert.Equal(t, "hello — привет", poll.Explanation)
+
+	edit := telegramEdit(chat.Edit{ChatID: 123, MessageID: 5, Text: "edited"})
+	assert.Equal(t, 5, edit.MessageID)
+	assert.Nil(t, edit.ReplyMarkup)
+}
+
+func TestTelegramArticle(t *testing.T) {
+	t.Parallel()
+
+	got := telegramArticle(chat.InlineResult{
+		ID:          "0",
+		Title:       "apple",
+		Description: "яблоко",
+		Text:        "**apple**",
+		Markdown:    true,
+		Buttons:     [][]chat.Button{chat.Row(chat.NewButton("Save", "isave_apple"))},
+	})
+	assert.Equal(t, "0", got.ID)
+	assert.Equal(t, "яблоко", got.Description)
+	content, ok := got.InputMessageContent.(tgbotapi.InputTextMessageContent)
+	require.True(t, ok)
+	assert.Equal(t, "**apple**", content.Text)
+	assert.Equal(t, "Markdown", content.ParseMode)
+	require.NotNil(t, got.ReplyMarkup)
+	assert.Equal(t, "isave_apple", *got.ReplyMarkup.InlineKeyboard[0][0].CallbackData)
+}
@@ -0,0 +1,251 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/lemma"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+// Checklist callbacks are "text_<index>" to tick a word, "text_all" and
+// "text_add".
+const (
+	textPrefix   = "text_"
+	textAll      = textPrefix + "all"
+	textAdd      = textPrefix + "add"
+	textWordsRow = 2
+
+	// minTextWords tells a text from a few words typed by mistake.
+	minTextWords = 3
+)
+
+// TextT finds new words in texts users paste, forward or send as .txt files
+// and lets them pick the ones to add.
+type TextT struct {
+	bot      BotSender
+	sessions SessionStore
+	service  WordSI
+}
+
+func NewTextTAPI(bot BotSender, sessions SessionStore, service WordSI) *TextT {
+	return &TextT{
+		bot:      bot,
+		sessions: sessions,
+		service:  service,
+	}
+}
+
+func isText(text string) bool {
+	return len(lemma.Tokenize(text)) >= minTextWords
+}
+
+func (t *TextT) sendFileWords(event chat.Event, lang string) {
+	if !strings.EqualFold(path.Ext(event.Document.Name), ".txt") {
+		sendMessage(t.bot, chat.NewMessage(event.ChatID, i18n.T(lang, "text.file_unsupported")))
+		return
+	}
+
+	data, err := t.bot.DownloadFile(event.Document.ID)
+	if err != nil {
+		log.Printf("Failed to download file of user %d: %v", event.From.ID, err)
+		key := "text.error"
+		if errors.Is(err, chat.ErrFileTooLarge) {
+			key = "text.file_too_large"
+		}
+		sendMessage(t.bot, chat.NewMessage(event.ChatID, i18n.T(lang, key)))
+		return
+	}
+	if !utf8.Valid(data) {
+		sendMessage(t.bot, chat.NewMessage(event.ChatID, i18n.T(lang, "text.file_unsupported")))
+		return
+	}
+
+	t.sendWords(event.ChatID, event.From.ID, string(data), lang)
+}
+
+// sendWords sends the checklist of the new words of text.
+func (t *TextT) sendWords(chatID, userID int64, text, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	words, err := t.service.TextWords(ctx, userID, text)
+	if err != nil {
+		log.Printf("Failed to find words in text of user %d: %v", userID, err)
+		sendMessage(t.bot, chat.NewMessage(chatID, i18n.T(lang, errorKey(err, "text.error"))))
+		return
+	}
+	if len(words) == 0 {
+		sendMessage(t.bot, chat.NewMessage(chatID, i18n.T(lang, "text.empty")))
+		return
+	}
+
+	list := models.WordChecklist{
+		UserID:   userID,
+		Words:    words,
+		Selected: make([]bool, len(words)),
+	}
+
+	msg := chat.NewMessage(chatID, checklistText(list, lang))
+	msg.Buttons = checklistButtons(list, lang)
+
+	sent, err := sendMessage(t.bot, msg)
+	if err != nil {
+		return
+	}
+
+	key := models.SessionKey{ChatID: chatID, MessageID: sent.MessageID}
+	if err := t.sessions.SetChecklist(ctx, key, list); err != nil {
+		log.Printf("Failed to save checklist for user %d: %v", userID, err)
+	}
+}
+
+// handleCallback ticks words of a checklist and adds the ticked ones. It
+// answers the callback itself, to tell when nothing is ticked.
+func (t *TextT) handleCallback(event chat.Event, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var notice string
+	defer func() {
+		if err := t.bot.AnswerCallback(event.CallbackID, notice); err != nil {
+			log.Printf("Failed to answer callback: %v", err)
+		}
+	}()
+
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	key := models.SessionKey{ChatID: event.ChatID, MessageID: event.MessageID}
+	list, exists, err := t.sessions.GetChecklist(ctx, key)
+	if err != nil {
+		log.Printf("Failed to get checklist for user %d: %v", event.From.ID, err)
+	}
+	if !exists {
+		editMessage(t.bot, chat.NewEdit(event.ChatID, event.MessageID, i18n.T(lang, "text.expired")))
+		return
+	}
+	if list.UserID != event.From.ID {
+		log.Printf("User %d pressed the checklist of user %d, ignoring", event.From.ID, list.UserID)
+		return
+	}
+
+	switch data := event.Data; data {
+	case textAdd:
+		notice = t.addWords(ctx, key, list, lang)
+		return
+	case textAll:
+		all := !allSelected(list.Selected)
+		for i := range list.Selected {
+			list.Selected[i] = all
+		}
+	default:
+		i, err := strconv.Atoi(strings.TrimPrefix(data, textPrefix))
+		if err != nil || i < 0 || i >= len(list.Selected) {
+			log.Printf("Invalid checklist callback %q from user %d", data, event.From.ID)
+			return
+		}
+		list.Selected[i] = !list.Selected[i]
+	}
+
+	if err := t.sessions.SetChecklist(ctx, key, list); err != nil {
+		log.Printf("Failed to save checklist for user %d: %v", event.From.ID, err)
+		return
+	}
+
+	edit := chat.NewEdit(event.ChatID, event.MessageID, checklistText(list, lang))
+	edit.Buttons = checklistButtons(list, lang)
+	editMessage(t.bot, edit)
+}
+
+// addWords saves the ticked words and returns the callback notice.
+func (t *TextT) addWords(ctx context.Context, key models.SessionKey, list models.WordChecklist, lang string) string {
+	var picked []models.TextWord
+	for i, w := range list.Words {
+		if list.Selected[i] {
+			picked = append(picked, w)
+		}
+	}
+	if len(picked) == 0 {
+		return i18n.T(lang, "text.nothing_selected")
+	}
+
+	if err := t.service.AddTextWords(ctx, list.UserID, picked); err != nil {
+		log.Printf("Failed to add words of text for user %d: %v", list.UserID, err)
+		return i18n.T(lang, "text.error")
+	}
+
+	if err := t.sessions.DeleteChecklist(ctx, key); err != nil {
+		log.Printf("Failed to delete checklist for user %d: %v", list.UserID, err)
+	}
+
+	names := make([]string, len(picked))
+	for i, w := range picked {
+		names[i] = w.Word
+	}
+	editMessage(t.bot, chat.NewEdit(key.ChatID, key.MessageID, i18n.T(lang, "text.added", len(picked), strings.Join(names, ", "))))
+
+	return ""
+}
+
+func checklistText(list models.WordChecklist, lang string) string {
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "text.found"))
+	sb.WriteString("\n")
+	for _, w := range list.Words {
+		sb.WriteString("\n• " + w.Word + " — " + w.Translation)
+	}
+	return sb.String()
+}
+
+func checklistButtons(list models.WordChecklist, lang string) [][]chat.Button {
+	var (
+		rows     [][]chat.Button
+		row      []chat.Button
+		selected int
+	)
+	for i, w := range list.Words {
+		mark := "⬜ "
+		if list.Selected[i] {
+			mark = "✅ "
+			selected++
+		}
+		row = append(row, chat.NewButton(mark+w.Word, textPrefix+strconv.Itoa(i)))
+		if len(row) == textWordsRow {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	all := i18n.T(lang, "text.select_all")
+	if allSelected(list.Selected) {
+		all = i18n.T(lang, "text.clear")
+	}
+
+	return append(rows, chat.Row(
+		chat.NewButton(all, textAll),
+		chat.NewButton(i18n.T(lang, "text.add", selected), textAdd),
+	))
+}
+
+func allSelected(selected []bool) bool {
+	for _, s := range selected {
+		if !s {
+			return false
+		}
+	}
+	return true
+}
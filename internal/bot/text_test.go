@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const textChatID = 123
+
+func textCallback(messageID int, data string) chat.Event {
+	return chat.Event{
+		Type:       chat.EventCallback,
+		ChatID:     textChatID,
+		From:       chat.User{ID: 1},
+		MessageID:  messageID,
+		CallbackID: "cb",
+		Data:       data,
+	}
+}
+
+func TestTextT_checklist(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const text = "Herons wade in the brook near the meadow."
+	words := []models.TextWord{
+		{Word: "heron", Translation: "цапля", Count: 1},
+		{Word: "wade", Translation: "переходить вброд", Count: 1},
+		{Word: "meadow", Translation: "луг", Count: 1},
+	}
+
+	handler, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+		ms.EXPECT().TextWords(gomock.Any(), int64(1), text).Return(words, nil)
+		ms.EXPECT().AddTextWords(gomock.Any(), int64(1), []models.TextWord{words[2]}).Return(nil)
+	})
+
+	handler.Handle(chat.Event{Type: chat.EventMessage, ChatID: textChatID, From: chat.User{ID: 1}, Text: text})
+
+	require.Len(t, mb.SentMessages, 1)
+	msg := mb.SentMessages[0].(chat.Message)
+	assert.Equal(t, i18n.T(i18n.Default, "text.found")+"\n\n• heron — цапля\n• wade — переходить вброд\n• meadow — луг", msg.Text)
+	assert.Equal(t, [][]chat.Button{
+		chat.Row(chat.NewButton("⬜ heron", "text_0"), chat.NewButton("⬜ wade", "text_1")),
+		chat.Row(chat.NewButton("⬜ meadow", "text_2")),
+		chat.Row(
+			chat.NewButton(i18n.T(i18n.Default, "text.select_all"), textAll),
+			chat.NewButton(i18n.T(i18n.Default, "text.add", 0), textAdd),
+		),
+	}, msg.Buttons)
+
+	handler.Handle(textCallback(1, textAdd))
+	assert.Equal(t, []string{i18n.T(i18n.Default, "text.nothing_selected")}, mb.CallbackTexts)
+
+	handler.Handle(textCallback(1, textAll))
+	edit := mb.SentMessages[1].(chat.Edit)
+	assert.Equal(t, "✅ heron", edit.Buttons[0][0].Text)
+	assert.Equal(t, i18n.T(i18n.Default, "text.clear"), edit.Buttons[2][0].Text)
+	assert.Equal(t, i18n.T(i18n.Default, "text.add", 3), edit.Buttons[2][1].Text)
+
+	handler.Handle(textCallback(1, textAll))
+	handler.Handle(textCallback(1, "text_2"))
+	edit = mb.SentMessages[3].(chat.Edit)
+	assert.Equal(t, "⬜ heron", edit.Buttons[0][0].Text)
+	assert.Equal(t, "✅ meadow", edit.Buttons[1][0].Text)
+
+	// Someone else's presses and broken data change nothing.
+	other := textCallback(1, "text_0")
+	other.From.ID = 2
+	handler.Handle(other)
+	handler.Handle(textCallback(1, "text_9"))
+	require.Len(t, mb.SentMessages, 4)
+
+	handler.Handle(textCallback(1, textAdd))
+	edit = mb.SentMessages[4].(chat.Edit)
+	assert.Equal(t, i18n.T(i18n.Default, "text.added", 1, "meadow"), edit.Text)
+	assert.Empty(t, edit.Buttons)
+
+	handler.Handle(textCallback(1, "text_0"))
+	edit = mb.SentMessages[5].(chat.Edit)
+	assert.Equal(t, i18n.T(i18n.Default, "text.expired"), edit.Text)
+
+	assert.Len(t, mb.AnsweredCallbacks, 8, "every press is answered")
+}
+
+func TestTextT_sendWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		event chat.Event
+		files map[string][]byte
+		f     func(*mock_bot.MockServiceI)
+		want  string
+	}{
+		{
+			name:  "text file",
+			event: chat.Event{Document: &chat.File{ID: "f", Name: "Chapter 1.TXT"}},
+			files: map[string][]byte{"f": []byte("Herons wade in the brook.")},
+			f: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().TextWords(gomock.Any(), int64(1), "Herons wade in the brook.").Return(nil, nil)
+			},
+			want: i18n.T(i18n.Default, "text.empty"),
+		},
+		{
+			name:  "other file",
+			event: chat.Event{Document: &chat.File{ID: "f", Name: "book.pdf"}},
+			want:  i18n.T(i18n.Default, "text.file_unsupported"),
+		},
+		{
+			name:  "binary file",
+			event: chat.Event{Document: &chat.File{ID: "f", Name: "book.txt"}},
+			files: map[string][]byte{"f": {0xff, 0xfe, 0x00}},
+			want:  i18n.T(i18n.Default, "text.file_unsupported"),
+		},
+		{
+			name:  "download error",
+			event: chat.Event{Document: &chat.File{ID: "missing", Name: "book.txt"}},
+			want:  i18n.T(i18n.Default, "text.error"),
+		},
+		{
+			name:  "service error",
+			event: chat.Event{Text: "Herons wade in the brook."},
+			f: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().TextWords(gomock.Any(), int64(1), gomock.Any()).Return(nil, assert.AnError)
+			},
+			want: i18n.T(i18n.Default, "text.error"),
+		},
+		{
+			name:  "translation rate limited",
+			event: chat.Event{Text: "Herons wade in the brook."},
+			f: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().TextWords(gomock.Any(), int64(1), gomock.Any()).Return(nil, ratelimit.ErrLimited)
+			},
+			want: i18n.T(i18n.Default, "rate_limit.wait"),
+		},
+		{
+			name:  "too short for a text",
+			event: chat.Event{Text: "hello there"},
+			want:  i18n.T(i18n.Default, "message.unknown"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+				if tt.f != nil {
+					tt.f(ms)
+				}
+			})
+			mb.Files = tt.files
+
+			event := tt.event
+			event.Type = chat.EventMessage
+			event.ChatID = textChatID
+			event.From = chat.User{ID: 1}
+			handler.Handle(event)
+
+			require.Len(t, mb.SentMessages, 1)
+			assert.Equal(t, tt.want, mb.SentMessages[0].(chat.Message).Text)
+		})
+	}
+}
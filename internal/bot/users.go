@@ -0,0 +1,32 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type UserSI interface {
+	TouchUser(ctx context.Context, user models.User) error
+	UserSettings(ctx context.Context, userID int64) (models.UserSettings, error)
+	UpdateUserSettings(ctx context.Context, userID int64, patch models.UserSettings) error
+}
+
+// touchUser keeps the users registry up to date, a failure doesn't stop the
+// update from being handled.
+func (h *Handler) touchUser(from chat.User) {
+	if from.ID == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user := models.User{ID: from.ID, UserName: from.UserName, FirstName: from.FirstName, LanguageCode: from.LanguageCode}
+	if err := h.users.TouchUser(ctx, user); err != nil {
+		log.Printf("Failed to touch user %d: %v", from.ID, err)
+	}
+}
@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+// Quizzes answered with English words. The type is also the command that
+// asks one, and the i18n keys of the question start with it.
+const (
+	clozeType      = "cloze"
+	synonymType    = "synonym"
+	definitionType = "definition"
+
+	newWordQuizPrefix = "new_"
+)
+
+var wordQuizzes = map[string]func(QuizSI, context.Context, int64) (models.WordQuiz, error){
+	clozeType:      QuizSI.NewCloze,
+	synonymType:    QuizSI.NewSynonymQuiz,
+	definitionType: QuizSI.NewDefinitionQuiz,
+}
+
+func isWordQuizCallback(data string) bool {
+	quizType, ok := strings.CutPrefix(data, newWordQuizPrefix)
+	_, known := wordQuizzes[quizType]
+	return ok && known
+}
+
+// sendWordQuiz asks a quiz of the given type, like which word fills the gap
+// in an example sentence. Answers are handled like any other quiz.
+func (t *QuizT) sendWordQuiz(chatID, userID int64, quizType, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if userID == 0 {
+		log.Printf("Message without sender: %d", chatID)
+		return
+	}
+
+	newQuiz, ok := wordQuizzes[quizType]
+	if !ok {
+		log.Printf("unknown word quiz type %q", quizType)
+		return
+	}
+
+	wordQuiz, err := newQuiz(t.service, ctx, userID)
+	if err != nil {
+		log.Printf("failed to get %s quiz for chat %d: %v", quizType, chatID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, errorKey(err, "quiz.error")))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	quiz := models.QuizCard{UserID: userID, Word: wordQuiz.Word, Translation: wordQuiz.Translation, Type: quizType}
+	for option, isCorrect := range wordQuiz.Options {
+		if isCorrect {
+			quiz.Answer = option
+		}
+	}
+
+	t.sendQuestion(ctx, chatID, quiz, i18n.T(lang, quizType+".question", wordQuiz.Question), wordQuiz.Options, lang)
+}
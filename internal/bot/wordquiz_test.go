@@ -0,0 +1,209 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuizT_sendWordQuiz(t *testing.T) {
+	t.Parallel()
+
+	cloze := models.WordQuiz{
+		Word:        "walk",
+		Translation: "гулять",
+		Question:    "We ＿＿＿ home.",
+		Options:     map[string]bool{"walk": true, "house": false, "green": false, "sing": false},
+	}
+
+	tests := []struct {
+		name       string
+		f          func(*mock_bot.MockServiceI, *mock_bot.MockBot)
+		assertFunc func(*testing.T, *QuizT, *mock_bot.MockBot)
+	}{
+		{
+			name: "sentence with word options",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewCloze(gomock.Any(), int64(456)).Return(cloze, nil)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, i18n.T(i18n.Default, "cloze.question", cloze.Question), msg.Text)
+				require.Len(t, msg.Buttons, 2)
+
+				quiz, exists, err := quizT.sessions.GetQuiz(context.Background(), models.SessionKey{ChatID: 123, MessageID: 1})
+				require.NoError(t, err)
+				require.True(t, exists)
+				assert.Equal(t, clozeType, quiz.Type)
+				assert.Equal(t, "walk", quiz.Word)
+				assert.Equal(t, "walk", quiz.Answer)
+				assert.Equal(t, "гулять", quiz.Translation)
+			},
+		},
+		{
+			name: "sentence is escaped",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				quiz := cloze
+				quiz.Question = "Name it my_var or *x* ＿＿＿."
+				ms.EXPECT().NewCloze(gomock.Any(), int64(456)).Return(quiz, nil)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.True(t, msg.Markdown)
+				assert.Equal(t, i18n.T(i18n.Default, "cloze.question", `Name it my\_var or \*x\* ＿＿＿.`), msg.Text)
+			},
+		},
+		{
+			name: "poll keeps the translation",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{QuizMode: models.QuizModePoll}, nil)
+				ms.EXPECT().NewCloze(gomock.Any(), int64(456)).Return(cloze, nil)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				poll := mb.SentMessages[0].(chat.Message).Poll
+				require.NotNil(t, poll)
+				assert.Equal(t, "walk", poll.Options[poll.CorrectOption])
+				assert.Equal(t, i18n.T(i18n.Default, "quiz.explanation", "walk", "гулять"), poll.Explanation)
+
+				quiz, exists, err := quizT.sessions.GetQuiz(context.Background(), models.SessionKey{ChatID: 123, MessageID: 1})
+				require.NoError(t, err)
+				require.True(t, exists)
+				assert.Equal(t, "гулять", quiz.Translation)
+			},
+		},
+		{
+			name: "quiz error",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewCloze(gomock.Any(), int64(456)).Return(models.WordQuiz{}, assert.AnError)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "quiz.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name: "rate limited",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().NewCloze(gomock.Any(), int64(456)).Return(models.WordQuiz{}, ratelimit.ErrLimited)
+			},
+			assertFunc: func(t *testing.T, quizT *QuizT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "rate_limit.wait"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quizT := newQuizTMock(t, ctrl, tt.f)
+			mb := quizT.bot.(*mock_bot.MockBot)
+
+			quizT.sendWordQuiz(123, 456, clozeType, i18n.Default)
+
+			tt.assertFunc(t, quizT, mb)
+		})
+	}
+}
+
+func TestQuizT_wordQuizAnswer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		quiz     models.QuizCard
+		wantText string
+	}{
+		{
+			name:     "cloze",
+			quiz:     models.QuizCard{UserID: 456, Word: "walk", Translation: "гулять", Answer: "walk", Type: clozeType},
+			wantText: i18n.T(i18n.Default, "cloze.word", "walk"),
+		},
+		{
+			name:     "synonym",
+			quiz:     models.QuizCard{UserID: 456, Word: "big", Translation: "большой", Answer: "large", Type: synonymType},
+			wantText: i18n.T(i18n.Default, "synonym.answer", "big", "large"),
+		},
+		{
+			name:     "definition",
+			quiz:     models.QuizCard{UserID: 456, Word: "run", Translation: "бежать", Answer: "to move fast", Type: definitionType},
+			wantText: i18n.T(i18n.Default, "definition.answer", "run", "to move fast"),
+		},
+		{
+			name:     "definition is escaped",
+			quiz:     models.QuizCard{UserID: 456, Word: "star", Translation: "звезда", Answer: "the * symbol, see [1]", Type: definitionType},
+			wantText: i18n.T(i18n.Default, "definition.answer", "star", `the \* symbol, see \[1]`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quizT := newQuizTMock(t, ctrl, func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, result models.QuizCard) error {
+					assert.Equal(t, tt.quiz.Type, result.Type)
+					assert.Equal(t, tt.quiz.Translation, result.Translation)
+					assert.False(t, result.IsCorrect)
+					return nil
+				})
+			})
+			mb := quizT.bot.(*mock_bot.MockBot)
+
+			key := models.SessionKey{ChatID: 123, MessageID: 2}
+			require.NoError(t, quizT.sessions.SetQuiz(context.Background(), key, tt.quiz))
+
+			quizT.handleQuizCallbackQuery(chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 2, From: chat.User{ID: 456}, Data: "quiz_wrong"}, i18n.Default)
+
+			require.Len(t, mb.SentMessages, 1)
+			edit := mb.SentMessages[0].(chat.Edit)
+			assert.Contains(t, edit.Text, tt.wantText)
+			assert.Equal(t, "new_"+tt.quiz.Type, edit.Buttons[0][0].Data)
+			assert.True(t, isWordQuizCallback(edit.Buttons[0][0].Data))
+		})
+	}
+}
+
+func TestHandler_wordQuizzes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mb := newHandlerMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().Language(gomock.Any(), int64(456)).Return("", nil).AnyTimes()
+		ms.EXPECT().NewSynonymQuiz(gomock.Any(), int64(456)).Return(models.WordQuiz{
+			Word:     "big",
+			Question: "big",
+			Options:  map[string]bool{"large": true, "house": false},
+		}, nil)
+		ms.EXPECT().NewDefinitionQuiz(gomock.Any(), int64(456)).Return(models.WordQuiz{
+			Word:     "run",
+			Question: "run",
+			Options:  map[string]bool{"to move fast": true, "to make music": false},
+		}, nil)
+	})
+
+	h.Handle(chat.Event{Type: chat.EventCommand, ChatID: 123, From: chat.User{ID: 456}, Command: "synonym"})
+	h.Handle(chat.Event{Type: chat.EventCallback, ChatID: 123, MessageID: 1, From: chat.User{ID: 456}, Data: "new_definition"})
+
+	require.Len(t, mb.SentMessages, 2)
+	assert.Equal(t, i18n.T(i18n.Default, "synonym.question", "big"), mb.SentMessages[0].(chat.Message).Text)
+	assert.Equal(t, i18n.T(i18n.Default, "definition.question", "run"), mb.SentMessages[1].(chat.Message).Text)
+}
@@ -2,253 +2,475 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
 	"github.com/DanRulev/vocabot.git/internal/models"
-	"github.com/DanRulev/vocabot.git/internal/storage/cache"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 type WordSI interface {
-	RandomWord(ctx context.Context) (string, models.WordCard, error)
+	RandomWord(ctx context.Context, lang string) (string, models.WordCard, error)
 	AddWord(ctx context.Context, word models.WordCard) error
-	Words(ctx context.Context, userID int64, page int, learned bool) (string, bool, error)
-	WordStat(ctx context.Context, userID int64) (string, error)
+	SavedWord(ctx context.Context, userID int64, word, lang string) (string, models.WordCard, error)
+	LookupWord(ctx context.Context, userID int64, text, lang string) ([]models.LookupResult, error)
+	SaveLookupWord(ctx context.Context, userID int64, word string) (models.WordCard, error)
+	TextWords(ctx context.Context, userID int64, text string) ([]models.TextWord, error)
+	AddTextWords(ctx context.Context, userID int64, words []models.TextWord) error
+	Words(ctx context.Context, userID int64, list models.WordList, lang string) (string, bool, error)
+	WordStat(ctx context.Context, userID int64, lang string) (string, error)
 }
 
+// Word list callbacks are "<deck>_<page>_<sort>_<part of speech>_<days>_<prefix>",
+// the deck being one of deckCallbacks. The short "<deck>_<page>" of older
+// messages still works. Telegram allows 64 bytes of callback data, so a
+// search prefix is cut to maxFindPrefix bytes.
+const maxFindPrefix = 32
+
+var deckCallbacks = map[string]string{
+	"f": models.DeckLearning,
+	"t": models.DeckLearned,
+	"a": models.DeckAll,
+}
+
+var (
+	errWordListFormat = errors.New("invalid word list callback")
+	errWordListPage   = errors.New("invalid word list page")
+)
+
 type WordT struct {
-	bot     BotSender
-	cache   *cache.Cache
-	service WordSI
+	bot      BotSender
+	sessions SessionStore
+	service  WordSI
+	game     GameSI
+	audio    AudioSI
+	users    UserSI
 }
 
-func NewWordTAPI(bot BotSender, cache *cache.Cache, service WordSI) *WordT {
+func NewWordTAPI(bot BotSender, sessions SessionStore, service WordSI, game GameSI, audio AudioSI, users UserSI) *WordT {
 	return &WordT{
-		bot:     bot,
-		cache:   cache,
-		service: service,
+		bot:      bot,
+		sessions: sessions,
+		service:  service,
+		game:     game,
+		audio:    audio,
+		users:    users,
 	}
 }
 
-func (t *WordT) sendNewWord(message *tgbotapi.Message, userID int64) {
+func (t *WordT) sendNewWord(chatID, userID int64, lang string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if message.From == nil {
-		log.Printf("Message without sender: %d", message.Chat.ID)
+	if userID == 0 {
+		log.Printf("Message without sender: %d", chatID)
 		return
 	}
 
-	word, card, err := t.service.RandomWord(ctx)
+	word, card, err := t.service.RandomWord(ctx, lang)
 	if err != nil {
-		log.Printf("Failed to get random word for chat %d: %v", message.Chat.ID, err)
-		msg := tgbotapi.NewMessage(message.Chat.ID, "Ошибка при получении слова. Попробуй позже.")
+		log.Printf("Failed to get random word for chat %d: %v", chatID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, errorKey(err, "word.error")))
 		sendMessage(t.bot, msg)
 		return
 	}
 
 	card.UserID = userID
-	t.cache.SetWord(userID, card)
 
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		[]tgbotapi.InlineKeyboardButton{
-			tgbotapi.NewInlineKeyboardButtonData("✅ Знаю", "know"),
-			tgbotapi.NewInlineKeyboardButtonData("❌ Не знаю", "repeat"),
-		},
-	)
+	sendWordCard(ctx, t.bot, t.sessions, chatID, word, card, lang)
+}
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, word)
-	msg.ParseMode = "markdown"
-	msg.ReplyMarkup = &keyboard
+// sendSavedWord shows the card of a word from the user's list, as it was when
+// the word was saved.
+func (t *WordT) sendSavedWord(chatID, userID int64, word, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	sendMessage(t.bot, msg)
+	if userID == 0 {
+		log.Printf("Message without sender: %d", chatID)
+		return
+	}
+
+	word = strings.TrimSpace(word)
+	if word == "" {
+		msg := chat.NewMessage(chatID, i18n.T(lang, "word.usage"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	text, card, err := t.service.SavedWord(ctx, userID, word, lang)
+	if errors.Is(err, models.ErrNoWord) {
+		msg := chat.NewMessage(chatID, i18n.T(lang, "word.not_saved", word))
+		sendMessage(t.bot, msg)
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get saved word for chat %d: %v", chatID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, "word.error"))
+		sendMessage(t.bot, msg)
+		return
+	}
+
+	sendWordCard(ctx, t.bot, t.sessions, chatID, text, card, lang)
+}
+
+// sendWordCard sends a dictionary card with the know/repeat and pronunciation
+// buttons and remembers the word for the answer callback. Extra rows go below
+// them.
+func sendWordCard(ctx context.Context, bot BotSender, sessions SessionStore, chatID int64, text string, card models.WordCard, lang string, extra ...[]chat.Button) error {
+	msg := chat.NewMessage(chatID, text)
+	msg.Markdown = true
+	msg.Buttons = append([][]chat.Button{
+		chat.Row(
+			chat.NewButton(i18n.T(lang, "inline.know"), "know"),
+			chat.NewButton(i18n.T(lang, "inline.repeat"), "repeat"),
+		),
+		chat.Row(chat.NewButton(i18n.T(lang, "inline.pronounce"), pronounceCallback)),
+	}, extra...)
+
+	sent, err := sendMessage(bot, msg)
+	if err != nil {
+		return err
+	}
+
+	key := models.SessionKey{ChatID: chatID, MessageID: sent.MessageID}
+	if err := sessions.SetWord(ctx, key, card); err != nil {
+		log.Printf("Failed to save word session for chat %d: %v", chatID, err)
+	}
+
+	return nil
 }
 
-func (t *WordT) showWords(message *tgbotapi.Message, userID int64, page int, learned bool) {
+func (t *WordT) showWords(chatID, userID int64, list models.WordList, lang string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	text, hasNext, err := t.service.Words(ctx, userID, page, learned) // true = learned
+	text, buttons, err := t.wordListPage(ctx, userID, list, lang)
+	if errors.Is(err, models.ErrNoWord) && list.Prefix != "" {
+		msg := chat.NewMessage(chatID, i18n.T(lang, "find.nothing", list.Prefix))
+		sendMessage(t.bot, msg)
+		return
+	}
 	if err != nil {
-		log.Printf("Failed to load words for chat %d: %v", message.Chat.ID, err)
-		msg := tgbotapi.NewMessage(message.Chat.ID, "❌ Ошибка загрузки слов")
+		log.Printf("Failed to load words for chat %d: %v", chatID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, "words.error"))
 		sendMessage(t.bot, msg)
 		return
 	}
 
-	knowPrefix := "f"
-	if learned {
-		knowPrefix = "t"
+	msg := chat.NewMessage(chatID, text)
+	msg.Markdown = true
+	msg.Buttons = buttons
+	sendMessage(t.bot, msg)
+}
+
+// findWords lists the user's words, learned or not, that start with prefix
+// in English or in translation.
+func (t *WordT) findWords(chatID, userID int64, prefix, lang string) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		msg := chat.NewMessage(chatID, i18n.T(lang, "find.usage"))
+		sendMessage(t.bot, msg)
+		return
 	}
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = "markdown"
-	keyboard := t.wordPaginationKeyboard(knowPrefix, page, hasNext)
-	if keyboard != nil {
-		msg.ReplyMarkup = keyboard
+	for len(prefix) > maxFindPrefix {
+		_, size := utf8.DecodeLastRuneInString(prefix)
+		prefix = prefix[:len(prefix)-size]
 	}
-	sendMessage(t.bot, msg)
+
+	t.showWords(chatID, userID, models.WordList{Deck: models.DeckAll, Prefix: prefix, Sort: models.WordSortAlpha}, lang)
 }
 
-func (t *WordT) sendWordStats(message *tgbotapi.Message) {
+// wordListPage renders a page of the list with the pagination and filter
+// buttons, the page size comes from the user's settings.
+func (t *WordT) wordListPage(ctx context.Context, userID int64, list models.WordList, lang string) (string, [][]chat.Button, error) {
+	settings, err := t.users.UserSettings(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get settings for user %d: %v", userID, err)
+	}
+	list.PageSize = settings.PageSize
+	if list.Sort == "" {
+		list.Sort = models.WordSortRecent
+	}
+
+	text, hasNext, err := t.service.Words(ctx, userID, list, lang)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return text, t.wordPaginationKeyboard(list, hasNext, lang), nil
+}
+
+func (t *WordT) sendWordStats(chatID, userID int64, lang string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	stats, err := t.service.WordStat(ctx, message.From.ID)
+	stats, err := t.service.WordStat(ctx, userID, lang)
 	if err != nil {
-		log.Printf("Failed to get stats for chat %d: %v", message.Chat.ID, err)
-		msg := tgbotapi.NewMessage(message.Chat.ID, "❌ Ошибка")
+		log.Printf("Failed to get stats for chat %d: %v", chatID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, "error.generic"))
 		sendMessage(t.bot, msg)
 		return
 	}
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, stats)
-	msg.ParseMode = "markdown"
+	msg := chat.NewMessage(chatID, stats)
+	msg.Markdown = true
 	sendMessage(t.bot, msg)
 }
 
-func (t *WordT) handleWordCallbackQuery(query *tgbotapi.CallbackQuery) {
-	data := query.Data
+func (t *WordT) handleWordCallbackQuery(event chat.Event, lang string) {
+	data := event.Data
 
 	switch data {
 	case "know", "repeat":
-		t.handleWordResponse(query)
+		t.handleWordResponse(event, lang)
+	case pronounceCallback:
+		t.sendPronunciation(event, lang)
 	case "new_word":
-		if query.Message == nil {
-			log.Printf("CallbackQuery without message: %v", query.ID)
+		if event.MessageID == 0 {
+			log.Printf("CallbackQuery without message: %v", event.CallbackID)
 			return
 		}
-		t.sendNewWord(query.Message, query.From.ID)
+		t.sendNewWord(event.ChatID, event.From.ID, lang)
 	default:
-		log.Printf("Unknown callback data: %s", query.Data)
-		msg := tgbotapi.NewMessage(query.Message.Chat.ID, "❌ НЕИЗВЕСТНАЯ КОМАНДА")
-		msg.ParseMode = "markdown"
+		log.Printf("Unknown callback data: %s", event.Data)
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "error.unknown_callback"))
+		msg.Markdown = true
 		sendMessage(t.bot, msg)
 	}
 }
 
-func (t *WordT) handleWordResponse(query *tgbotapi.CallbackQuery) {
-	userID := query.From.ID
-	data := query.Data
+func (t *WordT) handleWordResponse(event chat.Event, lang string) {
+	userID := event.From.ID
+	data := event.Data
 
-	word, exists := t.cache.GetWord(userID)
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message: %v", event.CallbackID)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := models.SessionKey{ChatID: event.ChatID, MessageID: event.MessageID}
+	word, exists, err := t.sessions.GetWord(ctx, key)
+	if err != nil {
+		log.Printf("Failed to get word session for user %d: %v", userID, err)
+	}
 	if !exists {
-		msg := tgbotapi.NewMessage(userID, "Не удалось определить слово.")
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "word.not_found"))
 		sendMessage(t.bot, msg)
 		return
 	}
+	// In a group anyone can press the buttons of someone else's card.
+	if word.UserID != 0 && word.UserID != userID {
+		log.Printf("User %d answered the word card of user %d, ignoring", userID, word.UserID)
+		return
+	}
 
-	t.cache.DeleteWord(userID)
+	// A double tap answers the card twice, only the first press takes it.
+	word, exists, err = t.sessions.TakeWord(ctx, key)
+	if err != nil {
+		log.Printf("Failed to take word session for user %d: %v", userID, err)
+	}
+	if !exists {
+		return
+	}
 
-	var statusText string
+	var (
+		statusText string
+		activity   models.Activity
+	)
 	switch data {
 	case "know":
 		word.Known = true
-		statusText = "✅ Отлично! Слово отмечено как выученное."
+		statusText = i18n.T(lang, "word.known")
+		activity = models.ActivityWordKnown
 	case "repeat":
 		word.Known = false
-		statusText = "❌ Запомнили. Повтори позже."
+		statusText = i18n.T(lang, "word.repeat")
+		activity = models.ActivityWordRepeat
 	default:
 		return
 	}
 
 	word.UserID = userID
+	word.Chosen = true
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	var announcement string
 	if err := t.service.AddWord(ctx, word); err != nil {
 		log.Printf("Failed to save word for user %d: %v", userID, err)
+	} else {
+		var progress string
+		progress, announcement = recordActivity(ctx, t.game, userID, activity, lang)
+		if progress != "" {
+			statusText += "\n" + progress
+		}
 	}
 
-	fullText := fmt.Sprintf("%s\n\n%s", query.Message.Text, statusText)
-	editMsg := tgbotapi.NewEditMessageText(
-		query.Message.Chat.ID,
-		query.Message.MessageID,
-		fullText,
-	)
-	editMsg.ParseMode = "markdown"
-
-	var buttons [][]tgbotapi.InlineKeyboardButton
-	buttons = append(buttons, []tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardButtonData("❓ НОВОЕ СЛОВО", "new_word")})
-
-	editMsg.ReplyMarkup = &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: buttons}
+	fullText := fmt.Sprintf("%s\n\n%s", event.Text, statusText)
+	editMsg := chat.NewEdit(event.ChatID, event.MessageID, fullText)
+	editMsg.Markdown = true
+	editMsg.Buttons = [][]chat.Button{
+		chat.Row(chat.NewButton(i18n.T(lang, "inline.new_word"), "new_word")),
+	}
 
-	sendMessage(t.bot, editMsg)
+	editMessage(t.bot, editMsg)
+	announce(t.bot, event.ChatID, announcement)
 }
 
-func (t *WordT) wordHandlePagination(query *tgbotapi.CallbackQuery) {
-	if query.Message == nil {
-		log.Printf("CallbackQuery without message from user %d", query.From.ID)
-		return
-	}
-	parts := strings.Split(query.Data, "_")
-	if len(parts) < 2 {
+func (t *WordT) wordHandlePagination(event chat.Event, lang string) {
+	if event.MessageID == 0 {
+		log.Printf("CallbackQuery without message from user %d", event.From.ID)
 		return
 	}
 
-	prefix := parts[0]
-	if prefix != "f" && prefix != "t" {
-		msg := tgbotapi.NewMessage(query.Message.Chat.ID, "❌ Ошибка: неверный формат страницы.")
-		sendMessage(t.bot, msg)
-		return
-	}
-	page, err := strconv.Atoi(parts[1])
-	if err != nil || page < 0 {
-		msg := tgbotapi.NewMessage(query.Message.Chat.ID, "❌ Ошибка: неверный номер страницы.")
+	list, err := parseWordListCallback(event.Data)
+	if err != nil {
+		key := "words.error_page_format"
+		if errors.Is(err, errWordListPage) {
+			key = "words.error_page_number"
+		}
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, key))
 		sendMessage(t.bot, msg)
 		return
 	}
 
-	learned := prefix == "t"
-
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	text, hasNext, err := t.service.Words(ctx, query.From.ID, page, learned)
-	if err != nil {
-		msg := tgbotapi.NewMessage(query.Message.Chat.ID, "❌ Ошибка загрузки слов")
+	text, buttons, err := t.wordListPage(ctx, event.From.ID, list, lang)
+	if errors.Is(err, models.ErrNoWord) {
+		// Keep the buttons, so a filter that matches nothing can be changed.
+		text, buttons = i18n.T(lang, "words.empty"), t.wordPaginationKeyboard(list, false, lang)
+	} else if err != nil {
+		msg := chat.NewMessage(event.ChatID, i18n.T(lang, "words.error"))
 		sendMessage(t.bot, msg)
 		return
 	}
-	editMsg := tgbotapi.NewEditMessageText(
-		query.Message.Chat.ID,
-		query.Message.MessageID,
-		text,
-	)
-	editMsg.ParseMode = "markdown"
-	keyboard := t.wordPaginationKeyboard(prefix, page, hasNext)
-	if keyboard != nil {
-		editMsg.ReplyMarkup = keyboard
-	}
 
-	sendMessage(t.bot, editMsg)
+	editMsg := chat.NewEdit(event.ChatID, event.MessageID, text)
+	editMsg.Markdown = true
+	editMsg.Buttons = buttons
+
+	editMessage(t.bot, editMsg)
 }
 
-func (t *WordT) wordPaginationKeyboard(prefix string, page int, hasNxt bool) *tgbotapi.InlineKeyboardMarkup {
-	var buttons [][]tgbotapi.InlineKeyboardButton
+// wordPaginationKeyboard has the page buttons and a button per filter that
+// switches it to its next value and goes back to the first page.
+func (t *WordT) wordPaginationKeyboard(list models.WordList, hasNxt bool, lang string) [][]chat.Button {
+	var buttons [][]chat.Button
 
-	row := make([]tgbotapi.InlineKeyboardButton, 0, 2)
+	row := make([]chat.Button, 0, 2)
 
-	if page > 0 {
-		row = append(row, tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", fmt.Sprintf("%s_%d", prefix, page-1)))
+	if list.Page > 0 {
+		prev := list
+		prev.Page--
+		row = append(row, chat.NewButton(i18n.T(lang, "inline.prev"), wordListCallback(prev)))
 	}
 
 	if hasNxt {
-		row = append(row, tgbotapi.NewInlineKeyboardButtonData("Далее ▶️", fmt.Sprintf("%s_%d", prefix, page+1)))
+		next := list
+		next.Page++
+		row = append(row, chat.NewButton(i18n.T(lang, "inline.next"), wordListCallback(next)))
 	}
 
 	if len(row) > 0 {
 		buttons = append(buttons, row)
 	}
 
-	buttons = append(buttons, []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData("❓ НОВОЕ СЛОВО", "new_word"),
-		tgbotapi.NewInlineKeyboardButtonData("🏠 Главное меню", "main_menu"),
-	})
+	first := list
+	first.Page = 0
+
+	sorted, deck, pos, added := first, first, first, first
+	sorted.Sort = nextOption(models.WordSorts, list.Sort)
+	deck.Deck = nextOption(models.Decks, list.Deck)
+	pos.PartOfSpeech = nextOption(append([]string{""}, models.PartsOfSpeech...), list.PartOfSpeech)
+	added.AddedDays = nextOption(append([]int{0}, models.AddedPeriods...), list.AddedDays)
+
+	posText := i18n.T(lang, "words.pos_any")
+	if list.PartOfSpeech != "" {
+		posText = i18n.T(lang, "words.pos."+list.PartOfSpeech)
+	}
+	addedText := i18n.T(lang, "words.added_any")
+	if list.AddedDays > 0 {
+		addedText = i18n.T(lang, "words.added_days", list.AddedDays)
+	}
+
+	buttons = append(buttons,
+		chat.Row(
+			chat.NewButton(i18n.T(lang, "words.sort."+list.Sort), wordListCallback(sorted)),
+			chat.NewButton(i18n.T(lang, "words.deck."+list.Deck), wordListCallback(deck)),
+		),
+		chat.Row(
+			chat.NewButton(posText, wordListCallback(pos)),
+			chat.NewButton(addedText, wordListCallback(added)),
+		),
+		chat.Row(
+			chat.NewButton(i18n.T(lang, "inline.new_word"), "new_word"),
+			chat.NewButton(i18n.T(lang, ButtonMainMenu), "main_menu"),
+		),
+	)
+
+	return buttons
+}
+
+func isWordListCallback(data string) bool {
+	deck, _, ok := strings.Cut(data, "_")
+	_, known := deckCallbacks[deck]
+	return ok && known
+}
+
+func wordListCallback(list models.WordList) string {
+	deck := "a"
+	for code, d := range deckCallbacks {
+		if d == list.Deck {
+			deck = code
+		}
+	}
+
+	return fmt.Sprintf("%s_%d_%s_%s_%d_%s", deck, list.Page, list.Sort, list.PartOfSpeech, list.AddedDays, list.Prefix)
+}
+
+func parseWordListCallback(data string) (models.WordList, error) {
+	parts := strings.SplitN(data, "_", 6)
+	deck, ok := deckCallbacks[parts[0]]
+	if !ok || (len(parts) != 2 && len(parts) != 6) {
+		return models.WordList{}, errWordListFormat
+	}
+
+	page, err := strconv.Atoi(parts[1])
+	if err != nil || page < 0 {
+		return models.WordList{}, errWordListPage
+	}
+
+	list := models.WordList{Deck: deck, Page: page, Sort: models.WordSortRecent}
+	if len(parts) == 2 {
+		return list, nil
+	}
+
+	days, err := strconv.Atoi(parts[4])
+	if err != nil || days < 0 {
+		return models.WordList{}, errWordListFormat
+	}
+	if slices.Contains(models.WordSorts, parts[2]) {
+		list.Sort = parts[2]
+	}
+	list.PartOfSpeech, list.AddedDays, list.Prefix = parts[3], days, parts[5]
+
+	return list, nil
+}
 
-	return &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: buttons}
+// nextOption is the option after current, or the first one.
+func nextOption[T comparable](options []T, current T) T {
+	i := slices.Index(options, current)
+	return options[(i+1)%len(options)]
 }
@@ -2,12 +2,15 @@ package bot
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
 	"github.com/DanRulev/vocabot.git/internal/models"
 	"github.com/DanRulev/vocabot.git/internal/storage/cache"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,22 +18,24 @@ import (
 
 func newWordTMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_bot.MockServiceI, *mock_bot.MockBot)) *WordT {
 	mockService := mock_bot.NewMockServiceI(ctrl)
-	cache := cache.NewCache()
+	sessions := cache.NewCache(time.Hour, 0)
 	mockBot := &mock_bot.MockBot{}
 
 	if setupMock != nil {
 		setupMock(mockService, mockBot)
 	}
+	mockService.EXPECT().RecordActivity(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+	mockService.EXPECT().UserSettings(gomock.Any(), gomock.Any()).Return(models.UserSettings{}, nil).AnyTimes()
 
-	return NewWordTAPI(mockBot, cache, mockService)
+	return NewWordTAPI(mockBot, sessions, mockService, mockService, mockService, mockService)
 }
 
 func TestWordT_sendNewWord(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		message *tgbotapi.Message
-		userID  int64
+		chatID int64
+		userID int64
 	}
 	tests := []struct {
 		name       string
@@ -42,14 +47,11 @@ func TestWordT_sendNewWord(t *testing.T) {
 		{
 			name: "success: sends word and keyboard",
 			args: args{
-				message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: 123},
-					From: &tgbotapi.User{ID: 456},
-				},
+				chatID: 123,
 				userID: 456,
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().RandomWord(gomock.Any()).Return(
+				ms.EXPECT().RandomWord(gomock.Any(), gomock.Any()).Return(
 					"**hello**\n*привет*",
 					models.WordCard{WordText: "hello", Translation: "привет"},
 					nil,
@@ -57,48 +59,40 @@ func TestWordT_sendNewWord(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg, ok := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg, ok := mb.SentMessages[0].(chat.Message)
 				require.True(t, ok)
 				assert.Equal(t, "**hello**\n*привет*", msg.Text)
-				assert.Equal(t, "markdown", msg.ParseMode)
-				assert.NotNil(t, msg.ReplyMarkup)
-				keyboard := msg.ReplyMarkup
-				kb, ok := keyboard.(*tgbotapi.InlineKeyboardMarkup)
-				require.True(t, ok)
-				assert.Equal(t, 1, len(kb.InlineKeyboard))
-				assert.Equal(t, "✅ Знаю", kb.InlineKeyboard[0][0].Text)
-				assert.Equal(t, "❌ Не знаю", kb.InlineKeyboard[0][1].Text)
+				assert.True(t, msg.Markdown)
+				require.NotNil(t, msg.Buttons)
+				assert.Equal(t, 2, len(msg.Buttons))
+				assert.Equal(t, "✅ Знаю", msg.Buttons[0][0].Text)
+				assert.Equal(t, "❌ Не знаю", msg.Buttons[0][1].Text)
+				assert.Equal(t, pronounceCallback, msg.Buttons[1][0].Data)
 			},
 			wantErr: false,
 		},
 		{
 			name: "error: RandomWord fails",
 			args: args{
-				message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: 123},
-					From: &tgbotapi.User{ID: 456},
-				},
+				chatID: 123,
 				userID: 456,
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().RandomWord(gomock.Any()).Return("", models.WordCard{}, assert.AnError)
+				ms.EXPECT().RandomWord(gomock.Any(), gomock.Any()).Return("", models.WordCard{}, assert.AnError)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg, ok := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg, ok := mb.SentMessages[0].(chat.Message)
 				require.True(t, ok)
 				assert.Equal(t, "Ошибка при получении слова. Попробуй позже.", msg.Text)
 			},
 			wantErr: false,
 		},
 		{
-			name: "nil From in message",
+			name: "no sender",
 			args: args{
-				message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: 123},
-					From: nil,
-				},
-				userID: 456,
+				chatID: 123,
+				userID: 0,
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				assert.Empty(t, mb.SentMessages)
@@ -117,7 +111,7 @@ func TestWordT_sendNewWord(t *testing.T) {
 			mb, _ := wordT.bot.(*mock_bot.MockBot)
 
 			mock_bot.ClearSentMessages(mb)
-			wordT.sendNewWord(tt.args.message, tt.args.userID)
+			wordT.sendNewWord(tt.args.chatID, tt.args.userID, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -126,11 +120,92 @@ func TestWordT_sendNewWord(t *testing.T) {
 	}
 }
 
+func TestWordT_sendSavedWord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		word       string
+		f          func(*mock_bot.MockServiceI, *mock_bot.MockBot)
+		assertFunc func(*testing.T, *WordT, *mock_bot.MockBot)
+	}{
+		{
+			name: "sends saved card",
+			word: " apple ",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().SavedWord(gomock.Any(), int64(456), "apple", gomock.Any()).Return(
+					"**apple**\n*яблоко*",
+					models.WordCard{UserID: 456, WordText: "apple", Translation: "яблоко", Audio: "https://example.com/apple.mp3"},
+					nil,
+				)
+			},
+			assertFunc: func(t *testing.T, wordT *WordT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg, ok := mb.SentMessages[0].(chat.Message)
+				require.True(t, ok)
+				assert.Equal(t, "**apple**\n*яблоко*", msg.Text)
+				require.Len(t, msg.Buttons, 2)
+				assert.Equal(t, pronounceCallback, msg.Buttons[1][0].Data)
+
+				card, ok, err := wordT.sessions.GetWord(context.Background(), models.SessionKey{ChatID: 123, MessageID: 1})
+				require.NoError(t, err)
+				require.True(t, ok)
+				assert.Equal(t, "https://example.com/apple.mp3", card.Audio)
+			},
+		},
+		{
+			name: "no word",
+			word: " ",
+			assertFunc: func(t *testing.T, wordT *WordT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "word.usage"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name: "not saved",
+			word: "dog",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().SavedWord(gomock.Any(), int64(456), "dog", gomock.Any()).Return("", models.WordCard{}, models.ErrNoWord)
+			},
+			assertFunc: func(t *testing.T, wordT *WordT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, "Слова «dog» пока нет в твоём списке.", mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name: "service error",
+			word: "dog",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().SavedWord(gomock.Any(), int64(456), "dog", gomock.Any()).Return("", models.WordCard{}, assert.AnError)
+			},
+			assertFunc: func(t *testing.T, wordT *WordT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "word.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wordT := newWordTMock(t, ctrl, tt.f)
+			mb, _ := wordT.bot.(*mock_bot.MockBot)
+
+			wordT.sendSavedWord(123, 456, tt.word, i18n.Default)
+
+			tt.assertFunc(t, wordT, mb)
+		})
+	}
+}
+
 func TestWordT_handleWordResponse(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		query *tgbotapi.CallbackQuery
+		event chat.Event
 	}
 
 	tests := []struct {
@@ -142,20 +217,20 @@ func TestWordT_handleWordResponse(t *testing.T) {
 		{
 			name: "know: marks word as known",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat:      &tgbotapi.Chat{ID: 123},
-						MessageID: 100,
-						Text:      "**hello**",
-					},
-					Data: "know",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Text:      "**hello**",
+					Data:      "know",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
 				ms.EXPECT().AddWord(gomock.Any(), gomock.Any()).DoAndReturn(
 					func(ctx context.Context, word models.WordCard) error {
 						assert.True(t, word.Known)
+						assert.True(t, word.Chosen)
 						assert.Equal(t, int64(456), word.UserID)
 						return nil
 					},
@@ -163,49 +238,64 @@ func TestWordT_handleWordResponse(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				editMsg, ok := mb.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+				editMsg, ok := mb.SentMessages[0].(chat.Edit)
 				require.True(t, ok)
 				assert.Contains(t, editMsg.Text, "✅ Отлично! Слово отмечено как выученное.")
-				assert.NotNil(t, editMsg.ReplyMarkup)
-				kb := editMsg.ReplyMarkup
-				assert.Equal(t, "❓ НОВОЕ СЛОВО", kb.InlineKeyboard[0][0].Text)
+				require.NotNil(t, editMsg.Buttons)
+				assert.Equal(t, "❓ НОВОЕ СЛОВО", editMsg.Buttons[0][0].Text)
 			},
 		},
 		{
 			name: "repeat: marks as not known",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat:      &tgbotapi.Chat{ID: 123},
-						MessageID: 100,
-						Text:      "**hello**",
-					},
-					Data: "repeat",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Text:      "**hello**",
+					Data:      "repeat",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
 				ms.EXPECT().AddWord(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				editMsg := mb.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+				editMsg := mb.SentMessages[0].(chat.Edit)
 				assert.Contains(t, editMsg.Text, "❌ Запомнили. Повтори позже.")
 			},
 		},
+		{
+			name: "answer from another group member is ignored",
+			args: args{
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 789},
+					ChatID:    123,
+					Group:     true,
+					MessageID: 100,
+					Data:      "know",
+				},
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Empty(t, mb.SentMessages)
+			},
+		},
 		{
 			name: "no word in cache",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat: &tgbotapi.Chat{ID: 123},
-					},
-					Data: "know",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 101,
+					Data:      "know",
 				},
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, int64(123), msg.ChatID, "replies go to the chat, not the user")
 				assert.Equal(t, "Не удалось определить слово.", msg.Text)
 			},
 		},
@@ -222,11 +312,13 @@ func TestWordT_handleWordResponse(t *testing.T) {
 			mb, _ := wordT.bot.(*mock_bot.MockBot)
 
 			if tt.name != "no word in cache" {
-				wordT.cache.SetWord(456, models.WordCard{WordText: "hello", Translation: "привет"})
+				key := models.SessionKey{ChatID: 123, MessageID: 100}
+				err := wordT.sessions.SetWord(context.Background(), key, models.WordCard{UserID: 456, WordText: "hello", Translation: "привет"})
+				require.NoError(t, err)
 			}
 
 			mock_bot.ClearSentMessages(mb)
-			wordT.handleWordResponse(tt.args.query)
+			wordT.handleWordResponse(tt.args.event, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -235,14 +327,64 @@ func TestWordT_handleWordResponse(t *testing.T) {
 	}
 }
 
+func TestWordT_handleWordResponse_keyedByMessage(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wordT := newWordTMock(t, ctrl, func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+		ms.EXPECT().RandomWord(gomock.Any(), gomock.Any()).Return("apple", models.WordCard{WordText: "apple", Translation: "яблоко"}, nil)
+		ms.EXPECT().RandomWord(gomock.Any(), gomock.Any()).Return("pear", models.WordCard{WordText: "pear", Translation: "груша"}, nil)
+		ms.EXPECT().AddWord(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, word models.WordCard) error {
+				assert.Equal(t, "apple", word.WordText)
+				assert.True(t, word.Known)
+				return nil
+			},
+		)
+	})
+	mb, _ := wordT.bot.(*mock_bot.MockBot)
+
+	wordT.sendNewWord(123, 456, i18n.Default)
+	wordT.sendNewWord(123, 456, i18n.Default)
+	require.Equal(t, 2, len(mb.SentMessages))
+
+	press := chat.Event{
+		Type:      chat.EventCallback,
+		From:      chat.User{ID: 456},
+		ChatID:    123,
+		MessageID: 1,
+		Text:      "apple",
+		Data:      "know",
+	}
+	wordT.handleWordResponse(press, i18n.Default)
+	require.Equal(t, 3, len(mb.SentMessages))
+
+	// A double tap must not save the word twice.
+	wordT.handleWordResponse(press, i18n.Default)
+	require.Equal(t, 4, len(mb.SentMessages))
+	msg, ok := mb.SentMessages[3].(chat.Message)
+	require.True(t, ok)
+	assert.Equal(t, "Не удалось определить слово.", msg.Text)
+
+	_, exists, err := wordT.sessions.GetWord(context.Background(), models.SessionKey{ChatID: 123, MessageID: 1})
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	pending, exists, err := wordT.sessions.GetWord(context.Background(), models.SessionKey{ChatID: 123, MessageID: 2})
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "pear", pending.WordText)
+}
+
 func TestWordT_showWords(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		message *tgbotapi.Message
-		userID  int64
-		page    int
-		learned bool
+		chatID int64
+		userID int64
+		list   models.WordList
 	}
 
 	tests := []struct {
@@ -254,42 +396,57 @@ func TestWordT_showWords(t *testing.T) {
 		{
 			name: "learned words: shows list and pagination",
 			args: args{
-				message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: 123},
-					From: &tgbotapi.User{ID: 456},
-				},
-				userID:  456,
-				page:    0,
-				learned: true,
+				chatID: 123,
+				userID: 456,
+				list:   models.WordList{Deck: models.DeckLearned},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().Words(gomock.Any(), int64(456), 0, true).Return("✅ Выученные: 5 слов", true, nil)
+				ms.EXPECT().Words(gomock.Any(), int64(456), models.WordList{Deck: models.DeckLearned, Sort: models.WordSortRecent}, gomock.Any()).
+					Return("✅ Выученные: 5 слов", true, nil)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "✅ Выученные: 5 слов", msg.Text)
-				assert.NotNil(t, msg.ReplyMarkup)
-				kb := msg.ReplyMarkup.(*tgbotapi.InlineKeyboardMarkup)
-				assert.Equal(t, "Далее ▶️", kb.InlineKeyboard[0][0].Text)
-				assert.Equal(t, "❓ НОВОЕ СЛОВО", kb.InlineKeyboard[1][0].Text)
+				require.Len(t, msg.Buttons, 4)
+				assert.Equal(t, "Далее ▶️", msg.Buttons[0][0].Text)
+				assert.Equal(t, "t_1_recent__0_", msg.Buttons[0][0].Data)
+				assert.Equal(t, i18n.T(i18n.Default, "words.sort.recent"), msg.Buttons[1][0].Text)
+				assert.Equal(t, "t_0_alpha__0_", msg.Buttons[1][0].Data)
+				assert.Equal(t, i18n.T(i18n.Default, "words.deck.learned"), msg.Buttons[1][1].Text)
+				assert.Equal(t, "a_0_recent__0_", msg.Buttons[1][1].Data)
+				assert.Equal(t, "t_0_recent_noun_0_", msg.Buttons[2][0].Data)
+				assert.Equal(t, "t_0_recent__7_", msg.Buttons[2][1].Data)
+				assert.Equal(t, "❓ НОВОЕ СЛОВО", msg.Buttons[3][0].Text)
+			},
+		},
+		{
+			name: "page size from settings",
+			args: args{
+				chatID: 123,
+				userID: 456,
+				list:   models.WordList{Deck: models.DeckLearning},
+			},
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().UserSettings(gomock.Any(), int64(456)).Return(models.UserSettings{PageSize: 5}, nil)
+				ms.EXPECT().Words(gomock.Any(), int64(456), models.WordList{Deck: models.DeckLearning, Sort: models.WordSortRecent, PageSize: 5}, gomock.Any()).
+					Return("words", false, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Equal(t, "words", mb.SentMessages[0].(chat.Message).Text)
 			},
 		},
 		{
 			name: "error: failed to load words",
 			args: args{
-				message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: 123},
-					From: &tgbotapi.User{ID: 456},
-				},
-				userID:  456,
-				page:    0,
-				learned: false,
+				chatID: 123,
+				userID: 456,
+				list:   models.WordList{Deck: models.DeckLearning},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().Words(gomock.Any(), int64(456), 0, false).Return("", false, assert.AnError)
+				ms.EXPECT().Words(gomock.Any(), int64(456), gomock.Any(), gomock.Any()).Return("", false, assert.AnError)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "❌ Ошибка загрузки слов", msg.Text)
 			},
 		},
@@ -306,7 +463,7 @@ func TestWordT_showWords(t *testing.T) {
 			mb, _ := wordT.bot.(*mock_bot.MockBot)
 
 			mock_bot.ClearSentMessages(mb)
-			wordT.showWords(tt.args.message, tt.args.userID, tt.args.page, tt.args.learned)
+			wordT.showWords(tt.args.chatID, tt.args.userID, tt.args.list, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -315,13 +472,128 @@ func TestWordT_showWords(t *testing.T) {
 	}
 }
 
-func TestWordT_sendWordStats(t *testing.T) {
+func TestWordT_findWords(t *testing.T) {
 	t.Parallel()
 
-	message := &tgbotapi.Message{
-		Chat: &tgbotapi.Chat{ID: 123},
-		From: &tgbotapi.User{ID: 456},
+	tests := []struct {
+		name       string
+		prefix     string
+		f          func(*mock_bot.MockServiceI, *mock_bot.MockBot)
+		assertFunc func(*testing.T, *mock_bot.MockBot)
+	}{
+		{
+			name:   "searches all words",
+			prefix: " app ",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().Words(gomock.Any(), int64(456), models.WordList{Deck: models.DeckAll, Prefix: "app", Sort: models.WordSortAlpha}, gomock.Any()).
+					Return("1. apple", false, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, "1. apple", msg.Text)
+				assert.Equal(t, "a_0_added__0_app", msg.Buttons[0][0].Data)
+			},
+		},
+		{
+			name:   "long prefix is cut",
+			prefix: strings.Repeat("я", 20),
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().Words(gomock.Any(), int64(456), models.WordList{Deck: models.DeckAll, Prefix: strings.Repeat("я", 16), Sort: models.WordSortAlpha}, gomock.Any()).
+					Return("words", false, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				msg := mb.SentMessages[0].(chat.Message)
+				for _, row := range msg.Buttons {
+					for _, button := range row {
+						assert.LessOrEqual(t, len(button.Data), 64)
+					}
+				}
+			},
+		},
+		{
+			name:   "nothing found",
+			prefix: "zz",
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().Words(gomock.Any(), int64(456), gomock.Any(), gomock.Any()).Return("", false, models.ErrNoWord)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Equal(t, "В твоём списке нет слов на «zz».", mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+		{
+			name:   "no prefix",
+			prefix: "",
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				assert.Equal(t, i18n.T(i18n.Default, "find.usage"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wordT := newWordTMock(t, ctrl, tt.f)
+			mb, _ := wordT.bot.(*mock_bot.MockBot)
+
+			wordT.findWords(123, 456, tt.prefix, i18n.Default)
+
+			require.Len(t, mb.SentMessages, 1)
+			tt.assertFunc(t, mb)
+		})
 	}
+}
+
+func Test_parseWordListCallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		data    string
+		want    models.WordList
+		wantErr error
+	}{
+		{data: "f_2", want: models.WordList{Deck: models.DeckLearning, Page: 2, Sort: models.WordSortRecent}},
+		{data: "t_0", want: models.WordList{Deck: models.DeckLearned, Sort: models.WordSortRecent}},
+		{
+			data: "a_1_errors_verb_30_my_word",
+			want: models.WordList{Deck: models.DeckAll, Page: 1, Sort: models.WordSortErrors, PartOfSpeech: "verb", AddedDays: 30, Prefix: "my_word"},
+		},
+		{data: "a_0_unknown__0_", want: models.WordList{Deck: models.DeckAll, Sort: models.WordSortRecent}},
+		{data: "x_1", wantErr: errWordListFormat},
+		{data: "f_1_recent", wantErr: errWordListFormat},
+		{data: "f_1_recent__x_", wantErr: errWordListFormat},
+		{data: "f_abc", wantErr: errWordListPage},
+		{data: "f_-1", wantErr: errWordListPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseWordListCallback(tt.data)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, mustParse(t, wordListCallback(got)), "callback data must round-trip")
+		})
+	}
+}
+
+func mustParse(t *testing.T, data string) models.WordList {
+	list, err := parseWordListCallback(data)
+	require.NoError(t, err)
+	return list
+}
+
+func TestWordT_sendWordStats(t *testing.T) {
+	t.Parallel()
 
 	tests := []struct {
 		name       string
@@ -331,21 +603,21 @@ func TestWordT_sendWordStats(t *testing.T) {
 		{
 			name: "success: sends stats",
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().WordStat(gomock.Any(), int64(456)).Return("📊 Слов выучено: 10", nil)
+				ms.EXPECT().WordStat(gomock.Any(), int64(456), gomock.Any()).Return("📊 Слов выучено: 10", nil)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "📊 Слов выучено: 10", msg.Text)
-				assert.Equal(t, "markdown", msg.ParseMode)
+				assert.True(t, msg.Markdown)
 			},
 		},
 		{
 			name: "error: failed to get stats",
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().WordStat(gomock.Any(), int64(456)).Return("", assert.AnError)
+				ms.EXPECT().WordStat(gomock.Any(), int64(456), gomock.Any()).Return("", assert.AnError)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "❌ Ошибка", msg.Text)
 			},
 		},
@@ -362,7 +634,7 @@ func TestWordT_sendWordStats(t *testing.T) {
 			mb, _ := wordT.bot.(*mock_bot.MockBot)
 
 			mock_bot.ClearSentMessages(mb)
-			wordT.sendWordStats(message)
+			wordT.sendWordStats(123, 456, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -375,7 +647,7 @@ func TestWordT_handleWordCallbackQuery(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		query *tgbotapi.CallbackQuery
+		event chat.Event
 	}
 
 	tests := []struct {
@@ -387,14 +659,13 @@ func TestWordT_handleWordCallbackQuery(t *testing.T) {
 		{
 			name: "know: calls handleWordResponse",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat:      &tgbotapi.Chat{ID: 123},
-						MessageID: 100,
-						Text:      "hello",
-					},
-					Data: "know",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Text:      "hello",
+					Data:      "know",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
@@ -402,7 +673,7 @@ func TestWordT_handleWordCallbackQuery(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				editMsg, ok := mb.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+				editMsg, ok := mb.SentMessages[0].(chat.Edit)
 				require.True(t, ok)
 				assert.Contains(t, editMsg.Text, "✅ Отлично! Слово отмечено как выученное.")
 			},
@@ -410,38 +681,36 @@ func TestWordT_handleWordCallbackQuery(t *testing.T) {
 		{
 			name: "repeat: calls handleWordResponse",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat:      &tgbotapi.Chat{ID: 123},
-						MessageID: 100,
-						Text:      "hello",
-					},
-					Data: "repeat",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Text:      "hello",
+					Data:      "repeat",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
 				ms.EXPECT().AddWord(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				editMsg := mb.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+				editMsg := mb.SentMessages[0].(chat.Edit)
 				assert.Contains(t, editMsg.Text, "❌ Запомнили. Повтори позже.")
 			},
 		},
 		{
 			name: "new_word: calls sendNewWord",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat: &tgbotapi.Chat{ID: 123},
-						From: &tgbotapi.User{ID: 456},
-					},
-					Data: "new_word",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Data:      "new_word",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().RandomWord(gomock.Any()).Return(
+				ms.EXPECT().RandomWord(gomock.Any(), gomock.Any()).Return(
 					"**hello**\n*привет*",
 					models.WordCard{WordText: "hello", Translation: "привет"},
 					nil,
@@ -449,7 +718,7 @@ func TestWordT_handleWordCallbackQuery(t *testing.T) {
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg, ok := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg, ok := mb.SentMessages[0].(chat.Message)
 				require.True(t, ok)
 				assert.Equal(t, "**hello**\n*привет*", msg.Text)
 			},
@@ -457,9 +726,12 @@ func TestWordT_handleWordCallbackQuery(t *testing.T) {
 		{
 			name: "new_word: query.Message is nil",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Data: "new_word",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 0,
+					Data:      "new_word",
 				},
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
@@ -469,19 +741,18 @@ func TestWordT_handleWordCallbackQuery(t *testing.T) {
 		{
 			name: "unknown command",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat: &tgbotapi.Chat{ID: 123},
-					},
-					Data: "unknown_data",
+				event: chat.Event{
+					Type:   chat.EventCallback,
+					From:   chat.User{ID: 456},
+					ChatID: 123,
+					Data:   "unknown_data",
 				},
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "❌ НЕИЗВЕСТНАЯ КОМАНДА", msg.Text)
-				assert.Equal(t, "markdown", msg.ParseMode)
+				assert.True(t, msg.Markdown)
 			},
 		},
 	}
@@ -496,11 +767,13 @@ func TestWordT_handleWordCallbackQuery(t *testing.T) {
 			mb, _ := wordT.bot.(*mock_bot.MockBot)
 
 			if tt.name == "know: calls handleWordResponse" || tt.name == "repeat: calls handleWordResponse" {
-				wordT.cache.SetWord(456, models.WordCard{WordText: "hello", Translation: "привет"})
+				key := models.SessionKey{ChatID: 123, MessageID: 100}
+				err := wordT.sessions.SetWord(context.Background(), key, models.WordCard{UserID: 456, WordText: "hello", Translation: "привет"})
+				require.NoError(t, err)
 			}
 
 			mock_bot.ClearSentMessages(mb)
-			wordT.handleWordCallbackQuery(tt.args.query)
+			wordT.handleWordCallbackQuery(tt.args.event, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
@@ -513,7 +786,7 @@ func TestWordT_wordHandlePagination(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		query *tgbotapi.CallbackQuery
+		event chat.Event
 	}
 
 	tests := []struct {
@@ -525,40 +798,106 @@ func TestWordT_wordHandlePagination(t *testing.T) {
 		{
 			name: "pagination: next page (f_1)",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat:      &tgbotapi.Chat{ID: 123},
-						MessageID: 100,
-					},
-					Data: "f_1",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Data:      "f_1",
 				},
 			},
 			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
-				ms.EXPECT().Words(gomock.Any(), int64(456), 1, false).Return("Слово 1\nСлово 2", false, nil)
+				ms.EXPECT().Words(gomock.Any(), int64(456), models.WordList{Deck: models.DeckLearning, Page: 1, Sort: models.WordSortRecent}, gomock.Any()).
+					Return("Слово 1\nСлово 2", false, nil)
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
 				require.Equal(t, 1, len(mb.SentMessages))
-				editMsg := mb.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+				editMsg := mb.SentMessages[0].(chat.Edit)
 				assert.Equal(t, "Слово 1\nСлово 2", editMsg.Text)
-				assert.NotNil(t, editMsg.ReplyMarkup)
-				kb := editMsg.ReplyMarkup
-				assert.Equal(t, "❓ НОВОЕ СЛОВО", kb.InlineKeyboard[1][0].Text)
+				assert.Equal(t, 100, editMsg.MessageID)
+				require.NotNil(t, editMsg.Buttons)
+				assert.Equal(t, "◀️ Назад", editMsg.Buttons[0][0].Text)
+				assert.Equal(t, "f_0_recent__0_", editMsg.Buttons[0][0].Data)
+				assert.Equal(t, "❓ НОВОЕ СЛОВО", editMsg.Buttons[3][0].Text)
+			},
+		},
+		{
+			name: "filters are kept",
+			args: args{
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Data:      "a_1_errors_adverb_30_ap",
+				},
+			},
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				list := models.WordList{Deck: models.DeckAll, Page: 1, Sort: models.WordSortErrors, PartOfSpeech: "adverb", AddedDays: 30, Prefix: "ap"}
+				ms.EXPECT().Words(gomock.Any(), int64(456), list, gomock.Any()).Return("words", true, nil)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				editMsg := mb.SentMessages[0].(chat.Edit)
+				assert.Equal(t, "a_0_errors_adverb_30_ap", editMsg.Buttons[0][0].Data)
+				assert.Equal(t, "a_2_errors_adverb_30_ap", editMsg.Buttons[0][1].Data)
+				assert.Equal(t, "a_0_recent_adverb_30_ap", editMsg.Buttons[1][0].Data)
+				assert.Equal(t, "f_0_errors_adverb_30_ap", editMsg.Buttons[1][1].Data)
+				assert.Equal(t, i18n.T(i18n.Default, "words.pos.adverb"), editMsg.Buttons[2][0].Text)
+				assert.Equal(t, "a_0_errors__30_ap", editMsg.Buttons[2][0].Data)
+				assert.Equal(t, i18n.T(i18n.Default, "words.added_days", 30), editMsg.Buttons[2][1].Text)
+				assert.Equal(t, "a_0_errors_adverb_0_ap", editMsg.Buttons[2][1].Data)
+			},
+		},
+		{
+			name: "no words match the filters",
+			args: args{
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Data:      "t_0_recent_verb_7_",
+				},
+			},
+			f: func(ms *mock_bot.MockServiceI, mb *mock_bot.MockBot) {
+				ms.EXPECT().Words(gomock.Any(), int64(456), gomock.Any(), gomock.Any()).Return("", false, models.ErrNoWord)
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				editMsg := mb.SentMessages[0].(chat.Edit)
+				assert.Equal(t, i18n.T(i18n.Default, "words.empty"), editMsg.Text)
+				require.Len(t, editMsg.Buttons, 3)
+				assert.Equal(t, "t_0_recent_adjective_7_", editMsg.Buttons[1][0].Data)
+			},
+		},
+		{
+			name: "invalid page number",
+			args: args{
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Data:      "t_abc",
+				},
+			},
+			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, "❌ Ошибка: неверный номер страницы.", msg.Text)
 			},
 		},
 		{
 			name: "invalid page format",
 			args: args{
-				query: &tgbotapi.CallbackQuery{
-					From: &tgbotapi.User{ID: 456},
-					Message: &tgbotapi.Message{
-						Chat: &tgbotapi.Chat{ID: 123},
-					},
-					Data: "x_abc",
+				event: chat.Event{
+					Type:      chat.EventCallback,
+					From:      chat.User{ID: 456},
+					ChatID:    123,
+					MessageID: 100,
+					Data:      "x_abc",
 				},
 			},
 			assertFunc: func(t *testing.T, mb *mock_bot.MockBot) {
-				msg := mb.SentMessages[0].(tgbotapi.MessageConfig)
+				msg := mb.SentMessages[0].(chat.Message)
 				assert.Equal(t, "❌ Ошибка: неверный формат страницы.", msg.Text)
 			},
 		},
@@ -575,7 +914,7 @@ func TestWordT_wordHandlePagination(t *testing.T) {
 			mb, _ := wordT.bot.(*mock_bot.MockBot)
 
 			mock_bot.ClearSentMessages(mb)
-			wordT.wordHandlePagination(tt.args.query)
+			wordT.wordHandlePagination(tt.args.event, i18n.Default)
 
 			if tt.assertFunc != nil {
 				tt.assertFunc(t, mb)
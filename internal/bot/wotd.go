@@ -0,0 +1,138 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+const (
+	wotdPrefix      = "wotd_"
+	wotdSubscribe   = wotdPrefix + "sub"
+	wotdUnsubscribe = wotdPrefix + "unsub"
+
+	// Telegram allows about 30 messages per second to different chats.
+	wotdSendInterval = 50 * time.Millisecond
+)
+
+type WotdSI interface {
+	WordOfDay(ctx context.Context, lang string) (string, models.WordCard, error)
+	SubscribeWotd(ctx context.Context, userID, chatID int64) error
+	UnsubscribeWotd(ctx context.Context, userID int64) error
+	WotdSubscribed(ctx context.Context, userID int64) (bool, error)
+	WotdSubscribers(ctx context.Context) ([]models.WotdSubscriber, error)
+	MarkWotdSent(ctx context.Context, userID int64) error
+}
+
+type WotdT struct {
+	bot      BotSender
+	sessions SessionStore
+	service  WotdSI
+}
+
+func NewWotdTAPI(bot BotSender, sessions SessionStore, service WotdSI) *WotdT {
+	return &WotdT{
+		bot:      bot,
+		sessions: sessions,
+		service:  service,
+	}
+}
+
+func (t *WotdT) sendWordOfDay(chatID, userID int64, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	subscribed, err := t.service.WotdSubscribed(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to check wotd subscription for user %d: %v", userID, err)
+	}
+
+	if err := t.send(ctx, chatID, userID, subscribed, lang); err != nil {
+		log.Printf("Failed to send word of the day to chat %d: %v", chatID, err)
+		msg := chat.NewMessage(chatID, i18n.T(lang, "wotd.error"))
+		sendMessage(t.bot, msg)
+	}
+}
+
+func (t *WotdT) send(ctx context.Context, chatID, userID int64, subscribed bool, lang string) error {
+	text, card, err := t.service.WordOfDay(ctx, lang)
+	if err != nil {
+		return err
+	}
+
+	card.UserID = userID
+
+	toggle := chat.NewButton(i18n.T(lang, "wotd.subscribe"), wotdSubscribe)
+	if subscribed {
+		toggle = chat.NewButton(i18n.T(lang, "wotd.unsubscribe"), wotdUnsubscribe)
+	}
+
+	return sendWordCard(ctx, t.bot, t.sessions, chatID, text, card, lang, chat.Row(toggle))
+}
+
+func (t *WotdT) handleSubscriptionCallback(event chat.Event, lang string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userID := event.From.ID
+
+	var (
+		err  error
+		text string
+	)
+	switch event.Data {
+	case wotdSubscribe:
+		err = t.service.SubscribeWotd(ctx, userID, event.ChatID)
+		text = i18n.T(lang, "wotd.subscribed")
+	case wotdUnsubscribe:
+		err = t.service.UnsubscribeWotd(ctx, userID)
+		text = i18n.T(lang, "wotd.unsubscribed")
+	default:
+		log.Printf("Unknown wotd callback: %s", event.Data)
+		return
+	}
+
+	if err != nil {
+		log.Printf("Failed to change wotd subscription for user %d: %v", userID, err)
+		text = i18n.T(lang, "wotd.subscription_error")
+	}
+
+	msg := chat.NewMessage(event.ChatID, text)
+	sendMessage(t.bot, msg)
+}
+
+// broadcast sends today's word to every subscriber who hasn't got it yet.
+// A subscriber is marked only after a successful send, so an interrupted run
+// can simply be repeated.
+func (t *WotdT) broadcast(ctx context.Context) {
+	subscribers, err := t.service.WotdSubscribers(ctx)
+	if err != nil {
+		log.Printf("Failed to get wotd subscribers: %v", err)
+		return
+	}
+
+	for i, sub := range subscribers {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wotdSendInterval):
+			}
+		}
+
+		if err := t.send(ctx, sub.ChatID, sub.UserID, true, i18n.Normalize(sub.Language)); err != nil {
+			log.Printf("Failed to send word of the day to chat %d: %v", sub.ChatID, err)
+			continue
+		}
+
+		if err := t.service.MarkWotdSent(ctx, sub.UserID); err != nil {
+			log.Printf("Failed to mark wotd sent for user %d: %v", sub.UserID, err)
+		}
+	}
+
+	log.Printf("Word of the day sent to %d subscribers", len(subscribers))
+}
@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/storage/cache"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWotdTMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_bot.MockServiceI)) (*WotdT, *mock_bot.MockBot) {
+	mockService := mock_bot.NewMockServiceI(ctrl)
+	mockBot := &mock_bot.MockBot{}
+
+	if setupMock != nil {
+		setupMock(mockService)
+	}
+
+	return NewWotdTAPI(mockBot, cache.NewCache(time.Hour, 0), mockService), mockBot
+}
+
+func TestWotdT_sendWordOfDay(t *testing.T) {
+	t.Parallel()
+
+	card := models.WordCard{WordText: "apple", Translation: "яблоко"}
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock_bot.MockServiceI)
+		check     func(*testing.T, *WotdT, *mock_bot.MockBot)
+	}{
+		{
+			name: "not subscribed",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().WotdSubscribed(gomock.Any(), int64(456)).Return(false, nil)
+				ms.EXPECT().WordOfDay(gomock.Any(), i18n.Default).Return("card", card, nil)
+			},
+			check: func(t *testing.T, wotdT *WotdT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				msg := mb.SentMessages[0].(chat.Message)
+				assert.Equal(t, "card", msg.Text)
+				require.Len(t, msg.Buttons, 3)
+				assert.Equal(t, "know", msg.Buttons[0][0].Data)
+				assert.Equal(t, pronounceCallback, msg.Buttons[1][0].Data)
+				assert.Equal(t, wotdSubscribe, msg.Buttons[2][0].Data)
+
+				word, ok, err := wotdT.sessions.GetWord(context.Background(), models.SessionKey{ChatID: 123, MessageID: 1})
+				require.NoError(t, err)
+				require.True(t, ok)
+				assert.Equal(t, models.WordCard{UserID: 456, WordText: "apple", Translation: "яблоко"}, word)
+			},
+		},
+		{
+			name: "subscribed",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().WotdSubscribed(gomock.Any(), int64(456)).Return(true, nil)
+				ms.EXPECT().WordOfDay(gomock.Any(), i18n.Default).Return("card", card, nil)
+			},
+			check: func(t *testing.T, wotdT *WotdT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, wotdUnsubscribe, mb.SentMessages[0].(chat.Message).Buttons[2][0].Data)
+			},
+		},
+		{
+			name: "service error",
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().WotdSubscribed(gomock.Any(), int64(456)).Return(false, nil)
+				ms.EXPECT().WordOfDay(gomock.Any(), i18n.Default).Return("", models.WordCard{}, assert.AnError)
+			},
+			check: func(t *testing.T, wotdT *WotdT, mb *mock_bot.MockBot) {
+				require.Len(t, mb.SentMessages, 1)
+				assert.Equal(t, i18n.T(i18n.Default, "wotd.error"), mb.SentMessages[0].(chat.Message).Text)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wotdT, mb := newWotdTMock(t, ctrl, tt.setupMock)
+
+			wotdT.sendWordOfDay(123, 456, i18n.Default)
+
+			tt.check(t, wotdT, mb)
+		})
+	}
+}
+
+func TestWotdT_handleSubscriptionCallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		data      string
+		setupMock func(*mock_bot.MockServiceI)
+		want      string
+	}{
+		{
+			name: "subscribe",
+			data: wotdSubscribe,
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().SubscribeWotd(gomock.Any(), int64(456), int64(123)).Return(nil)
+			},
+			want: i18n.T(i18n.Default, "wotd.subscribed"),
+		},
+		{
+			name: "unsubscribe",
+			data: wotdUnsubscribe,
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().UnsubscribeWotd(gomock.Any(), int64(456)).Return(nil)
+			},
+			want: i18n.T(i18n.Default, "wotd.unsubscribed"),
+		},
+		{
+			name: "service error",
+			data: wotdSubscribe,
+			setupMock: func(ms *mock_bot.MockServiceI) {
+				ms.EXPECT().SubscribeWotd(gomock.Any(), int64(456), int64(123)).Return(assert.AnError)
+			},
+			want: i18n.T(i18n.Default, "wotd.subscription_error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wotdT, mb := newWotdTMock(t, ctrl, tt.setupMock)
+
+			wotdT.handleSubscriptionCallback(chat.Event{ChatID: 123, MessageID: 5, From: chat.User{ID: 456}, Data: tt.data}, i18n.Default)
+
+			require.Len(t, mb.SentMessages, 1)
+			assert.Equal(t, tt.want, mb.SentMessages[0].(chat.Message).Text)
+		})
+	}
+}
+
+func TestWotdT_broadcast(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wotdT, mb := newWotdTMock(t, ctrl, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().WotdSubscribers(gomock.Any()).Return([]models.WotdSubscriber{
+			{UserID: 1, ChatID: 10, Language: "en"},
+			{UserID: 2, ChatID: 20},
+			{UserID: 3, ChatID: 30, Language: "uk"},
+		}, nil)
+		ms.EXPECT().WordOfDay(gomock.Any(), "en").Return("card en", models.WordCard{WordText: "apple"}, nil)
+		ms.EXPECT().WordOfDay(gomock.Any(), i18n.Default).Return("", models.WordCard{}, assert.AnError)
+		ms.EXPECT().WordOfDay(gomock.Any(), "uk").Return("card uk", models.WordCard{WordText: "apple"}, nil)
+		ms.EXPECT().MarkWotdSent(gomock.Any(), int64(1)).Return(nil)
+		ms.EXPECT().MarkWotdSent(gomock.Any(), int64(3)).Return(nil)
+	})
+
+	wotdT.broadcast(context.Background())
+
+	require.Len(t, mb.SentMessages, 2, "failed sends are not reported to subscribers")
+	first := mb.SentMessages[0].(chat.Message)
+	assert.Equal(t, int64(10), first.ChatID)
+	assert.Equal(t, "card en", first.Text)
+	assert.Equal(t, wotdUnsubscribe, first.Buttons[2][0].Data)
+	assert.Equal(t, int64(30), mb.SentMessages[1].(chat.Message).ChatID)
+}
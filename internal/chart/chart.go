@@ -0,0 +1,255 @@
+// Package chart renders small PNG charts for the progress screen using only
+// the standard image packages and the Go fonts.
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	Width  = 800
+	Height = 400
+
+	marginLeft   = 60
+	marginRight  = 20
+	marginTop    = 50
+	marginBottom = 50
+
+	maxXLabels = 10
+)
+
+var (
+	background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	foreground = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	grid       = color.RGBA{0xe5, 0xe5, 0xe5, 0xff}
+	accent     = color.RGBA{0x2e, 0x86, 0xde, 0xff}
+)
+
+type Point struct {
+	Label string
+	Value float64
+}
+
+type fonts struct {
+	title font.Face
+	label font.Face
+}
+
+var (
+	loadFonts = sync.OnceValues(func() (fonts, error) {
+		f, err := opentype.Parse(goregular.TTF)
+		if err != nil {
+			return fonts{}, fmt.Errorf("failed to parse font: %w", err)
+		}
+
+		title, err := opentype.NewFace(f, &opentype.FaceOptions{Size: 18, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return fonts{}, fmt.Errorf("failed to create title face: %w", err)
+		}
+
+		label, err := opentype.NewFace(f, &opentype.FaceOptions{Size: 12, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return fonts{}, fmt.Errorf("failed to create label face: %w", err)
+		}
+
+		return fonts{title: title, label: label}, nil
+	})
+
+	// font.Face implementations keep glyph caches and aren't safe for
+	// concurrent use.
+	drawMu sync.Mutex
+)
+
+func PNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+type canvas struct {
+	img   *image.RGBA
+	fonts fonts
+}
+
+func newCanvas(title string) (*canvas, error) {
+	f, err := loadFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &canvas{
+		img:   image.NewRGBA(image.Rect(0, 0, Width, Height)),
+		fonts: f,
+	}
+	draw.Draw(c.img, c.img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	c.text(c.fonts.title, title, Width/2, 30, alignCenter)
+
+	return c, nil
+}
+
+func (c *canvas) rect(r image.Rectangle, col color.Color) {
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+func (c *canvas) hline(x0, x1, y int, col color.Color) {
+	c.rect(image.Rect(x0, y, x1, y+1), col)
+}
+
+// line draws a segment with the given thickness by stamping squares along it.
+func (c *canvas) line(x0, y0, x1, y1, thickness int, col color.Color) {
+	steps := int(math.Max(math.Abs(float64(x1-x0)), math.Abs(float64(y1-y0))))
+	if steps == 0 {
+		steps = 1
+	}
+	half := thickness / 2
+	for i := 0; i <= steps; i++ {
+		x := x0 + (x1-x0)*i/steps
+		y := y0 + (y1-y0)*i/steps
+		c.rect(image.Rect(x-half, y-half, x-half+thickness, y-half+thickness), col)
+	}
+}
+
+type align int
+
+const (
+	alignLeft align = iota
+	alignCenter
+	alignRight
+)
+
+func (c *canvas) text(face font.Face, s string, x, y int, a align) {
+	d := &font.Drawer{Dst: c.img, Src: image.NewUniform(foreground), Face: face}
+
+	width := d.MeasureString(s).Round()
+	switch a {
+	case alignCenter:
+		x -= width / 2
+	case alignRight:
+		x -= width
+	}
+
+	d.Dot = fixed.P(x, y)
+	d.DrawString(s)
+}
+
+// plotArea draws horizontal grid lines with value labels and returns the
+// drawable rectangle.
+func (c *canvas) plotArea(maxValue float64, format string) image.Rectangle {
+	area := image.Rect(marginLeft, marginTop, Width-marginRight, Height-marginBottom)
+
+	const ticks = 4
+	for i := 0; i <= ticks; i++ {
+		y := area.Max.Y - area.Dy()*i/ticks
+		c.hline(area.Min.X, area.Max.X, y, grid)
+		c.text(c.fonts.label, fmt.Sprintf(format, maxValue*float64(i)/ticks), area.Min.X-8, y+4, alignRight)
+	}
+
+	return area
+}
+
+func (c *canvas) xLabels(area image.Rectangle, points []Point, x func(i int) int) {
+	step := (len(points) + maxXLabels - 1) / maxXLabels
+	if step < 1 {
+		step = 1
+	}
+	for i := 0; i < len(points); i += step {
+		c.text(c.fonts.label, points[i].Label, x(i), area.Max.Y+20, alignCenter)
+	}
+}
+
+func (c *canvas) empty(area image.Rectangle, text string) {
+	c.text(c.fonts.label, text, area.Min.X+area.Dx()/2, area.Min.Y+area.Dy()/2, alignCenter)
+}
+
+// Line draws values as a line chart with a fixed y range of [0, maxValue].
+// emptyText is shown when there are no points.
+func Line(title, emptyText string, points []Point, maxValue float64, format string) (image.Image, error) {
+	drawMu.Lock()
+	defer drawMu.Unlock()
+
+	c, err := newCanvas(title)
+	if err != nil {
+		return nil, err
+	}
+
+	area := c.plotArea(maxValue, format)
+	if len(points) == 0 || maxValue <= 0 {
+		c.empty(area, emptyText)
+		return c.img, nil
+	}
+
+	x := func(i int) int {
+		if len(points) == 1 {
+			return area.Min.X + area.Dx()/2
+		}
+		return area.Min.X + area.Dx()*i/(len(points)-1)
+	}
+	y := func(v float64) int {
+		v = math.Max(0, math.Min(v, maxValue))
+		return area.Max.Y - int(float64(area.Dy())*v/maxValue)
+	}
+
+	for i := 1; i < len(points); i++ {
+		c.line(x(i-1), y(points[i-1].Value), x(i), y(points[i].Value), 3, accent)
+	}
+	for i, p := range points {
+		c.rect(image.Rect(x(i)-4, y(p.Value)-4, x(i)+4, y(p.Value)+4), accent)
+	}
+
+	c.xLabels(area, points, x)
+
+	return c.img, nil
+}
+
+// Bars draws values as a bar chart scaled to the largest value.
+func Bars(title, emptyText string, points []Point) (image.Image, error) {
+	drawMu.Lock()
+	defer drawMu.Unlock()
+
+	c, err := newCanvas(title)
+	if err != nil {
+		return nil, err
+	}
+
+	maxValue := 0.0
+	for _, p := range points {
+		maxValue = math.Max(maxValue, p.Value)
+	}
+	// Round the axis up so tick labels stay whole numbers.
+	maxValue = math.Ceil(maxValue/4) * 4
+
+	area := c.plotArea(maxValue, "%.0f")
+	if len(points) == 0 || maxValue <= 0 {
+		c.empty(area, emptyText)
+		return c.img, nil
+	}
+
+	slot := area.Dx() / len(points)
+	barWidth := slot * 2 / 3
+	x := func(i int) int {
+		return area.Min.X + slot*i + slot/2
+	}
+
+	for i, p := range points {
+		height := int(float64(area.Dy()) * p.Value / maxValue)
+		c.rect(image.Rect(x(i)-barWidth/2, area.Max.Y-height, x(i)+barWidth/2, area.Max.Y), accent)
+	}
+
+	c.xLabels(area, points, x)
+
+	return c.img, nil
+}
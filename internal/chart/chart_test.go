@@ -0,0 +1,129 @@
+package chart
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite golden images in testdata")
+
+func assertGolden(t *testing.T, name string, img image.Image) {
+	t.Helper()
+
+	got, err := PNG(img)
+	require.NoError(t, err)
+
+	path := filepath.Join("testdata", name+".golden.png")
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test ./internal/chart -update to create golden files")
+
+	wantImg, err := png.Decode(bytes.NewReader(want))
+	require.NoError(t, err)
+	gotImg, err := png.Decode(bytes.NewReader(got))
+	require.NoError(t, err)
+
+	require.Equal(t, wantImg.Bounds(), gotImg.Bounds())
+	for y := wantImg.Bounds().Min.Y; y < wantImg.Bounds().Max.Y; y++ {
+		for x := wantImg.Bounds().Min.X; x < wantImg.Bounds().Max.X; x++ {
+			if wantImg.At(x, y) != gotImg.At(x, y) {
+				t.Fatalf("%s differs from golden image at (%d, %d)", name, x, y)
+			}
+		}
+	}
+}
+
+func TestLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		points []Point
+	}{
+		{
+			name: "line_accuracy",
+			points: []Point{
+				{Label: "03.03", Value: 50},
+				{Label: "04.03", Value: 75},
+				{Label: "05.03", Value: 60},
+				{Label: "06.03", Value: 90},
+				{Label: "07.03", Value: 100},
+			},
+		},
+		{
+			name:   "line_single",
+			points: []Point{{Label: "07.03", Value: 40}},
+		},
+		{
+			name: "line_empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			img, err := Line("Точность викторин, %", "нет данных", tt.points, 100, "%.0f%%")
+			require.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, Width, Height), img.Bounds())
+
+			assertGolden(t, tt.name, img)
+		})
+	}
+}
+
+func TestBars(t *testing.T) {
+	t.Parallel()
+
+	points := make([]Point, 0, 12)
+	for i := 0; i < 12; i++ {
+		points = append(points, Point{
+			Label: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*i).Format("02.01"),
+			Value: float64((i * 7) % 11),
+		})
+	}
+
+	img, err := Bars("Words learned per week", "no data", points)
+	require.NoError(t, err)
+
+	assertGolden(t, "bars_weekly", img)
+}
+
+func TestHeatmap(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2025, 3, 12, 18, 0, 0, 0, time.UTC)
+	counts := map[time.Time]int{}
+	for i := 0; i < 120; i += 3 {
+		counts[end.AddDate(0, 0, -i)] = i % 9
+	}
+	counts[end] = 20
+
+	img, err := Heatmap("Activity", end, 16, counts, [7]string{"Mon", "", "Wed", "", "Fri", "", "Sun"})
+	require.NoError(t, err)
+
+	assertGolden(t, "heatmap", img)
+}
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, level(0, 10))
+	assert.Equal(t, 1, level(1, 10))
+	assert.Equal(t, len(heatLevels)-1, level(10, 10))
+	assert.Equal(t, len(heatLevels)-1, level(50, 10))
+	assert.Equal(t, 0, level(3, 0))
+}
@@ -0,0 +1,83 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"time"
+)
+
+var heatLevels = []color.RGBA{
+	{0xeb, 0xed, 0xf0, 0xff},
+	{0x9b, 0xe9, 0xa8, 0xff},
+	{0x40, 0xc4, 0x63, 0xff},
+	{0x30, 0xa1, 0x4e, 0xff},
+	{0x21, 0x6e, 0x39, 0xff},
+}
+
+// Heatmap draws daily activity counts as a weeks × weekdays grid ending with
+// the week that contains end. weekdays holds the row labels, Monday first.
+func Heatmap(title string, end time.Time, weeks int, counts map[time.Time]int, weekdays [7]string) (image.Image, error) {
+	drawMu.Lock()
+	defer drawMu.Unlock()
+
+	c, err := newCanvas(title)
+	if err != nil {
+		return nil, err
+	}
+
+	end = day(end)
+	// Monday of the first column.
+	offset := (int(end.Weekday()) + 6) % 7
+	start := end.AddDate(0, 0, -offset-7*(weeks-1))
+
+	daily := make(map[time.Time]int, len(counts))
+	maxCount := 0
+	for d, n := range counts {
+		d = day(d)
+		daily[d] += n
+		if !d.Before(start) && !d.After(end) && daily[d] > maxCount {
+			maxCount = daily[d]
+		}
+	}
+
+	area := image.Rect(marginLeft, marginTop+10, Width-marginRight, Height-marginBottom)
+	cell := min(area.Dx()/weeks, area.Dy()/7)
+	gap := max(cell/8, 1)
+
+	for row, label := range weekdays {
+		c.text(c.fonts.label, label, area.Min.X-8, area.Min.Y+row*cell+cell/2+4, alignRight)
+	}
+
+	for w := 0; w < weeks; w++ {
+		x := area.Min.X + w*cell
+		monday := start.AddDate(0, 0, 7*w)
+		if w%4 == 0 {
+			c.text(c.fonts.label, monday.Format("02.01"), x, area.Min.Y+7*cell+18, alignLeft)
+		}
+
+		for row := 0; row < 7; row++ {
+			d := monday.AddDate(0, 0, row)
+			if d.After(end) {
+				break
+			}
+			y := area.Min.Y + row*cell
+			c.rect(image.Rect(x, y, x+cell-gap, y+cell-gap), heatLevels[level(daily[d], maxCount)])
+		}
+	}
+
+	return c.img, nil
+}
+
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// level maps a count to one of the heat colors relative to the busiest day.
+func level(n, maxCount int) int {
+	if n <= 0 || maxCount <= 0 {
+		return 0
+	}
+	l := 1 + (n-1)*(len(heatLevels)-1)/maxCount
+	return min(l, len(heatLevels)-1)
+}
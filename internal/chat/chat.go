@@ -0,0 +1,167 @@
+// Package chat holds the platform-neutral types the bot flows are written
+// against. Frontends (Telegram, HTTP, the simulator) translate their native
+// updates into Events and render Messages and Edits back.
+package chat
+
+import "errors"
+
+// ErrUnsupported is returned by frontends for messages they can't render,
+// callers fall back to something simpler.
+var ErrUnsupported = errors.New("not supported by this frontend")
+
+// ErrFileTooLarge is returned for files users send that are too large to
+// download.
+var ErrFileTooLarge = errors.New("file is too large")
+
+type EventType string
+
+const (
+	EventMessage    EventType = "message"
+	EventCommand    EventType = "command"
+	EventCallback   EventType = "callback"
+	EventPollAnswer EventType = "poll_answer"
+	EventInline     EventType = "inline_query"
+)
+
+type User struct {
+	ID           int64  `json:"id"`
+	UserName     string `json:"username,omitempty"`
+	FirstName    string `json:"first_name,omitempty"`
+	LanguageCode string `json:"language_code,omitempty"`
+}
+
+type Event struct {
+	Type   EventType `json:"type"`
+	ChatID int64     `json:"chat_id"`
+	From   User      `json:"from"`
+
+	// Group is set for chats with several members, where ChatID is not the
+	// sender's id.
+	Group bool `json:"group,omitempty"`
+
+	// MessageID is the incoming message, or for callbacks the bot message
+	// whose button was pressed.
+	MessageID int `json:"message_id,omitempty"`
+
+	// Text is what the user typed, or for callbacks the current text of the
+	// bot message whose button was pressed.
+	Text string `json:"text,omitempty"`
+
+	// Document is a file sent with the message, Text is its caption then.
+	// Only its ID and Name are set, the contents are fetched with the
+	// frontend's DownloadFile.
+	Document *File `json:"document,omitempty"`
+
+	Command string `json:"command,omitempty"`
+	Args    string `json:"args,omitempty"`
+
+	CallbackID string `json:"callback_id,omitempty"`
+	Data       string `json:"data,omitempty"`
+
+	// PollID and Options identify an answer to a poll sent by the bot. The
+	// platform doesn't tell which chat the poll is in, ChatID is empty.
+	PollID  string `json:"poll_id,omitempty"`
+	Options []int  `json:"options,omitempty"`
+
+	// QueryID identifies an inline query, typed in any chat after the bot's
+	// name. Text is the query then, and ChatID is the sender's id.
+	QueryID string `json:"query_id,omitempty"`
+}
+
+type Button struct {
+	Text string `json:"text"`
+	Data string `json:"data"`
+}
+
+type Message struct {
+	ChatID   int64  `json:"chat_id"`
+	Text     string `json:"text"`
+	Markdown bool   `json:"markdown,omitempty"`
+
+	// Buttons are attached to the message itself and produce callbacks.
+	Buttons [][]Button `json:"buttons,omitempty"`
+
+	// Menu replaces the persistent keyboard; pressing a key sends its text.
+	Menu [][]string `json:"menu,omitempty"`
+
+	// Photo turns the message into an image, Text becomes its caption.
+	Photo *File `json:"photo,omitempty"`
+
+	// Poll turns the message into a native quiz poll, Text is not used.
+	Poll *Poll `json:"poll,omitempty"`
+
+	// Audio turns the message into a sound file, Text becomes its caption.
+	Audio *File `json:"audio,omitempty"`
+}
+
+// Poll is a quiz with a single correct option. Answers come back as
+// EventPollAnswer.
+type Poll struct {
+	Question      string   `json:"question"`
+	Options       []string `json:"options"`
+	CorrectOption int      `json:"correct_option"`
+	Explanation   string   `json:"explanation,omitempty"`
+}
+
+type File struct {
+	// ID refers to a file the platform already has, Data is not sent then.
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	Data []byte `json:"data"`
+}
+
+// InlineResult is one answer to an inline query. When it is picked, a
+// message with Text and Buttons is sent on behalf of the user; callbacks of
+// its buttons come without a MessageID.
+type InlineResult struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Text        string     `json:"text"`
+	Markdown    bool       `json:"markdown,omitempty"`
+	Buttons     [][]Button `json:"buttons,omitempty"`
+}
+
+type Edit struct {
+	ChatID    int64      `json:"chat_id"`
+	MessageID int        `json:"message_id"`
+	Text      string     `json:"text"`
+	Markdown  bool       `json:"markdown,omitempty"`
+	Buttons   [][]Button `json:"buttons,omitempty"`
+}
+
+type Sent struct {
+	MessageID int    `json:"message_id"`
+	PollID    string `json:"poll_id,omitempty"`
+	// FileID is the platform's id of a file sent with the message, it can be
+	// sent again without uploading it.
+	FileID string `json:"file_id,omitempty"`
+}
+
+func NewMessage(chatID int64, text string) Message {
+	return Message{ChatID: chatID, Text: text}
+}
+
+func NewPhoto(chatID int64, name string, data []byte) Message {
+	return Message{ChatID: chatID, Photo: &File{Name: name, Data: data}}
+}
+
+func NewAudio(chatID int64, file File) Message {
+	return Message{ChatID: chatID, Audio: &file}
+}
+
+func NewPoll(chatID int64, poll Poll) Message {
+	return Message{ChatID: chatID, Poll: &poll}
+}
+
+func NewEdit(chatID int64, messageID int, text string) Edit {
+	return Edit{ChatID: chatID, MessageID: messageID, Text: text}
+}
+
+func Row(buttons ...Button) []Button {
+	return buttons
+}
+
+func NewButton(text, data string) Button {
+	return Button{Text: text, Data: data}
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"path"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+// maxAudioSize caps a downloaded pronunciation, real ones are a few KB.
+const maxAudioSize = 5 << 20
+
+// URLAudio downloads the pronunciation the dictionary links to.
+type URLAudio struct{}
+
+func NewURLAudio() *URLAudio {
+	return &URLAudio{}
+}
+
+func (a *URLAudio) Audio(ctx context.Context, word, url string) (models.Audio, error) {
+	if url == "" {
+		return models.Audio{}, models.ErrNoAudio
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return models.Audio{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return models.Audio{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.Audio{}, fmt.Errorf("failed to download audio for %q: %s", word, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAudioSize))
+	if err != nil {
+		return models.Audio{}, err
+	}
+
+	ext := path.Ext(req.URL.Path)
+	if ext == "" {
+		ext = ".mp3"
+	}
+
+	return models.Audio{Name: word + ext, Data: data}, nil
+}
+
+// CommandAudio is a local text-to-speech stand-in: it runs a command with the
+// word as the last argument, after "--" so a word can't pass for an option,
+// and reads WAV audio from its stdout, e.g. espeak-ng --stdout.
+type CommandAudio struct {
+	command []string
+}
+
+func NewCommandAudio(command []string) *CommandAudio {
+	return &CommandAudio{command: command}
+}
+
+func (a *CommandAudio) Audio(ctx context.Context, word, _ string) (models.Audio, error) {
+	if len(a.command) == 0 {
+		return models.Audio{}, errors.New("no text-to-speech command configured")
+	}
+
+	args := append(append([]string{}, a.command[1:]...), "--", word)
+	data, err := exec.CommandContext(ctx, a.command[0], args...).Output()
+	if err != nil {
+		return models.Audio{}, fmt.Errorf("failed to synthesize %q: %w", word, err)
+	}
+	if len(data) == 0 {
+		return models.Audio{}, models.ErrNoAudio
+	}
+
+	return models.Audio{Name: word + ".wav", Data: data}, nil
+}
@@ -1,15 +1,28 @@
 package client
 
+import (
+	"github.com/DanRulev/vocabot.git/internal/config"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+)
+
 type Clients struct {
 	*MyMemoryAPI
 	*PythonAnyWhereAPI
 	*VercelAPI
 }
 
-func InitClients() Clients {
+// InitClients builds the API clients, each limited to its share of requests
+// across all users.
+func InitClients(cfg config.ProvidersLimit) Clients {
 	return Clients{
-		MyMemoryAPI:       NewMyMemoryAPI(),
-		PythonAnyWhereAPI: NewPythonAnyWhereAPI(),
-		VercelAPI:         NewVercelAPI(),
+		MyMemoryAPI:       NewMyMemoryAPI(providerBucket(cfg.MyMemory)),
+		PythonAnyWhereAPI: NewPythonAnyWhereAPI(providerBucket(cfg.PythonAnyWhere)),
+		VercelAPI:         NewVercelAPI(providerBucket(cfg.Vercel)),
 	}
 }
+
+func providerBucket(cfg config.LimitConfig) *ratelimit.Bucket {
+	bucket := ratelimit.NewBucket(cfg.Rate, cfg.Burst)
+	bucket.SetDaily(cfg.Daily)
+	return bucket
+}
@@ -3,17 +3,26 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 
 	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
 )
 
-type MyMemoryAPI struct{}
+// myMemoryWorkers caps the requests a batch translation sends at once.
+const myMemoryWorkers = 4
 
-func NewMyMemoryAPI() *MyMemoryAPI {
-	return &MyMemoryAPI{}
+type MyMemoryAPI struct {
+	limit *ratelimit.Bucket
+}
+
+func NewMyMemoryAPI(limit *ratelimit.Bucket) *MyMemoryAPI {
+	return &MyMemoryAPI{limit: limit}
 }
 
 func (m *MyMemoryAPI) TranslateEnToRu(ctx context.Context, text string) (models.MyMemoryTranslationResult, error) {
@@ -22,6 +31,10 @@ func (m *MyMemoryAPI) TranslateEnToRu(ctx context.Context, text string) (models.
 		url.QueryEscape(text),
 	)
 
+	if !m.limit.Allow() {
+		return models.MyMemoryTranslationResult{}, ratelimit.ErrLimited
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return models.MyMemoryTranslationResult{}, err
@@ -59,3 +72,41 @@ func (m *MyMemoryAPI) TranslateEnToRu(ctx context.Context, text string) (models.
 		Alternatives: alternatives,
 	}, nil
 }
+
+// TranslateEnToRuBatch translates texts concurrently. The results are in the
+// order of texts, the ones that failed have Error set. If any text couldn't
+// be sent because of the rate limit, ratelimit.ErrLimited is returned with
+// the results.
+func (m *MyMemoryAPI) TranslateEnToRuBatch(ctx context.Context, texts []string) ([]models.MyMemoryTranslationResult, error) {
+	var (
+		results = make([]models.MyMemoryTranslationResult, len(texts))
+		sem     = make(chan struct{}, myMemoryWorkers)
+		wg      sync.WaitGroup
+		limited atomic.Bool
+	)
+
+	for i, text := range texts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result, err := m.TranslateEnToRu(ctx, text)
+			if errors.Is(err, ratelimit.ErrLimited) {
+				limited.Store(true)
+			}
+			if err != nil {
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	if limited.Load() {
+		return results, ratelimit.ErrLimited
+	}
+	return results, ctx.Err()
+}
@@ -7,15 +7,22 @@ import (
 	"net/http"
 
 	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
 )
 
-type PythonAnyWhereAPI struct{}
+type PythonAnyWhereAPI struct {
+	limit *ratelimit.Bucket
+}
 
-func NewPythonAnyWhereAPI() *PythonAnyWhereAPI {
-	return &PythonAnyWhereAPI{}
+func NewPythonAnyWhereAPI(limit *ratelimit.Bucket) *PythonAnyWhereAPI {
+	return &PythonAnyWhereAPI{limit: limit}
 }
 
 func (m *PythonAnyWhereAPI) DictionaryData(ctx context.Context, word string) (models.TranslationResponse, error) {
+	if !m.limit.Allow() {
+		return models.TranslationResponse{}, ratelimit.ErrLimited
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "GET", "https://ftapi.pythonanywhere.com/translate?sl=en&dl=ru&text="+word, nil)
 	if err != nil {
 		return models.TranslationResponse{}, err
@@ -5,16 +5,28 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
 )
 
-type VercelAPI struct{}
+type VercelAPI struct {
+	limit *ratelimit.Bucket
+}
 
-func NewVercelAPI() *VercelAPI {
-	return &VercelAPI{}
+func NewVercelAPI(limit *ratelimit.Bucket) *VercelAPI {
+	return &VercelAPI{limit: limit}
 }
 
 func (v *VercelAPI) RandomWord(ctx context.Context) (string, error) {
-	resp, err := http.Get("https://random-word-api.vercel.app/api?words=1")
+	if !v.limit.Allow() {
+		return "", ratelimit.ErrLimited
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://random-word-api.vercel.app/api?words=1", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -10,19 +10,74 @@ import (
 )
 
 type Config struct {
-	App      AppConfig `mapstructure:"app" validate:"required"`
-	BotToken string    `mapstructure:"bot_token" validate:"required"`
-	DB       DBConfig  `mapstructure:"db" validate:"required"`
-	Env      string    `mapstructure:"env" validate:"oneof=development production staging"`
+	App      AppConfig     `mapstructure:"app" validate:"required"`
+	BotToken string        `mapstructure:"bot_token"`
+	Frontend string        `mapstructure:"frontend" validate:"oneof=telegram http"`
+	HTTP     HTTPConfig    `mapstructure:"http"`
+	DB       DBConfig      `mapstructure:"db" validate:"required"`
+	Session  SessionConfig `mapstructure:"session" validate:"required"`
+	Wotd     WotdConfig    `mapstructure:"wotd"`
+	Audio    AudioConfig   `mapstructure:"audio"`
+	Limits   LimitsConfig  `mapstructure:"rate_limit"`
+	Admins   []int64       `mapstructure:"admins"`
+	Env      string        `mapstructure:"env" validate:"oneof=development production staging"`
 }
 
 type AppConfig struct {
 	Timeout time.Duration `mapstructure:"timeout" validate:"min=1"`
 }
 
+type HTTPConfig struct {
+	Addr  string `mapstructure:"addr"`
+	Token string `mapstructure:"token"`
+}
+
+type SessionConfig struct {
+	Store           string        `mapstructure:"store" validate:"oneof=memory postgres"`
+	TTL             time.Duration `mapstructure:"ttl" validate:"min=1"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval" validate:"min=0"`
+}
+
+// WotdConfig controls the daily word of the day push. Time is "HH:MM" in the
+// server's local time zone (TZ).
+type WotdConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Time    string `mapstructure:"time" validate:"required_if=Enabled true,omitempty,datetime=15:04"`
+}
+
+// AudioConfig picks where word pronunciations come from: "url" downloads the
+// dictionary's audio, "command" runs a local text-to-speech command.
+type AudioConfig struct {
+	Source  string   `mapstructure:"source" validate:"oneof=url command"`
+	Command []string `mapstructure:"command" validate:"required_if=Source command"`
+}
+
+// LimitsConfig holds the token buckets that keep users from flooding the bot
+// and the bot from running out of the external APIs' quotas.
+type LimitsConfig struct {
+	User      LimitConfig    `mapstructure:"user"`
+	Providers ProvidersLimit `mapstructure:"providers"`
+}
+
+type ProvidersLimit struct {
+	MyMemory       LimitConfig `mapstructure:"mymemory"`
+	PythonAnyWhere LimitConfig `mapstructure:"pythonanywhere"`
+	Vercel         LimitConfig `mapstructure:"vercel"`
+}
+
+// LimitConfig is a token bucket: Rate tokens per second, up to Burst at once.
+// A zero rate turns the limit off. Daily caps the requests per day of a
+// provider, zero for none.
+type LimitConfig struct {
+	Rate  float64 `mapstructure:"rate" validate:"min=0"`
+	Burst int     `mapstructure:"burst" validate:"min=0"`
+	Daily int     `mapstructure:"daily" validate:"min=0"`
+}
+
 type DBConfig struct {
-	Conn DBConn `mapstructure:"conn"`
-	Cfg  DBCfg  `mapstructure:"cfg"`
+	Conn        DBConn `mapstructure:"conn"`
+	Cfg         DBCfg  `mapstructure:"cfg"`
+	AutoMigrate bool   `mapstructure:"auto_migrate"`
 }
 
 type DBConn struct {
@@ -57,6 +112,12 @@ func Init() (*Config, error) {
 	if err := v.BindEnv("bot_token", "BOT_TOKEN"); err != nil {
 		return nil, fmt.Errorf("failed to bind BOT_TOKEN: %w", err)
 	}
+	if err := v.BindEnv("http.token", "HTTP_TOKEN"); err != nil {
+		return nil, fmt.Errorf("failed to bind HTTP_TOKEN: %w", err)
+	}
+	if err := v.BindEnv("admins", "ADMIN_IDS"); err != nil {
+		return nil, fmt.Errorf("failed to bind ADMIN_IDS: %w", err)
+	}
 	if err := v.BindEnv("db.conn.host", "DB_HOST"); err != nil {
 		return nil, fmt.Errorf("failed to bind DB_HOST: %w", err)
 	}
@@ -0,0 +1,308 @@
+// Package httpchat is a JSON-over-HTTP chat frontend. Clients post user
+// events and receive the bot's replies in the response; replies produced
+// outside a request (e.g. by background jobs) are queued per chat and can be
+// fetched with GET /v1/chats/{chat_id}/updates.
+package httpchat
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+)
+
+const (
+	maxTrackedMessages = 1000
+	// maxQueuedUpdates caps the replies kept for a chat nobody fetches, the
+	// oldest are dropped.
+	maxQueuedUpdates = 100
+	// maxChats caps the conversations kept, the least recently used one is
+	// dropped for a new chat.
+	maxChats = 10000
+)
+
+type Dispatcher interface {
+	Handle(event chat.Event)
+}
+
+type Outgoing struct {
+	Type       string        `json:"type"`
+	Message    *chat.Message `json:"message,omitempty"`
+	Edit       *chat.Edit    `json:"edit,omitempty"`
+	MessageID  int           `json:"message_id,omitempty"`
+	CallbackID string        `json:"callback_id,omitempty"`
+	Text       string        `json:"text,omitempty"`
+}
+
+type EventRequest struct {
+	ChatID    int64     `json:"chat_id"`
+	From      chat.User `json:"from"`
+	Group     bool      `json:"group,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	MessageID int       `json:"message_id,omitempty"`
+	Data      string    `json:"data,omitempty"`
+}
+
+type conversation struct {
+	nextID int
+	texts  map[int]string
+	outbox []Outgoing
+	used   int
+}
+
+type Server struct {
+	mu         sync.Mutex
+	token      string
+	dispatcher Dispatcher
+	chats      map[int64]*conversation
+	callbacks  map[string]int64
+	nextCbID   int
+	uses       int
+	mux        *http.ServeMux
+}
+
+func NewServer(token string) *Server {
+	s := &Server{
+		token:     token,
+		chats:     make(map[int64]*conversation),
+		callbacks: make(map[string]int64),
+		mux:       http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("POST /v1/events", s.handleEvent)
+	s.mux.HandleFunc("GET /v1/chats/{chat_id}/updates", s.handleUpdates)
+
+	return s
+}
+
+// SetDispatcher must be called before serving; the dispatcher usually needs
+// the server itself as its sender, so it can't be a constructor argument.
+func (s *Server) SetDispatcher(d Dispatcher) {
+	s.dispatcher = d
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) conversation(chatID int64) *conversation {
+	c, ok := s.chats[chatID]
+	if !ok {
+		if len(s.chats) >= maxChats {
+			s.forgetLeastUsed()
+		}
+		c = &conversation{texts: make(map[int]string)}
+		s.chats[chatID] = c
+	}
+	s.uses++
+	c.used = s.uses
+	return c
+}
+
+func (s *Server) forgetLeastUsed() {
+	var (
+		oldest int64
+		used   = s.uses + 1
+	)
+	for id, c := range s.chats {
+		if c.used < used {
+			oldest, used = id, c.used
+		}
+	}
+	delete(s.chats, oldest)
+	for callbackID, chatID := range s.callbacks {
+		if chatID == oldest {
+			delete(s.callbacks, callbackID)
+		}
+	}
+}
+
+func (c *conversation) remember(id int, text string) {
+	c.texts[id] = text
+	delete(c.texts, id-maxTrackedMessages)
+}
+
+func (c *conversation) queue(update Outgoing) {
+	if len(c.outbox) >= maxQueuedUpdates {
+		c.outbox = slices.Delete(c.outbox, 0, len(c.outbox)-maxQueuedUpdates+1)
+	}
+	c.outbox = append(c.outbox, update)
+}
+
+func (s *Server) Send(msg chat.Message) (chat.Sent, error) {
+	if msg.Poll != nil {
+		return chat.Sent{}, chat.ErrUnsupported
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.conversation(msg.ChatID)
+	c.nextID++
+	c.remember(c.nextID, msg.Text)
+	c.queue(Outgoing{Type: "message", MessageID: c.nextID, Message: &msg})
+
+	return chat.Sent{MessageID: c.nextID}, nil
+}
+
+func (s *Server) Edit(edit chat.Edit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.chats[edit.ChatID]; !ok {
+		return errors.New("message not found")
+	}
+	c := s.conversation(edit.ChatID)
+	if _, ok := c.texts[edit.MessageID]; !ok {
+		return errors.New("message not found")
+	}
+	c.texts[edit.MessageID] = edit.Text
+	c.queue(Outgoing{Type: "edit", MessageID: edit.MessageID, Edit: &edit})
+
+	return nil
+}
+
+func (s *Server) AnswerCallback(callbackID, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chatID, ok := s.callbacks[callbackID]
+	if !ok {
+		return errors.New("unknown callback")
+	}
+	delete(s.callbacks, callbackID)
+
+	if text != "" {
+		c := s.conversation(chatID)
+		c.queue(Outgoing{Type: "callback_answer", CallbackID: callbackID, Text: text})
+	}
+
+	return nil
+}
+
+// AnswerInline is not supported, there are no inline queries here.
+func (s *Server) AnswerInline(queryID string, results []chat.InlineResult) error {
+	return chat.ErrUnsupported
+}
+
+// DownloadFile is not supported, users can't send files here.
+func (s *Server) DownloadFile(fileID string) ([]byte, error) {
+	return nil, chat.ErrUnsupported
+}
+
+func (s *Server) drain(chatID int64) []Outgoing {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Polling a chat the bot never wrote to doesn't start a conversation.
+	c, ok := s.chats[chatID]
+	if !ok || c.outbox == nil {
+		return []Outgoing{}
+	}
+	out := c.outbox
+	c.outbox = nil
+	return out
+}
+
+func (s *Server) handleEvent(w http.ResponseWriter, r *http.Request) {
+	var req EventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("invalid json"))
+		return
+	}
+	if req.ChatID == 0 || req.From.ID == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("chat_id and from.id are required"))
+		return
+	}
+
+	event, err := s.event(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.dispatcher.Handle(event)
+
+	writeJSON(w, http.StatusOK, map[string]any{"updates": s.drain(req.ChatID)})
+}
+
+func (s *Server) event(req EventRequest) (chat.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.conversation(req.ChatID)
+
+	if req.Data != "" {
+		text, ok := c.texts[req.MessageID]
+		if !ok {
+			return chat.Event{}, errors.New("message_id does not refer to a bot message")
+		}
+
+		s.nextCbID++
+		callbackID := strconv.Itoa(s.nextCbID)
+		s.callbacks[callbackID] = req.ChatID
+
+		return chat.Event{
+			Type:       chat.EventCallback,
+			ChatID:     req.ChatID,
+			From:       req.From,
+			Group:      req.Group,
+			MessageID:  req.MessageID,
+			Text:       text,
+			CallbackID: callbackID,
+			Data:       req.Data,
+		}, nil
+	}
+
+	if strings.TrimSpace(req.Text) == "" {
+		return chat.Event{}, errors.New("either text or data is required")
+	}
+
+	c.nextID++
+	event := chat.Event{
+		Type:      chat.EventMessage,
+		ChatID:    req.ChatID,
+		From:      req.From,
+		Group:     req.Group,
+		MessageID: c.nextID,
+		Text:      req.Text,
+	}
+	if strings.HasPrefix(req.Text, "/") {
+		command, args, _ := strings.Cut(strings.TrimPrefix(req.Text, "/"), " ")
+		event.Type = chat.EventCommand
+		event.Command = command
+		event.Args = strings.TrimSpace(args)
+	}
+
+	return event, nil
+}
+
+func (s *Server) handleUpdates(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.PathValue("chat_id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("invalid chat_id"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"updates": s.drain(chatID)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
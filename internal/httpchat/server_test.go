@@ -0,0 +1,220 @@
+package httpchat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/bot"
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/chat"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/storage/cache"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type updatesResponse struct {
+	Updates []Outgoing `json:"updates"`
+	Error   string     `json:"error"`
+}
+
+func newTestServer(t *testing.T, token string, setupMock func(*mock_bot.MockServiceI)) *httptest.Server {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	service := mock_bot.NewMockServiceI(ctrl)
+	service.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	service.EXPECT().IsBanned(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	service.EXPECT().TouchUser(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RecordActivity(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+	if setupMock != nil {
+		setupMock(service)
+	}
+	service.EXPECT().UserSettings(gomock.Any(), gomock.Any()).Return(models.UserSettings{}, nil).AnyTimes()
+
+	sessions := cache.NewCache(time.Hour, 0)
+	t.Cleanup(sessions.Close)
+
+	server := NewServer(token)
+	server.SetDispatcher(bot.NewHandler(server, service, sessions))
+
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func do(t *testing.T, req *http.Request) (int, updatesResponse) {
+	t.Helper()
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body updatesResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	return resp.StatusCode, body
+}
+
+func postEvent(t *testing.T, ts *httptest.Server, event EventRequest) (int, updatesResponse) {
+	t.Helper()
+
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/v1/events", bytes.NewReader(payload))
+	require.NoError(t, err)
+
+	return do(t, req)
+}
+
+func TestServer_Start(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, "", func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().UserSettings(gomock.Any(), int64(42)).Return(models.UserSettings{Onboarded: true}, nil)
+	})
+
+	status, body := postEvent(t, ts, EventRequest{ChatID: 7, From: chat.User{ID: 42}, Text: "/start"})
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, body.Updates, 1)
+
+	update := body.Updates[0]
+	assert.Equal(t, "message", update.Type)
+	assert.Equal(t, 2, update.MessageID)
+	require.NotNil(t, update.Message)
+	assert.Equal(t, int64(7), update.Message.ChatID)
+	assert.Equal(t, i18n.T(i18n.Default, bot.ButtonNewWord), update.Message.Menu[0][0])
+}
+
+func TestServer_QuizFlow(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, "", func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().NewQuiz(gomock.Any(), int64(42)).Return("hello", map[string]bool{"привет": true}, nil)
+		ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, card models.QuizCard) error {
+			assert.Equal(t, int64(42), card.UserID)
+			assert.True(t, card.IsCorrect)
+			return nil
+		})
+	})
+
+	_, body := postEvent(t, ts, EventRequest{ChatID: 42, From: chat.User{ID: 42}, Text: i18n.T(i18n.Default, bot.ButtonQuiz)})
+	require.Len(t, body.Updates, 1)
+	question := body.Updates[0]
+	require.NotNil(t, question.Message)
+	assert.Contains(t, question.Message.Text, "hello")
+	require.NotEmpty(t, question.Message.Buttons)
+
+	status, body := postEvent(t, ts, EventRequest{
+		ChatID:    42,
+		From:      chat.User{ID: 42},
+		MessageID: question.MessageID,
+		Data:      question.Message.Buttons[0][0].Data,
+	})
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, body.Updates, 1)
+	assert.Equal(t, "edit", body.Updates[0].Type)
+	require.NotNil(t, body.Updates[0].Edit)
+	assert.Contains(t, body.Updates[0].Edit.Text, "✅ Правильно! привет")
+}
+
+func TestServer_BadRequests(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, "", nil)
+
+	tests := []struct {
+		name  string
+		event EventRequest
+	}{
+		{name: "missing chat", event: EventRequest{From: chat.User{ID: 1}, Text: "/start"}},
+		{name: "missing sender", event: EventRequest{ChatID: 1, Text: "/start"}},
+		{name: "empty text", event: EventRequest{ChatID: 1, From: chat.User{ID: 1}, Text: " "}},
+		{name: "unknown message", event: EventRequest{ChatID: 1, From: chat.User{ID: 1}, MessageID: 99, Data: "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := postEvent(t, ts, tt.event)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.NotEmpty(t, body.Error)
+		})
+	}
+}
+
+func TestServer_Updates(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("")
+
+	_, err := server.Send(chat.NewMessage(5, "hi"))
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/chats/5/updates", nil)
+	require.NoError(t, err)
+	status, body := do(t, req)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, body.Updates, 1)
+	assert.Equal(t, "hi", body.Updates[0].Message.Text)
+
+	_, body = do(t, req)
+	assert.Empty(t, body.Updates)
+}
+
+func TestServer_Limits(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("")
+
+	for i := 0; i < maxQueuedUpdates+5; i++ {
+		_, err := server.Send(chat.NewMessage(5, "hi"))
+		require.NoError(t, err)
+	}
+	updates := server.drain(5)
+	require.Len(t, updates, maxQueuedUpdates, "the oldest replies are dropped")
+	assert.Equal(t, 6, updates[0].MessageID)
+
+	assert.Empty(t, server.drain(6))
+	assert.NotContains(t, server.chats, int64(6), "polling doesn't start a conversation")
+
+	for id := int64(1); id <= maxChats; id++ {
+		_, err := server.Send(chat.NewMessage(id, "hi"))
+		require.NoError(t, err)
+	}
+	_, err := server.Send(chat.NewMessage(maxChats+1, "hi"))
+	require.NoError(t, err)
+	assert.Len(t, server.chats, maxChats)
+	assert.NotContains(t, server.chats, int64(1), "the least recently used chat is dropped")
+	assert.Contains(t, server.chats, int64(5))
+}
+
+func TestServer_Token(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, "secret", nil)
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/chats/1/updates", nil)
+	require.NoError(t, err)
+	status, _ := do(t, req)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	req.Header.Set("Authorization", "Bearer wrong")
+	status, _ = do(t, req)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	req.Header.Set("Authorization", "Bearer secret")
+	status, _ = do(t, req)
+	assert.Equal(t, http.StatusOK, status)
+}
@@ -0,0 +1,172 @@
+// Package i18n holds the bot's UI message catalog. Locale files live in
+// locales/<lang>.json as flat key/message maps; messages may contain fmt verbs.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+const Default = "ru"
+
+// Keys with this prefix are reply-keyboard buttons and can be matched back
+// from the text a user sends.
+const buttonPrefix = "button."
+
+//go:embed locales/*.json
+var locales embed.FS
+
+type Catalog struct {
+	messages map[string]map[string]string
+	buttons  map[string]string
+}
+
+func Load(fsys fs.FS) (*Catalog, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{
+		messages: make(map[string]map[string]string),
+		buttons:  make(map[string]string),
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read locale %s: %w", file, err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse locale %s: %w", file, err)
+		}
+
+		lang := strings.TrimSuffix(path.Base(file), ".json")
+		c.messages[lang] = messages
+
+		for key, text := range messages {
+			if !strings.HasPrefix(key, buttonPrefix) {
+				continue
+			}
+			if other, ok := c.buttons[text]; ok && other != key {
+				return nil, fmt.Errorf("locale %s: button text %q is used by both %s and %s", lang, text, other, key)
+			}
+			c.buttons[text] = key
+		}
+	}
+
+	if _, ok := c.messages[Default]; !ok {
+		return nil, fmt.Errorf("default locale %s not found", Default)
+	}
+
+	return c, nil
+}
+
+// T returns the message for key in lang, falling back to the default locale
+// and then to the key itself.
+func (c *Catalog) T(lang, key string, args ...any) string {
+	msg, ok := c.messages[lang][key]
+	if !ok {
+		msg, ok = c.messages[Default][key]
+		if !ok {
+			msg = key
+		}
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+
+	return msg
+}
+
+// Lookup is T without fallbacks or formatting, for keys built at runtime.
+func (c *Catalog) Lookup(lang, key string) (string, bool) {
+	if msg, ok := c.messages[lang][key]; ok {
+		return msg, true
+	}
+	msg, ok := c.messages[Default][key]
+	return msg, ok
+}
+
+// Match returns the button key whose text in any locale equals text, so
+// keyboards sent before a language switch keep working.
+func (c *Catalog) Match(text string) (string, bool) {
+	key, ok := c.buttons[text]
+	return key, ok
+}
+
+// Normalize maps a language code such as "en-US" to a supported locale.
+func (c *Catalog) Normalize(code string) string {
+	code = strings.ToLower(code)
+	if base, _, found := strings.Cut(code, "-"); found {
+		code = base
+	}
+
+	if _, ok := c.messages[code]; ok {
+		return code
+	}
+
+	return Default
+}
+
+func (c *Catalog) Supported(lang string) bool {
+	_, ok := c.messages[lang]
+	return ok
+}
+
+func (c *Catalog) Languages() []string {
+	langs := make([]string, 0, len(c.messages))
+	for lang := range c.messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+var std = mustLoad()
+
+func mustLoad() *Catalog {
+	sub, err := fs.Sub(locales, "locales")
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := Load(sub)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func T(lang, key string, args ...any) string {
+	return std.T(lang, key, args...)
+}
+
+func Lookup(lang, key string) (string, bool) {
+	return std.Lookup(lang, key)
+}
+
+func Match(text string) (string, bool) {
+	return std.Match(text)
+}
+
+func Normalize(code string) string {
+	return std.Normalize(code)
+}
+
+func Supported(lang string) bool {
+	return std.Supported(lang)
+}
+
+func Languages() []string {
+	return std.Languages()
+}
@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var verbRe = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func TestLocales_Complete(t *testing.T) {
+	t.Parallel()
+
+	base := std.messages[Default]
+	for _, lang := range std.Languages() {
+		messages := std.messages[lang]
+
+		for key, text := range base {
+			translated, ok := messages[key]
+			if !assert.Truef(t, ok, "%s: missing key %s", lang, key) {
+				continue
+			}
+			assert.Equalf(t, verbRe.FindAllString(text, -1), verbRe.FindAllString(translated, -1), "%s: format verbs of %s differ", lang, key)
+		}
+		for key := range messages {
+			_, ok := base[key]
+			assert.Truef(t, ok, "%s: key %s is not in the default locale", lang, key)
+		}
+	}
+}
+
+func TestCatalog_T(t *testing.T) {
+	t.Parallel()
+
+	c, err := Load(fstest.MapFS{
+		"ru.json": {Data: []byte(`{"hello": "Привет, %s", "only_ru": "только"}`)},
+		"en.json": {Data: []byte(`{"hello": "Hello, %s"}`)},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello, Bob", c.T("en", "hello", "Bob"))
+	assert.Equal(t, "Привет, Bob", c.T("de", "hello", "Bob"))
+	assert.Equal(t, "только", c.T("en", "only_ru"))
+	assert.Equal(t, "missing", c.T("en", "missing"))
+}
+
+func TestCatalog_Match(t *testing.T) {
+	t.Parallel()
+
+	key, ok := Match(T("en", "button.quiz"))
+	require.True(t, ok)
+	assert.Equal(t, "button.quiz", key)
+
+	key, ok = Match(T("uk", "button.quiz"))
+	require.True(t, ok)
+	assert.Equal(t, "button.quiz", key)
+
+	_, ok = Match("random text")
+	assert.False(t, ok)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "no default locale",
+			fsys: fstest.MapFS{"en.json": {Data: []byte(`{}`)}},
+		},
+		{
+			name: "invalid json",
+			fsys: fstest.MapFS{"ru.json": {Data: []byte(`{`)}},
+		},
+		{
+			name: "ambiguous button",
+			fsys: fstest.MapFS{
+				"ru.json": {Data: []byte(`{"button.a": "X"}`)},
+				"en.json": {Data: []byte(`{"button.b": "X"}`)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Load(tt.fsys)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":      Default,
+		"en":    "en",
+		"en-US": "en",
+		"UK":    "uk",
+		"de":    Default,
+	}
+
+	for code, want := range tests {
+		assert.Equal(t, want, Normalize(code), code)
+	}
+}
@@ -0,0 +1,66 @@
+package lemma
+
+// irregular maps forms that no suffix rule explains to their dictionary
+// form.
+var irregular = map[string]string{
+	// Verbs.
+	"arose": "arise", "arisen": "arise", "ate": "eat", "eaten": "eat",
+	"awoke": "awake", "awoken": "awake", "became": "become", "began": "begin",
+	"begun": "begin", "bent": "bend", "bled": "bleed", "blew": "blow",
+	"blown": "blow", "bought": "buy", "bred": "breed", "broke": "break",
+	"broken": "break", "brought": "bring", "built": "build", "came": "come",
+	"caught": "catch", "chose": "choose", "chosen": "choose", "dealt": "deal",
+	"died": "die", "dies": "die", "dying": "die", "drank": "drink",
+	"drawn": "draw", "drew": "draw", "driven": "drive", "drove": "drive",
+	"drunk": "drink", "dug": "dig", "fed": "feed", "fell": "fall",
+	"fallen": "fall", "felt": "feel", "fled": "flee", "flew": "fly",
+	"flies": "fly", "flown": "fly", "forbade": "forbid", "forbidden": "forbid",
+	"forgave": "forgive", "forgiven": "forgive", "forgot": "forget", "forgotten": "forget",
+	"fought": "fight", "found": "find", "froze": "freeze", "frozen": "freeze",
+	"gave": "give", "given": "give", "goes": "go", "going": "go",
+	"gone": "go", "got": "get", "gotten": "get", "grew": "grow",
+	"grown": "grow", "has": "have", "heard": "hear", "held": "hold",
+	"hid": "hide", "hidden": "hide", "hung": "hang", "kept": "keep",
+	"knew": "know", "known": "know", "led": "lead", "lent": "lend",
+	"lied": "lie", "lies": "lie", "lying": "lie", "lit": "light",
+	"lost": "lose", "made": "make", "meant": "mean", "met": "meet",
+	"misled": "mislead", "overcame": "overcome", "paid": "pay", "ran": "run",
+	"ridden": "ride", "rode": "ride", "said": "say", "sang": "sing",
+	"sat": "sit", "saw": "see", "seen": "see", "sent": "send",
+	"shaken": "shake", "shook": "shake", "slept": "sleep", "slid": "slide",
+	"sold": "sell", "sought": "seek", "spent": "spend", "spoke": "speak",
+	"spoken": "speak", "spun": "spin", "stole": "steal", "stolen": "steal",
+	"stood": "stand", "struck": "strike", "stuck": "stick", "stung": "sting",
+	"sung": "sing", "swam": "swim", "swept": "sweep", "swore": "swear",
+	"sworn": "swear", "swum": "swim", "taken": "take", "taught": "teach",
+	"thought": "think", "threw": "throw", "thrown": "throw", "tied": "tie",
+	"ties": "tie", "tying": "tie", "told": "tell", "took": "take",
+	"tore": "tear", "torn": "tear", "understood": "understand", "undertaken": "undertake",
+	"undertook": "undertake", "went": "go", "wept": "weep", "withdrawn": "withdraw",
+	"withdrew": "withdraw", "woke": "wake", "woken": "wake", "won": "win",
+	"wore": "wear", "worn": "wear", "written": "write", "wrote": "write",
+
+	// Nouns.
+	"analyses": "analysis", "calves": "calf", "children": "child", "crises": "crisis",
+	"criteria": "criterion", "feet": "foot", "geese": "goose", "halves": "half",
+	"hypotheses": "hypothesis", "indices": "index", "knives": "knife", "loaves": "loaf",
+	"men": "man", "mice": "mouse", "phenomena": "phenomenon", "shelves": "shelf",
+	"teeth": "tooth", "theses": "thesis", "thieves": "thief", "wives": "wife",
+	"wolves": "wolf", "women": "woman",
+
+	// Adjectives.
+	"best": "good", "better": "good", "bigger": "big", "biggest": "big",
+	"cheaper": "cheap", "cheapest": "cheap", "closer": "close", "closest": "close",
+	"deeper": "deep", "deepest": "deep", "earlier": "early", "earliest": "early",
+	"easier": "easy", "easiest": "easy", "farther": "far", "farthest": "far",
+	"faster": "fast", "fastest": "fast", "greater": "great", "greatest": "great",
+	"happier": "happy", "happiest": "happy", "harder": "hard", "hardest": "hard",
+	"heavier": "heavy", "heaviest": "heavy", "higher": "high", "highest": "high",
+	"larger": "large", "largest": "large", "longer": "long", "longest": "long",
+	"lower": "low", "lowest": "low", "newer": "new", "newest": "new",
+	"older": "old", "oldest": "old", "richer": "rich", "richest": "rich",
+	"shorter": "short", "shortest": "short", "simpler": "simple", "simplest": "simple",
+	"smaller": "small", "smallest": "small", "stronger": "strong", "strongest": "strong",
+	"wider": "wide", "widest": "wide", "worse": "bad", "worst": "bad",
+	"younger": "young", "youngest": "young",
+}
@@ -0,0 +1,188 @@
+package lemma
+
+import "strings"
+
+// Lemmatizer brings inflected words to their dictionary form. Suffix rules
+// give several possible forms, a known one is preferred; for unknown words
+// the rules guess.
+type Lemmatizer struct {
+	known map[string]bool
+}
+
+// New returns a lemmatizer that knows the given dictionary forms.
+func New(known []string) *Lemmatizer {
+	return &Lemmatizer{known: toSet(known...)}
+}
+
+// Lemma returns the dictionary form of a lowercase word.
+func (l *Lemmatizer) Lemma(word string) string {
+	if lemma, ok := irregular[word]; ok {
+		return lemma
+	}
+	if l.known[word] {
+		return word
+	}
+
+	candidates, guess := bases(word)
+	for _, c := range candidates {
+		if l.known[c] {
+			return c
+		}
+	}
+	return guess
+}
+
+// Inflections lists the regular forms of an English word: plurals, the third
+// person, past tense and the -ing form.
+func Inflections(word string) map[string]bool {
+	w := strings.ToLower(word)
+
+	forms := map[string]bool{
+		w:         true,
+		w + "s":   true,
+		w + "es":  true,
+		w + "d":   true,
+		w + "ed":  true,
+		w + "ing": true,
+	}
+
+	// make → making
+	if stem, ok := strings.CutSuffix(w, "e"); ok && stem != "" {
+		forms[stem+"ing"] = true
+	}
+
+	// study → studies, studied
+	if stem, ok := strings.CutSuffix(w, "y"); ok && stem != "" && !isVowel(stem[len(stem)-1]) {
+		forms[stem+"ies"] = true
+		forms[stem+"ied"] = true
+	}
+
+	// stop → stopped, stopping
+	if endsCVC(w) {
+		forms[w+w[len(w)-1:]+"ed"] = true
+		forms[w+w[len(w)-1:]+"ing"] = true
+	}
+
+	return forms
+}
+
+// bases returns the forms word may come from, most likely first, and the one
+// to take when none of them is known.
+func bases(word string) ([]string, string) {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return []string{word[:len(word)-3] + "y", word[:len(word)-1]}, word[:len(word)-3] + "y"
+	case len(word) > 4 && strings.HasSuffix(word, "ied"):
+		return []string{word[:len(word)-3] + "y"}, word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "eed"):
+		return []string{word[:len(word)-1]}, word
+	case strings.HasSuffix(word, "s"):
+		return plural(word)
+	case strings.HasSuffix(word, "ing"):
+		return verbForm(word[:len(word)-3], word)
+	case strings.HasSuffix(word, "ed"):
+		return verbForm(word[:len(word)-2], word)
+	}
+	return nil, word
+}
+
+func plural(word string) ([]string, string) {
+	if len(word) <= 3 {
+		return nil, word
+	}
+	for _, suffix := range []string{"ss", "us", "is", "ics"} {
+		if strings.HasSuffix(word, suffix) {
+			return nil, word
+		}
+	}
+
+	var (
+		noS  = word[:len(word)-1]
+		noES = word[:len(word)-2]
+	)
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		return nil, noES
+	case strings.HasSuffix(word, "zzes"):
+		return nil, word[:len(word)-3]
+	case strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "oes"):
+		return []string{noES, noS}, noES
+	case strings.HasSuffix(word, "ves"):
+		return []string{noS, word[:len(word)-3] + "f", word[:len(word)-3] + "fe"}, noS
+	case strings.HasSuffix(word, "es"):
+		return []string{noS, noES}, noS
+	}
+	return nil, noS
+}
+
+// verbForm undoes an -ing or -ed ending cut off from word.
+func verbForm(stem, word string) ([]string, string) {
+	if len(stem) < 2 || !strings.ContainsAny(stem, "aeiouy") {
+		return nil, word
+	}
+
+	switch {
+	case doubled(stem):
+		return []string{stem[:len(stem)-1], stem}, stem[:len(stem)-1]
+	case endsCVC(stem):
+		if len(stem) == 3 || needsE(stem) {
+			return []string{stem + "e", stem}, stem + "e"
+		}
+		return []string{stem + "e", stem}, stem
+	case needsE(stem):
+		return []string{stem + "e", stem}, stem + "e"
+	}
+	return []string{stem, stem + "e"}, stem
+}
+
+// doubled reports whether the last consonant of stem was doubled before the
+// ending, as in "running". Double l, s, f and z belong to the word itself.
+func doubled(stem string) bool {
+	n := len(stem)
+	if n < 3 || stem[n-1] != stem[n-2] || isVowel(stem[n-1]) {
+		return false
+	}
+	return !strings.ContainsRune("lsfz", rune(stem[n-1]))
+}
+
+// endsCVC reports whether stem ends in a consonant, a vowel and a consonant
+// other than w, x or y, like "hop" or "visit".
+func endsCVC(stem string) bool {
+	n := len(stem)
+	if n < 3 {
+		return false
+	}
+	return !isVowel(stem[n-3]) && isVowel(stem[n-2]) && !isVowel(stem[n-1]) && !strings.ContainsRune("wxy", rune(stem[n-1]))
+}
+
+// eEndings are endings of stems that lost a silent e, as in "making" or
+// "closed". Those marked with a leading dot only count after a consonant.
+var eEndings = []string{
+	"v", "c", "u", "z", "dg", "rg", "uir",
+	"rs", "ns", "ps", "ls", "as", "is", "os", "us", "ys",
+	"bl", "cl", "dl", "fl", "gl", "kl", "pl", "tl", "zl",
+	".ak", ".ik", ".ok", ".am", ".im", ".om", ".um", ".in", ".un",
+	".ap", ".ip", ".ar", ".ir", ".ur", ".at", ".ut", ".ad", ".id", ".od", ".ud",
+}
+
+func needsE(stem string) bool {
+	for _, e := range eEndings {
+		afterConsonant := strings.HasPrefix(e, ".")
+		e = strings.TrimPrefix(e, ".")
+
+		if !strings.HasSuffix(stem, e) {
+			continue
+		}
+		if !afterConsonant {
+			return true
+		}
+		if i := len(stem) - len(e) - 1; i >= 0 && !isVowel(stem[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
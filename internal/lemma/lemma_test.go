@@ -0,0 +1,110 @@
+package lemma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	got := Tokenize("The Smiths’ dog didn't bark. Anna's well-known 2nd café -- opened!\nNext Mr. Brown")
+
+	assert.Equal(t, []Token{
+		{Word: "the", Capitalized: true, SentenceStart: true},
+		{Word: "smiths", Capitalized: true},
+		{Word: "dog"},
+		{Word: "bark"},
+		{Word: "anna", Capitalized: true, SentenceStart: true},
+		{Word: "well-known"},
+		{Word: "opened"},
+		{Word: "next", Capitalized: true, SentenceStart: true},
+		{Word: "mr", Capitalized: true},
+		{Word: "brown", Capitalized: true},
+	}, got)
+}
+
+func TestIsStopWord(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsStopWord("the"))
+	assert.True(t, IsStopWord("themselves"))
+	assert.False(t, IsStopWord("meadow"))
+}
+
+func TestLemmatizer_Lemma(t *testing.T) {
+	t.Parallel()
+
+	l := New([]string{"hope", "hop", "shoe", "bus", "news", "open", "visit", "morning", "agree", "cookie", "cause"})
+
+	for word, want := range map[string]string{
+		// Known words and irregular forms.
+		"news":     "news",
+		"morning":  "morning",
+		"went":     "go",
+		"children": "child",
+		"better":   "good",
+		"wolves":   "wolf",
+
+		// Known forms win over the guesses.
+		"hoped":   "hope",
+		"hopping": "hop",
+		"shoes":   "shoe",
+		"buses":   "bus",
+		"opened":  "open",
+		"visited": "visit",
+		"agreed":  "agree",
+		"cookies": "cookie",
+		"caused":  "cause",
+
+		// Guesses for unknown words.
+		"meadows":    "meadow",
+		"boxes":      "box",
+		"glasses":    "glass",
+		"quizzes":    "quiz",
+		"horses":     "horse",
+		"berries":    "berry",
+		"carried":    "carry",
+		"wandering":  "wander",
+		"stopped":    "stop",
+		"making":     "make",
+		"combined":   "combine",
+		"whistling":  "whistle",
+		"arguing":    "argue",
+		"dancing":    "dance",
+		"closed":     "close",
+		"organized":  "organize",
+		"developed":  "develop",
+		"calling":    "call",
+		"called":     "call",
+		"thing":      "thing",
+		"string":     "string",
+		"status":     "status",
+		"analysis":   "analysis",
+		"physics":    "physics",
+		"guaranteed": "guaranteed",
+		"well-known": "well-known",
+	} {
+		assert.Equal(t, want, l.Lemma(word), word)
+	}
+}
+
+func TestInflections(t *testing.T) {
+	t.Parallel()
+
+	for word, forms := range map[string][]string{
+		"apple": {"apple", "apples"},
+		"watch": {"watches", "watched", "watching"},
+		"make":  {"makes", "making"},
+		"study": {"studies", "studied", "studying"},
+		"stop":  {"stops", "stopped", "stopping"},
+	} {
+		got := Inflections(word)
+		for _, form := range forms {
+			assert.True(t, got[form], "%s of %s", form, word)
+		}
+	}
+
+	assert.False(t, Inflections("make")["made"], "irregular forms aren't listed")
+}
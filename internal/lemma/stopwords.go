@@ -0,0 +1,41 @@
+package lemma
+
+// stopWords are function words, not worth learning on their own.
+var stopWords = toSet(
+	"a", "about", "above", "after", "again", "against", "ago", "all", "almost", "along",
+	"already", "also", "although", "always", "am", "among", "an", "and", "another", "any",
+	"anybody", "anyone", "anything", "anyway", "anywhere", "are", "around", "as", "at", "away",
+	"back", "be", "because", "been", "before", "behind", "being", "below", "beside", "besides",
+	"between", "beyond", "both", "but", "by", "can", "cannot", "could", "did", "do",
+	"does", "doing", "done", "down", "during", "each", "either", "else", "enough", "etc",
+	"even", "ever", "every", "everybody", "everyone", "everything", "everywhere", "except", "few", "for",
+	"from", "further", "had", "has", "have", "having", "he", "her", "here", "hers",
+	"herself", "him", "himself", "his", "how", "however", "i", "if", "in", "inside",
+	"into", "is", "it", "its", "itself", "just", "least", "less", "like", "many",
+	"may", "me", "might", "mine", "more", "most", "much", "must", "my", "myself",
+	"near", "neither", "never", "next", "no", "nobody", "none", "nor", "not", "nothing",
+	"now", "nowhere", "of", "off", "often", "oh", "ok", "okay", "on", "once",
+	"one", "only", "onto", "or", "other", "others", "otherwise", "ought", "our", "ours",
+	"ourselves", "out", "outside", "over", "own", "per", "perhaps", "please", "quite", "rather",
+	"same", "shall", "she", "should", "since", "so", "some", "somebody", "someone", "something",
+	"sometimes", "somewhere", "soon", "still", "such", "than", "that", "the", "their", "theirs",
+	"them", "themselves", "then", "there", "therefore", "these", "they", "this", "those", "though",
+	"through", "thus", "till", "to", "together", "too", "toward", "towards", "under", "unless",
+	"until", "up", "upon", "us", "very", "via", "was", "we", "well", "were",
+	"what", "whatever", "when", "whenever", "where", "whereas", "wherever", "whether", "which", "while",
+	"who", "whoever", "whole", "whom", "whose", "why", "will", "with", "within", "without",
+	"would", "yes", "yet", "you", "your", "yours", "yourself", "yourselves",
+)
+
+// IsStopWord reports whether a lowercase word is a function word.
+func IsStopWord(word string) bool {
+	return stopWords[word]
+}
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,90 @@
+// Package lemma splits English text into words and brings them to their
+// dictionary form.
+package lemma
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Token is a word of a text, lowercased.
+type Token struct {
+	Word string
+	// Capitalized is set for words written with a capital letter. Together
+	// with SentenceStart it tells names from ordinary words.
+	Capitalized   bool
+	SentenceStart bool
+}
+
+// contractions are the endings of short forms like "don't" or "they're".
+// Such words are all function words and are dropped.
+var contractions = []string{"n't", "'re", "'ve", "'ll", "'d", "'m"}
+
+// titles are abbreviations whose dot doesn't end a sentence.
+var titles = toSet("mr", "mrs", "ms", "dr", "prof", "st", "jr", "sr", "vs")
+
+// Tokenize returns the English words of text. Words with digits or letters
+// of other alphabets are skipped, possessive endings are cut off.
+func Tokenize(text string) []Token {
+	var (
+		tokens []Token
+		word   []rune
+		token  Token
+		valid  = true
+		start  = true
+	)
+
+	flush := func() {
+		defer func() {
+			word = word[:0]
+			valid = true
+		}()
+
+		w := strings.Trim(string(word), "'-")
+		if !valid || w == "" || strings.Contains(w, "--") {
+			return
+		}
+
+		w = strings.TrimSuffix(w, "'s")
+		for _, c := range contractions {
+			if strings.HasSuffix(w, c) {
+				return
+			}
+		}
+
+		token.Word = strings.ToLower(w)
+		tokens = append(tokens, token)
+		start = false
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if len(word) == 0 {
+				token = Token{Capitalized: unicode.IsUpper(r), SentenceStart: start}
+			}
+			if r > unicode.MaxASCII || unicode.IsDigit(r) {
+				valid = false
+			}
+			word = append(word, r)
+		case (r == '\'' || r == '’' || r == '-') && len(word) > 0:
+			if r == '’' {
+				r = '\''
+			}
+			word = append(word, r)
+		default:
+			if len(word) > 0 {
+				flush()
+			}
+			title := r == '.' && !start && titles[tokens[len(tokens)-1].Word]
+			if (r == '.' || r == '!' || r == '?' || r == '\n') && !title {
+				start = true
+			}
+		}
+	}
+	if len(word) > 0 {
+		flush()
+	}
+
+	return tokens
+}
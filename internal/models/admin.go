@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type GlobalStats struct {
+	Users       int `db:"users"`
+	ActiveToday int `db:"active_today"`
+	ActiveWeek  int `db:"active_week"`
+	WordsTotal  int `db:"words_total"`
+	WordsToday  int `db:"words_today"`
+	QuizTotal   int `db:"quiz_total"`
+	QuizToday   int `db:"quiz_today"`
+	Banned      int `db:"banned"`
+}
+
+type UserInfo struct {
+	UserID         int64      `db:"user_id"`
+	Language       string     `db:"language"`
+	Words          int        `db:"words"`
+	Learned        int        `db:"learned"`
+	Quizzes        int        `db:"quizzes"`
+	Correct        int        `db:"correct"`
+	LastActive     *time.Time `db:"last_active"`
+	WotdSubscribed bool       `db:"wotd"`
+	Banned         bool       `db:"banned"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// The file id of a word's audio is kept per kind: a listening quiz uploads
+// its clip under a neutral name, so the word isn't shown as the title.
+const (
+	AudioCard      = "card"
+	AudioListening = "listening"
+)
+
+// Audio is a word's pronunciation. FileID is set when the frontend already
+// has the file, Data otherwise.
+type Audio struct {
+	FileID string
+	Name   string
+	Data   []byte
+}
@@ -3,5 +3,8 @@ package models
 import "errors"
 
 var (
-	ErrAPI = errors.New("API error")
+	ErrAPI         = errors.New("API error")
+	ErrNoHardWords = errors.New("no hard words")
+	ErrNoAudio     = errors.New("no pronunciation audio")
+	ErrNoWord      = errors.New("word not found")
 )
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// DefaultDailyGoal is used until the user picks a goal during onboarding.
+const DefaultDailyGoal = 10
+
+// What a daily goal counts: words answered on cards or quiz answers. Words
+// are counted unless the user picked otherwise.
+const (
+	GoalWords = "words"
+	GoalQuiz  = "quiz"
+)
+
+// Activity is something the user earns XP for.
+type Activity string
+
+const (
+	ActivityWordKnown  Activity = "word_known"
+	ActivityWordRepeat Activity = "word_repeat"
+	ActivityQuizRight  Activity = "quiz_right"
+	ActivityQuizWrong  Activity = "quiz_wrong"
+	ActivityDailyGoal  Activity = "daily_goal"
+)
+
+// GameStats holds the totals achievements are checked against.
+type GameStats struct {
+	Learned      int `db:"learned"`
+	QuizAnswers  int `db:"quiz_answers"`
+	GoalsReached int `db:"goals_reached"`
+	Streak       int `db:"-"`
+}
+
+type UserAchievement struct {
+	Code       string    `db:"achievement"`
+	UnlockedAt time.Time `db:"unlocked_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+// GroupQuizType marks a group quiz, its answers are stored in
+// group_quiz_results for the chat leaderboard.
+const GroupQuizType = "group"
+
+type LeaderboardEntry struct {
+	UserID    int64  `db:"user_id"`
+	UserName  string `db:"username"`
+	FirstName string `db:"first_name"`
+	Points    int    `db:"points"`
+	Answers   int    `db:"answers"`
+}
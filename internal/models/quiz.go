@@ -4,11 +4,22 @@ import "time"
 
 type QuizCard struct {
 	UserID      int64     `db:"user_id"`
+	ChatID      int64     `db:"chat_id"`
 	Word        string    `db:"word"`
 	Translation string    `db:"translation"`
 	Type        string    `db:"type"`
 	IsCorrect   bool      `db:"is_correct"`
 	LastSeen    time.Time `db:"last_seen"`
+
+	// Answered lists the members who already gave a wrong answer to a group
+	// quiz, it lives only in the session.
+	Answered []int64 `db:"-"`
+	// CorrectOption is the index of the right answer of a quiz sent as a
+	// poll, it lives only in the session.
+	CorrectOption int `db:"-"`
+	// Answer is the correct option of a quiz answered with English words,
+	// it lives only in the session.
+	Answer string `db:"-"`
 }
 
 type QuizStats struct {
@@ -16,3 +27,37 @@ type QuizStats struct {
 	RightCount int `db:"right_count"`
 	WrongCount int `db:"wrong_count"`
 }
+
+// HardWord sums up a user's quiz answers for a word they have missed at
+// least once. Streak counts correct answers given after the last miss.
+type HardWord struct {
+	Word        string    `db:"word"`
+	Translation string    `db:"translation"`
+	Attempts    int       `db:"attempts"`
+	Misses      int       `db:"misses"`
+	Streak      int       `db:"streak"`
+	LastMiss    time.Time `db:"last_miss"`
+}
+
+// WordQuiz is a question about an English word that is answered with
+// English words, like an example sentence with the word left out.
+type WordQuiz struct {
+	Word        string
+	Translation string
+	Question    string
+	Options     map[string]bool
+}
+
+// QuizWord is a word with what is known about it for picking the wrong
+// options of a quiz.
+type QuizWord struct {
+	Word         string `db:"word_text"`
+	Translation  string `db:"translation"`
+	PartOfSpeech string `db:"-"`
+	// Rank is the word's place in the frequency list, 0 if it isn't there.
+	Rank int `db:"-"`
+	// Synonyms and Alternatives are the English synonyms and the other
+	// translations of the word, wrong options must not match them.
+	Synonyms     []string `db:"-"`
+	Alternatives []string `db:"-"`
+}
@@ -0,0 +1,13 @@
+package models
+
+const (
+	SessionWord      = "word"
+	SessionQuiz      = "quiz"
+	SessionPoll      = "poll"
+	SessionChecklist = "checklist"
+)
+
+type SessionKey struct {
+	ChatID    int64 `db:"chat_id"`
+	MessageID int   `db:"message_id"`
+}
@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+type StatsPeriod string
+
+const (
+	PeriodWeek  StatsPeriod = "7d"
+	PeriodMonth StatsPeriod = "30d"
+	PeriodAll   StatsPeriod = "all"
+)
+
+var StatsPeriods = []StatsPeriod{PeriodWeek, PeriodMonth, PeriodAll}
+
+func ParseStatsPeriod(s string) (StatsPeriod, bool) {
+	for _, p := range StatsPeriods {
+		if string(p) == s {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// Since returns the start of the period ending at now; the zero time for PeriodAll.
+func (p StatsPeriod) Since(now time.Time) time.Time {
+	switch p {
+	case PeriodWeek:
+		return StartOfDay(now.AddDate(0, 0, -6))
+	case PeriodMonth:
+		return StartOfDay(now.AddDate(0, 0, -29))
+	default:
+		return time.Time{}
+	}
+}
+
+// StartOfDay returns the UTC midnight of t's day. Days are UTC everywhere, the
+// database sessions run in UTC too.
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// StartOfWeek returns the Monday of t's week in UTC.
+func StartOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return StartOfDay(t.AddDate(0, 0, -offset))
+}
+
+// Bucket is the date_trunc unit used to group the period's history.
+func (p StatsPeriod) Bucket() string {
+	if p == PeriodWeek {
+		return "day"
+	}
+	return "week"
+}
+
+type DayCount struct {
+	Day   time.Time `db:"day"`
+	Count int       `db:"count"`
+}
+
+type DayAccuracy struct {
+	Day     time.Time `db:"day"`
+	Total   int       `db:"total"`
+	Correct int       `db:"correct"`
+}
+
+type TypeAccuracy struct {
+	Type    string `db:"type"`
+	Total   int    `db:"total"`
+	Correct int    `db:"correct"`
+}
+
+type MissedWord struct {
+	Word        string `db:"word"`
+	Translation string `db:"translation"`
+	Misses      int    `db:"misses"`
+	Attempts    int    `db:"attempts"`
+}
+
+type LearnTime struct {
+	Count      int     `db:"count"`
+	AvgSeconds float64 `db:"avg_seconds"`
+}
+
+type ProgressStats struct {
+	Period        StatsPeriod
+	CurrentStreak int
+	BestStreak    int
+	LearnedToday  int
+	LearnedWeek   int
+	Learned       []DayCount
+	Accuracy      []DayAccuracy
+	ByType        []TypeAccuracy
+	MostMissed    []MissedWord
+	TimeToLearn   LearnTime
+}
+
+// Chart is a rendered PNG image with the caption it is sent with.
+type Chart struct {
+	Name    string
+	Caption string
+	PNG     []byte
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type User struct {
+	ID           int64     `db:"user_id"`
+	UserName     string    `db:"username"`
+	FirstName    string    `db:"first_name"`
+	LanguageCode string    `db:"language_code"`
+	CreatedAt    time.Time `db:"created_at"`
+	LastActiveAt time.Time `db:"last_active_at"`
+}
+
+// UserSettings is stored as JSON in users.settings. Empty fields are left out,
+// so a partial value can be merged into the stored one.
+type UserSettings struct {
+	Language       string `json:"language,omitempty"`
+	TargetLanguage string `json:"target_language,omitempty"`
+	Level          string `json:"level,omitempty"`
+	DailyGoal      int    `json:"daily_goal,omitempty"`
+	GoalType       string `json:"goal_type,omitempty"`
+	Onboarded      bool   `json:"onboarded,omitempty"`
+	QuizMode       string `json:"quiz_mode,omitempty"`
+	PageSize       int    `json:"page_size,omitempty"`
+}
+
+// Ways a quiz question can be asked.
+const (
+	QuizModeButtons = "buttons"
+	QuizModePoll    = "poll"
+)
+
+// Choices offered during onboarding.
+var (
+	TargetLanguages = []string{"en"}
+	Levels          = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+	DailyGoals      = []int{5, 10, 20, 30}
+	QuizGoals       = []int{10, 20, 50, 100}
+	GoalTypes       = []string{GoalWords, GoalQuiz}
+	QuizModes       = []string{QuizModeButtons, QuizModePoll}
+	PageSizes       = []int{5, DefaultPageSize, 20}
+)
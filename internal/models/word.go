@@ -10,6 +10,46 @@ type WordCard struct {
 	Translation string    `db:"translation"`
 	LastSeen    time.Time `db:"last_seen"`
 	Known       bool      `db:"known"`
+
+	// Audio is the pronunciation URL from the dictionary, it lives only in
+	// the session.
+	Audio string `db:"-"`
+	// Details are saved with the word when it comes from a dictionary card.
+	Details *WordDetails `db:"-"`
+	// Chosen marks a word the user added themselves, from a word card or
+	// inline mode. Only those count toward the daily words goal.
+	Chosen bool `db:"-"`
+}
+
+// WordDetails keeps the API responses a word card was built from, so the card
+// can be shown again without calling the APIs.
+type WordDetails struct {
+	Translation MyMemoryTranslationResult `json:"translation"`
+	Dictionary  TranslationResponse       `json:"dictionary"`
+}
+
+// LookupResult is a word offered by inline mode, with the text of its card.
+// Saved words are already in the user's list.
+type LookupResult struct {
+	Card  WordCard
+	Text  string
+	Saved bool
+}
+
+// TextWord is a new word found in a text the user sent.
+type TextWord struct {
+	Word        string `json:"word"`
+	Translation string `json:"translation"`
+	// Count is how many times the word occurs in the text.
+	Count int `json:"count"`
+}
+
+// WordChecklist holds the words found in a text while the user picks the
+// ones to add.
+type WordChecklist struct {
+	UserID   int64      `json:"user_id"`
+	Words    []TextWord `json:"words"`
+	Selected []bool     `json:"selected"`
 }
 
 type WordStats struct {
@@ -17,3 +57,41 @@ type WordStats struct {
 	LearnedCount   int `db:"learned_count"`
 	UnlearnedCount int `db:"unlearned_count"`
 }
+
+// WordList picks a page of the user's words. Empty filters match every word.
+type WordList struct {
+	Deck         string
+	Prefix       string
+	PartOfSpeech string
+	AddedDays    int
+	Sort         string
+	Page         int
+	PageSize     int
+}
+
+// Decks of the word list: the words being learned, the learned ones or both.
+const (
+	DeckLearning = "learning"
+	DeckLearned  = "learned"
+	DeckAll      = "all"
+)
+
+// Orders of the word list. Due words are the ones not seen for the longest
+// time, the errors order puts the words missed most in quizzes first.
+const (
+	WordSortRecent = "recent"
+	WordSortAlpha  = "alpha"
+	WordSortAdded  = "added"
+	WordSortDue    = "due"
+	WordSortErrors = "errors"
+)
+
+const DefaultPageSize = 10
+
+// Choices offered by the word list buttons.
+var (
+	Decks         = []string{DeckLearning, DeckLearned, DeckAll}
+	WordSorts     = []string{WordSortRecent, WordSortAlpha, WordSortAdded, WordSortDue, WordSortErrors}
+	PartsOfSpeech = []string{"noun", "verb", "adjective", "adverb"}
+	AddedPeriods  = []int{7, 30}
+)
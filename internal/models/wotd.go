@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type WordOfDay struct {
+	Day         time.Time
+	LangPair    string
+	Word        string
+	Translation string
+	// Card is rendered the same way all day without calling the APIs again.
+	Card WordDetails
+}
+
+type WotdSubscriber struct {
+	UserID   int64  `db:"user_id"`
+	ChatID   int64  `db:"chat_id"`
+	Language string `db:"language"`
+}
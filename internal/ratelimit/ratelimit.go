@@ -0,0 +1,168 @@
+// Package ratelimit implements token buckets: a bucket holds up to burst
+// tokens, refills at rate tokens per second and every call takes one.
+package ratelimit
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLimited is returned by callers of Allow when a call is over its limit.
+var ErrLimited = errors.New("rate limit exceeded")
+
+// Bucket is a single token bucket, optionally with a cap on the tokens taken
+// per day. A nil Bucket or one with a zero rate and no daily cap doesn't
+// limit anything.
+type Bucket struct {
+	rate  float64
+	burst float64
+	daily int
+	now   func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	day    time.Time
+	used   int
+}
+
+func NewBucket(rate float64, burst int) *Bucket {
+	return &Bucket{
+		rate:   rate,
+		burst:  float64(max(burst, 1)),
+		now:    time.Now,
+		tokens: float64(max(burst, 1)),
+	}
+}
+
+// SetDaily caps the tokens taken per UTC day, e.g. to stay within an API's
+// daily quota; zero turns the cap off. It must be called before the Bucket is
+// used.
+func (b *Bucket) SetDaily(n int) {
+	b.daily = n
+}
+
+// Allow takes a token if there is one. It never waits, so a caller handling
+// updates one by one doesn't hold up everyone else.
+func (b *Bucket) Allow() bool {
+	if b == nil || (b.rate <= 0 && b.daily <= 0) {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.daily > 0 {
+		if day := b.now().UTC().Truncate(24 * time.Hour); !day.Equal(b.day) {
+			b.day, b.used = day, 0
+		}
+		if b.used >= b.daily {
+			return false
+		}
+	}
+
+	if b.rate > 0 {
+		b.refill()
+		if b.tokens < 1 {
+			return false
+		}
+		b.tokens--
+	}
+	b.used++
+	return true
+}
+
+// full reports whether the bucket has refilled completely, so dropping it
+// loses nothing.
+func (b *Bucket) full() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	return b.tokens >= b.burst
+}
+
+func (b *Bucket) refill() {
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+}
+
+// pruneEvery is how many calls a Limiter takes between dropping the buckets
+// of users who went quiet.
+const pruneEvery = 1000
+
+// Limiter keeps a bucket per key, e.g. per user. A nil Limiter or one with a
+// zero rate doesn't limit anything.
+type Limiter struct {
+	rate  float64
+	burst int
+	now   func() time.Time
+
+	// onDrop is called with the keys whose buckets were dropped.
+	onDrop func(key int64)
+
+	mu      sync.Mutex
+	buckets map[int64]*Bucket
+	calls   int
+}
+
+func New(rate float64, burst int) *Limiter {
+	return &Limiter{
+		rate:    rate,
+		burst:   burst,
+		now:     time.Now,
+		buckets: make(map[int64]*Bucket),
+	}
+}
+
+// OnDrop sets f to be called with every key whose bucket is dropped, so that
+// whatever the caller keeps per key can be dropped too. It must be set before
+// the Limiter is used.
+func (l *Limiter) OnDrop(f func(key int64)) {
+	l.onDrop = f
+}
+
+// Allow takes a token from the bucket of key if there is one.
+func (l *Limiter) Allow(key int64) bool {
+	if l == nil || l.rate <= 0 {
+		return true
+	}
+
+	b, dropped := l.bucket(key)
+	if l.onDrop != nil {
+		for _, k := range dropped {
+			l.onDrop(k)
+		}
+	}
+	return b.Allow()
+}
+
+// bucket returns the bucket of key along with the keys whose buckets were
+// pruned on the way.
+func (l *Limiter) bucket(key int64) (*Bucket, []int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var dropped []int64
+	l.calls++
+	if l.calls%pruneEvery == 0 {
+		for k, b := range l.buckets {
+			if b.full() {
+				delete(l.buckets, k)
+				dropped = append(dropped, k)
+			}
+		}
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = NewBucket(l.rate, l.burst)
+		b.now = l.now
+		l.buckets[key] = b
+	}
+	return b, dropped
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func TestBucket_Allow(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{t: time.Unix(0, 0)}
+	b := NewBucket(2, 3)
+	b.now = c.now
+
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow(), "the burst is spent")
+
+	c.t = c.t.Add(500 * time.Millisecond)
+	assert.True(t, b.Allow(), "one token refilled")
+	assert.False(t, b.Allow())
+
+	c.t = c.t.Add(time.Hour)
+	for range 3 {
+		assert.True(t, b.Allow())
+	}
+	assert.False(t, b.Allow(), "refills stop at the burst")
+
+	var unlimited *Bucket
+	assert.True(t, unlimited.Allow())
+	assert.True(t, NewBucket(0, 1).Allow())
+}
+
+func TestBucket_SetDaily(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{t: time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC)}
+	b := NewBucket(1, 2)
+	b.now = c.now
+	b.SetDaily(3)
+
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+	c.t = c.t.Add(10 * time.Minute)
+	assert.True(t, b.Allow())
+	c.t = c.t.Add(10 * time.Minute)
+	assert.False(t, b.Allow(), "the day's tokens are spent though the bucket refilled")
+
+	c.t = c.t.Add(time.Hour)
+	assert.True(t, b.Allow(), "a new day starts at midnight UTC")
+
+	daily := NewBucket(0, 1)
+	daily.now = c.now
+	daily.SetDaily(1)
+	assert.True(t, daily.Allow())
+	assert.False(t, daily.Allow(), "the daily cap works without a rate")
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{t: time.Unix(0, 0)}
+	l := New(1, 2)
+	l.now = c.now
+	var dropped []int64
+	l.OnDrop(func(key int64) { dropped = append(dropped, key) })
+
+	assert.True(t, l.Allow(1))
+	assert.True(t, l.Allow(1))
+	assert.False(t, l.Allow(1))
+	assert.True(t, l.Allow(2), "users have buckets of their own")
+
+	c.t = c.t.Add(time.Minute)
+	for range pruneEvery {
+		l.Allow(3)
+	}
+	assert.NotContains(t, l.buckets, int64(1), "full buckets are dropped")
+	assert.ElementsMatch(t, []int64{1, 2}, dropped)
+
+	var unlimited *Limiter
+	assert.True(t, unlimited.Allow(1))
+}
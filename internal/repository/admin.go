@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type AdminR struct {
+	db QueryI
+}
+
+func NewAdminRepository(db QueryI) *AdminR {
+	return &AdminR{db: db}
+}
+
+func (a *AdminR) GlobalStats(ctx context.Context, today, week time.Time) (models.GlobalStats, error) {
+	query := `
+		WITH activity AS (
+			SELECT user_id, last_active_at AS at FROM users
+			UNION ALL
+			SELECT user_id, created_at FROM user_quiz_results
+			UNION ALL
+			SELECT user_id, last_seen FROM user_words
+		)
+		SELECT
+			(SELECT COUNT(*) FROM users) AS users,
+			(SELECT COUNT(DISTINCT user_id) FROM activity WHERE at >= $1) AS active_today,
+			(SELECT COUNT(DISTINCT user_id) FROM activity WHERE at >= $2) AS active_week,
+			(SELECT COUNT(*) FROM user_words) AS words_total,
+			(SELECT COUNT(*) FROM user_words WHERE created_at >= $1) AS words_today,
+			(SELECT COUNT(*) FROM user_quiz_results) AS quiz_total,
+			(SELECT COUNT(*) FROM user_quiz_results WHERE created_at >= $1) AS quiz_today,
+			(SELECT COUNT(*) FROM banned_users) AS banned
+	`
+
+	var stats models.GlobalStats
+	if err := a.db.GetContext(ctx, &stats, query, today, week); err != nil {
+		return models.GlobalStats{}, fmt.Errorf("failed to get global stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// UserIDs returns every registered user who isn't banned. Group members who
+// only answered a group quiz never started the bot and get no broadcasts.
+func (a *AdminR) UserIDs(ctx context.Context) ([]int64, error) {
+	query := `
+		SELECT user_id FROM users
+		WHERE user_id NOT IN (SELECT user_id FROM banned_users)
+		ORDER BY user_id
+	`
+
+	ids := make([]int64, 0)
+	if err := a.db.SelectContext(ctx, &ids, query); err != nil {
+		return nil, fmt.Errorf("failed to get user ids: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (a *AdminR) UserInfo(ctx context.Context, userID int64) (models.UserInfo, error) {
+	query := `
+		SELECT
+			$1::bigint AS user_id,
+			COALESCE((SELECT settings->>'language' FROM users WHERE user_id = $1), '') AS language,
+			(SELECT COUNT(*) FROM user_words WHERE user_id = $1) AS words,
+			(SELECT COUNT(*) FROM user_words WHERE user_id = $1 AND known) AS learned,
+			(SELECT COUNT(*) FROM user_quiz_results WHERE user_id = $1) AS quizzes,
+			(SELECT COUNT(*) FROM user_quiz_results WHERE user_id = $1 AND is_correct) AS correct,
+			(SELECT MAX(at) FROM (
+				SELECT last_active_at AS at FROM users WHERE user_id = $1
+				UNION ALL
+				SELECT MAX(created_at) FROM user_quiz_results WHERE user_id = $1
+				UNION ALL
+				SELECT MAX(last_seen) FROM user_words WHERE user_id = $1
+			) AS activity) AS last_active,
+			EXISTS (SELECT 1 FROM wotd_subscriptions WHERE user_id = $1) AS wotd,
+			EXISTS (SELECT 1 FROM banned_users WHERE user_id = $1) AS banned
+	`
+
+	var info models.UserInfo
+	if err := a.db.GetContext(ctx, &info, query, userID); err != nil {
+		return models.UserInfo{}, fmt.Errorf("failed to get info for user %d: %w", userID, err)
+	}
+
+	return info, nil
+}
+
+func (a *AdminR) Ban(ctx context.Context, userID, bannedBy int64, reason string) error {
+	query := `INSERT INTO banned_users (user_id, reason, banned_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			reason = EXCLUDED.reason,
+			banned_by = EXCLUDED.banned_by
+		`
+	_, err := a.db.ExecContext(ctx, query, userID, reason, bannedBy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *AdminR) Unban(ctx context.Context, userID int64) error {
+	query := `DELETE FROM banned_users WHERE user_id = $1`
+	_, err := a.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (a *AdminR) IsBanned(ctx context.Context, userID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM banned_users WHERE user_id = $1)`
+
+	var banned bool
+	if err := a.db.GetContext(ctx, &banned, query, userID); err != nil {
+		return false, fmt.Errorf("failed to check ban for user %d: %w", userID, err)
+	}
+
+	return banned, nil
+}
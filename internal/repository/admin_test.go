@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAdminMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_repository.MockQueryI)) *AdminR {
+	db := mock_repository.NewMockQueryI(ctrl)
+	if setupMock != nil {
+		setupMock(db)
+	}
+
+	return &AdminR{db: db}
+}
+
+func TestAdminR_GlobalStats(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	week := today.AddDate(0, 0, -6)
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    models.GlobalStats
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), today, week).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*models.GlobalStats) = models.GlobalStats{Users: 10, ActiveToday: 2}
+						return nil
+					})
+			},
+			want: models.GlobalStats{Users: 10, ActiveToday: 2},
+		},
+		{
+			name: "error get",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), today, week).Return(errors.New("error get"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newAdminMock(t, ctrl, tt.f)
+
+			got, err := repo.GlobalStats(context.Background(), today, week)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAdminR_UserIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]int64) = []int64{1, 2}
+						return nil
+					})
+			},
+			want: []int64{1, 2},
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newAdminMock(t, ctrl, tt.f)
+
+			got, err := repo.UserIDs(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAdminR_Ban(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(5), "spam", int64(1)).Return(nil, nil)
+			},
+		},
+		{
+			name: "error exec",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(5), "spam", int64(1)).Return(nil, errors.New("error exec"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newAdminMock(t, ctrl, tt.f)
+
+			err := repo.Ban(context.Background(), 5, 1, "spam")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAdminR_IsBanned(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "banned",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(5)).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*bool) = true
+						return nil
+					})
+			},
+			want: true,
+		},
+		{
+			name: "error get",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(5)).Return(errors.New("error get"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newAdminMock(t, ctrl, tt.f)
+
+			got, err := repo.IsBanned(context.Background(), 5)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type AudioR struct {
+	db QueryI
+}
+
+func NewAudioRepository(db QueryI) *AudioR {
+	return &AudioR{db: db}
+}
+
+func (a *AudioR) AudioFileID(ctx context.Context, kind, word string) (string, bool, error) {
+	query := `SELECT file_id FROM audio_files WHERE word = $1 AND kind = $2`
+
+	var fileID string
+	err := a.db.GetContext(ctx, &fileID, query, word, kind)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("database error: %w", err)
+	}
+
+	return fileID, true, nil
+}
+
+func (a *AudioR) SaveAudioFileID(ctx context.Context, kind, word, fileID string) error {
+	query := `INSERT INTO audio_files (word, kind, file_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (word, kind)
+		DO UPDATE SET
+			file_id = EXCLUDED.file_id,
+			created_at = NOW()
+		`
+	_, err := a.db.ExecContext(ctx, query, word, kind, fileID)
+	return err
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudioR_AudioFileID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		f         func(*mock_repository.MockQueryI)
+		want      string
+		wantFound bool
+		wantErr   bool
+	}{
+		{
+			name: "found",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), "apple", models.AudioCard).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*string) = "file"
+						return nil
+					})
+			},
+			want:      "file",
+			wantFound: true,
+		},
+		{
+			name: "not found",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), "apple", models.AudioCard).Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "db error",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), "apple", models.AudioCard).Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db := mock_repository.NewMockQueryI(ctrl)
+			tt.f(db)
+
+			got, found, err := NewAudioRepository(db).AudioFileID(context.Background(), models.AudioCard, "apple")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAudioR_SaveAudioFileID(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := mock_repository.NewMockQueryI(ctrl)
+	db.EXPECT().ExecContext(gomock.Any(), gomock.Any(), "apple", models.AudioCard, "file").Return(nil, nil)
+
+	require.NoError(t, NewAudioRepository(db).SaveAudioFileID(context.Background(), models.AudioCard, "apple", "file"))
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/lib/pq"
+)
+
+type GameR struct {
+	db QueryI
+}
+
+func NewGameRepository(db QueryI) *GameR {
+	return &GameR{
+		db: db,
+	}
+}
+
+// AddXP records the XP event and returns the user's total including it.
+func (g *GameR) AddXP(ctx context.Context, userID int64, activity models.Activity, xp int) (int, error) {
+	query := `WITH event AS (
+			INSERT INTO xp_events (user_id, activity, xp) VALUES ($1, $2, $3)
+		)
+		INSERT INTO user_xp (user_id, xp)
+		VALUES ($1, $3)
+		ON CONFLICT (user_id) DO UPDATE SET xp = user_xp.xp + EXCLUDED.xp
+		RETURNING xp
+		`
+
+	var total int
+	if err := g.db.GetContext(ctx, &total, query, userID, activity, xp); err != nil {
+		return 0, fmt.Errorf("failed to add xp for user %d: %w", userID, err)
+	}
+
+	return total, nil
+}
+
+func (g *GameR) TotalXP(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COALESCE((SELECT xp FROM user_xp WHERE user_id = $1), 0)`
+
+	var xp int
+	if err := g.db.GetContext(ctx, &xp, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to get xp for user %d: %w", userID, err)
+	}
+
+	return xp, nil
+}
+
+// ActivitiesSince counts the XP events of the given activities since the
+// given moment.
+func (g *GameR) ActivitiesSince(ctx context.Context, userID int64, since time.Time, activities []models.Activity) (int, error) {
+	query := `SELECT COUNT(*) FROM xp_events WHERE user_id = $1 AND created_at >= $2 AND activity = ANY($3)`
+
+	names := make([]string, len(activities))
+	for i, a := range activities {
+		names[i] = string(a)
+	}
+
+	var count int
+	if err := g.db.GetContext(ctx, &count, query, userID, since, pq.Array(names)); err != nil {
+		return 0, fmt.Errorf("failed to count activities for user %d: %w", userID, err)
+	}
+
+	return count, nil
+}
+
+// NewWordsSince counts the words the user added to their list since the given
+// moment. Words saved by quiz answers or a text import don't count.
+func (g *GameR) NewWordsSince(ctx context.Context, userID int64, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM user_words WHERE user_id = $1 AND chosen_at >= $2`
+
+	var count int
+	if err := g.db.GetContext(ctx, &count, query, userID, since); err != nil {
+		return 0, fmt.Errorf("failed to count new words for user %d: %w", userID, err)
+	}
+
+	return count, nil
+}
+
+// CompleteGoal records the daily goal as reached, it reports false if it
+// already was that day.
+func (g *GameR) CompleteGoal(ctx context.Context, userID int64, day time.Time, target int) (bool, error) {
+	query := `INSERT INTO daily_goals (user_id, day, target)
+		VALUES ($1, $2::date, $3)
+		ON CONFLICT (user_id, day) DO NOTHING
+		RETURNING true
+		`
+
+	var inserted bool
+	err := g.db.GetContext(ctx, &inserted, query, userID, day, target)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to complete goal for user %d: %w", userID, err)
+	}
+
+	return inserted, nil
+}
+
+func (g *GameR) GameStats(ctx context.Context, userID int64) (models.GameStats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM user_words WHERE user_id = $1 AND known) AS learned,
+			(SELECT COUNT(*) FROM user_quiz_results WHERE user_id = $1) AS quiz_answers,
+			(SELECT COUNT(*) FROM daily_goals WHERE user_id = $1) AS goals_reached
+	`
+
+	var stats models.GameStats
+	if err := g.db.GetContext(ctx, &stats, query, userID); err != nil {
+		return models.GameStats{}, fmt.Errorf("failed to get game stats for user %d: %w", userID, err)
+	}
+
+	return stats, nil
+}
+
+func (g *GameR) Achievements(ctx context.Context, userID int64) ([]models.UserAchievement, error) {
+	query := `
+		SELECT achievement, unlocked_at
+		FROM user_achievements
+		WHERE user_id = $1
+		ORDER BY unlocked_at, achievement
+	`
+
+	achievements := make([]models.UserAchievement, 0)
+	if err := g.db.SelectContext(ctx, &achievements, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get achievements for user %d: %w", userID, err)
+	}
+
+	return achievements, nil
+}
+
+// UnlockAchievement reports false if the user already had the achievement.
+func (g *GameR) UnlockAchievement(ctx context.Context, userID int64, code string) (bool, error) {
+	query := `INSERT INTO user_achievements (user_id, achievement)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, achievement) DO NOTHING
+		RETURNING true
+		`
+
+	var inserted bool
+	err := g.db.GetContext(ctx, &inserted, query, userID, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to unlock %s for user %d: %w", code, userID, err)
+	}
+
+	return inserted, nil
+}
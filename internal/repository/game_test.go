@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGameMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_repository.MockQueryI)) *GameR {
+	db := mock_repository.NewMockQueryI(ctrl)
+	if setupMock != nil {
+		setupMock(db)
+	}
+
+	return &GameR{db: db}
+}
+
+func TestGameR_AddXP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), models.ActivityQuizRight, 10).
+					DoAndReturn(func(_ context.Context, dest any, _ string, _ ...any) error {
+						*dest.(*int) = 110
+						return nil
+					})
+			},
+		},
+		{
+			name: "error exec",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), models.ActivityQuizRight, 10).Return(errors.New("error exec"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newGameMock(t, ctrl, tt.f)
+
+			total, err := repo.AddXP(context.Background(), 1, models.ActivityQuizRight, 10)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 110, total)
+		})
+	}
+}
+
+func TestGameR_CompleteGoal(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "first time today",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), day, 10).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*bool) = true
+						return nil
+					})
+			},
+			want: true,
+		},
+		{
+			name: "already reached",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), day, 10).Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "error get",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), day, 10).Return(errors.New("error get"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newGameMock(t, ctrl, tt.f)
+
+			got, err := repo.CompleteGoal(context.Background(), 1, day, 10)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGameR_UnlockAchievement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "unlocked",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "streak_7").
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*bool) = true
+						return nil
+					})
+			},
+			want: true,
+		},
+		{
+			name: "already unlocked",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "streak_7").Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "error get",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "streak_7").Return(errors.New("error get"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newGameMock(t, ctrl, tt.f)
+
+			got, err := repo.UnlockAchievement(context.Background(), 1, "streak_7")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
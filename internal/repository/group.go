@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type GroupR struct {
+	db QueryI
+}
+
+func NewGroupRepository(db QueryI) *GroupR {
+	return &GroupR{
+		db: db,
+	}
+}
+
+func (g *GroupR) AddGroupResult(ctx context.Context, result models.QuizCard) error {
+	query := `
+		INSERT INTO group_quiz_results (chat_id, user_id, word, translation, is_correct)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := g.db.ExecContext(ctx, query, result.ChatID, result.UserID, result.Word, result.Translation, result.IsCorrect)
+	return err
+}
+
+// Leaderboard ranks the members of a chat by group quizzes won since the
+// given moment. A group quiz has one correct answer, the winning one.
+func (g *GroupR) Leaderboard(ctx context.Context, chatID int64, since time.Time, limit int) ([]models.LeaderboardEntry, error) {
+	query := `
+		SELECT
+			r.user_id,
+			COALESCE(u.username, '') AS username,
+			COALESCE(u.first_name, '') AS first_name,
+			COUNT(*) FILTER (WHERE r.is_correct) AS points,
+			COUNT(*) AS answers
+		FROM group_quiz_results r
+		LEFT JOIN users u ON u.user_id = r.user_id
+		WHERE r.chat_id = $1 AND r.created_at >= $2
+		GROUP BY r.user_id, u.username, u.first_name
+		HAVING COUNT(*) FILTER (WHERE r.is_correct) > 0
+		ORDER BY points DESC, answers, r.user_id
+		LIMIT $3
+	`
+
+	entries := make([]models.LeaderboardEntry, 0)
+	if err := g.db.SelectContext(ctx, &entries, query, chatID, since, limit); err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard for chat %d: %w", chatID, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupR_AddGroupResult(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := mock_repository.NewMockQueryI(ctrl)
+	db.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(-100), int64(2), "hello", "привет", true).Return(nil, nil)
+	repo := &GroupR{db: db}
+
+	require.NoError(t, repo.AddGroupResult(context.Background(), models.QuizCard{UserID: 2, ChatID: -100, Word: "hello", Translation: "привет", IsCorrect: true}))
+}
+
+func TestGroupR_Leaderboard(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	entries := []models.LeaderboardEntry{{UserID: 1, FirstName: "Ann", Points: 2, Answers: 3}}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.LeaderboardEntry
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(-100), since, 10).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.LeaderboardEntry) = entries
+						return nil
+					})
+			},
+			want: entries,
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(-100), since, 10).
+					Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db := mock_repository.NewMockQueryI(ctrl)
+			tt.f(db)
+			repo := &GroupR{db: db}
+
+			got, err := repo.Leaderboard(context.Background(), -100, since, 10)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/DanRulev/vocabot.git/internal/models"
 )
@@ -18,11 +19,11 @@ func NewQuizRepository(db QueryI) *QuizR {
 
 func (q *QuizR) AddQuizResult(ctx context.Context, result models.QuizCard) error {
 	query := `
-        INSERT INTO user_quiz_results (user_id, word, translation, type, is_correct)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO user_quiz_results (user_id, chat_id, word, translation, type, is_correct)
+        VALUES ($1, $2, $3, $4, $5, $6)
     `
 
-	_, err := q.db.ExecContext(ctx, query, result.UserID, result.Word, result.Translation, result.Type, result.IsCorrect)
+	_, err := q.db.ExecContext(ctx, query, result.UserID, result.ChatID, result.Word, result.Translation, result.Type, result.IsCorrect)
 	if err != nil {
 		return err
 	}
@@ -47,3 +48,31 @@ func (q *QuizR) QuizStats(ctx context.Context, userID int64) (models.QuizStats,
 
 	return stats, nil
 }
+
+func (q *QuizR) HardWords(ctx context.Context, userID int64) ([]models.HardWord, error) {
+	query := `
+		SELECT
+			word,
+			MAX(translation) AS translation,
+			COUNT(*) AS attempts,
+			COUNT(*) FILTER (WHERE NOT is_correct) AS misses,
+			COUNT(*) FILTER (WHERE created_at > last_miss) AS streak,
+			MAX(last_miss) AS last_miss
+		FROM (
+			SELECT
+				word, translation, is_correct, created_at,
+				MAX(created_at) FILTER (WHERE NOT is_correct) OVER (PARTITION BY word) AS last_miss
+			FROM user_quiz_results
+			WHERE user_id = $1
+		) AS answers
+		WHERE last_miss IS NOT NULL
+		GROUP BY word
+	`
+
+	words := make([]models.HardWord, 0)
+	if err := q.db.SelectContext(ctx, &words, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get hard words for user %d: %w", userID, err)
+	}
+
+	return words, nil
+}
@@ -142,3 +142,54 @@ func TestQuizR_QuizStats(t *testing.T) {
 		})
 	}
 }
+
+func TestQuizR_HardWords(t *testing.T) {
+	t.Parallel()
+
+	words := []models.HardWord{{Word: "apple", Translation: "яблоко", Attempts: 3, Misses: 2, Streak: 1}}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.HardWord
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.HardWord) = words
+						return nil
+					})
+			},
+			want: words,
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newQuizMock(t, ctrl, tt.f)
+
+			got, err := repo.HardWords(context.Background(), 1)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -14,11 +14,27 @@ type QueryI interface {
 type Repository struct {
 	*WordsR
 	*QuizR
+	*SettingsR
+	*StatsR
+	*WotdR
+	*AdminR
+	*UsersR
+	*GameR
+	*GroupR
+	*AudioR
 }
 
 func NewRepository(db QueryI) Repository {
 	return Repository{
-		WordsR: NewWordsRepository(db),
-		QuizR:  NewQuizRepository(db),
+		WordsR:    NewWordsRepository(db),
+		QuizR:     NewQuizRepository(db),
+		SettingsR: NewSettingsRepository(db),
+		StatsR:    NewStatsRepository(db),
+		WotdR:     NewWotdRepository(db),
+		AdminR:    NewAdminRepository(db),
+		UsersR:    NewUsersRepository(db),
+		GameR:     NewGameRepository(db),
+		GroupR:    NewGroupRepository(db),
+		AudioR:    NewAudioRepository(db),
 	}
 }
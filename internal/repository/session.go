@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type SessionR struct {
+	db  QueryI
+	ttl time.Duration
+}
+
+func NewSessionRepository(db QueryI, ttl time.Duration) *SessionR {
+	return &SessionR{
+		db:  db,
+		ttl: ttl,
+	}
+}
+
+func (s *SessionR) set(ctx context.Context, kind string, key models.SessionKey, value any) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s session: %w", kind, err)
+	}
+
+	query := `INSERT INTO bot_sessions (chat_id, message_id, kind, payload, expires_at)
+		VALUES ($1, $2, $3, $4, NOW() + make_interval(secs => $5))
+		ON CONFLICT (chat_id, message_id, kind)
+		DO UPDATE SET
+			payload = EXCLUDED.payload,
+			expires_at = EXCLUDED.expires_at
+		`
+	_, err = s.db.ExecContext(ctx, query, key.ChatID, key.MessageID, kind, payload, s.ttl.Seconds())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *SessionR) get(ctx context.Context, kind string, key models.SessionKey, dest any) (bool, error) {
+	query := `
+		SELECT payload
+		FROM bot_sessions
+		WHERE chat_id = $1 AND message_id = $2 AND kind = $3 AND expires_at > NOW()
+	`
+
+	return s.load(ctx, query, kind, key, dest)
+}
+
+// take reads and removes the session in one statement, so of two concurrent
+// answers to the same message only one gets it.
+func (s *SessionR) take(ctx context.Context, kind string, key models.SessionKey, dest any) (bool, error) {
+	query := `
+		DELETE FROM bot_sessions
+		WHERE chat_id = $1 AND message_id = $2 AND kind = $3 AND expires_at > NOW()
+		RETURNING payload
+	`
+
+	return s.load(ctx, query, kind, key, dest)
+}
+
+func (s *SessionR) load(ctx context.Context, query, kind string, key models.SessionKey, dest any) (bool, error) {
+	var payload []byte
+	err := s.db.GetContext(ctx, &payload, query, key.ChatID, key.MessageID, kind)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("database error: %w", err)
+	}
+
+	if err := json.Unmarshal(payload, dest); err != nil {
+		return false, fmt.Errorf("failed to unmarshal %s session: %w", kind, err)
+	}
+
+	return true, nil
+}
+
+func (s *SessionR) delete(ctx context.Context, kind string, key models.SessionKey) error {
+	query := `DELETE FROM bot_sessions WHERE chat_id = $1 AND message_id = $2 AND kind = $3`
+	_, err := s.db.ExecContext(ctx, query, key.ChatID, key.MessageID, kind)
+	return err
+}
+
+func (s *SessionR) DeleteExpired(ctx context.Context) error {
+	query := `DELETE FROM bot_sessions WHERE expires_at <= NOW()`
+	if _, err := s.db.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	query = `DELETE FROM poll_sessions WHERE expires_at <= NOW()`
+	_, err := s.db.ExecContext(ctx, query)
+	return err
+}
+
+func (s *SessionR) SetWord(ctx context.Context, key models.SessionKey, word models.WordCard) error {
+	return s.set(ctx, models.SessionWord, key, word)
+}
+
+func (s *SessionR) GetWord(ctx context.Context, key models.SessionKey) (models.WordCard, bool, error) {
+	var word models.WordCard
+	exists, err := s.get(ctx, models.SessionWord, key, &word)
+	return word, exists, err
+}
+
+func (s *SessionR) TakeWord(ctx context.Context, key models.SessionKey) (models.WordCard, bool, error) {
+	var word models.WordCard
+	exists, err := s.take(ctx, models.SessionWord, key, &word)
+	return word, exists, err
+}
+
+func (s *SessionR) SetQuiz(ctx context.Context, key models.SessionKey, quiz models.QuizCard) error {
+	return s.set(ctx, models.SessionQuiz, key, quiz)
+}
+
+func (s *SessionR) GetQuiz(ctx context.Context, key models.SessionKey) (models.QuizCard, bool, error) {
+	var quiz models.QuizCard
+	exists, err := s.get(ctx, models.SessionQuiz, key, &quiz)
+	return quiz, exists, err
+}
+
+func (s *SessionR) TakeQuiz(ctx context.Context, key models.SessionKey) (models.QuizCard, bool, error) {
+	var quiz models.QuizCard
+	exists, err := s.take(ctx, models.SessionQuiz, key, &quiz)
+	return quiz, exists, err
+}
+
+func (s *SessionR) SetChecklist(ctx context.Context, key models.SessionKey, list models.WordChecklist) error {
+	return s.set(ctx, models.SessionChecklist, key, list)
+}
+
+func (s *SessionR) GetChecklist(ctx context.Context, key models.SessionKey) (models.WordChecklist, bool, error) {
+	var list models.WordChecklist
+	exists, err := s.get(ctx, models.SessionChecklist, key, &list)
+	return list, exists, err
+}
+
+func (s *SessionR) DeleteChecklist(ctx context.Context, key models.SessionKey) error {
+	return s.delete(ctx, models.SessionChecklist, key)
+}
+
+func (s *SessionR) SetPoll(ctx context.Context, pollID string, key models.SessionKey) error {
+	query := `INSERT INTO poll_sessions (poll_id, chat_id, message_id, expires_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+		ON CONFLICT (poll_id)
+		DO UPDATE SET
+			chat_id = EXCLUDED.chat_id,
+			message_id = EXCLUDED.message_id,
+			expires_at = EXCLUDED.expires_at
+		`
+	_, err := s.db.ExecContext(ctx, query, pollID, key.ChatID, key.MessageID, s.ttl.Seconds())
+	return err
+}
+
+func (s *SessionR) GetPoll(ctx context.Context, pollID string) (models.SessionKey, bool, error) {
+	query := `
+		SELECT chat_id, message_id
+		FROM poll_sessions
+		WHERE poll_id = $1 AND expires_at > NOW()
+	`
+
+	var key models.SessionKey
+	err := s.db.GetContext(ctx, &key, query, pollID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.SessionKey{}, false, nil
+		}
+		return models.SessionKey{}, false, fmt.Errorf("database error: %w", err)
+	}
+
+	return key, true, nil
+}
+
+func (s *SessionR) DeletePoll(ctx context.Context, pollID string) error {
+	query := `DELETE FROM poll_sessions WHERE poll_id = $1`
+	_, err := s.db.ExecContext(ctx, query, pollID)
+	return err
+}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_repository.MockQueryI)) *SessionR {
+	db := mock_repository.NewMockQueryI(ctrl)
+	if setupMock != nil {
+		setupMock(db)
+	}
+
+	return &SessionR{db: db, ttl: time.Hour}
+}
+
+func TestSessionR_SetWord(t *testing.T) {
+	t.Parallel()
+
+	key := models.SessionKey{ChatID: 1, MessageID: 10}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), 10, models.SessionWord, gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error exec",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error exec"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newSessionMock(t, ctrl, tt.f)
+
+			err := repo.SetWord(context.Background(), key, models.WordCard{WordText: "hello"})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSessionR_GetQuiz(t *testing.T) {
+	t.Parallel()
+
+	key := models.SessionKey{ChatID: 1, MessageID: 10}
+
+	tests := []struct {
+		name       string
+		f          func(*mock_repository.MockQueryI)
+		want       models.QuizCard
+		wantExists bool
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), 10, models.SessionQuiz).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]byte) = []byte(`{"UserID":2,"Word":"hello","Translation":"привет","Type":"quiz"}`)
+						return nil
+					})
+			},
+			want:       models.QuizCard{UserID: 2, Word: "hello", Translation: "привет", Type: "quiz"},
+			wantExists: true,
+		},
+		{
+			name: "no rows",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+			},
+			want:       models.QuizCard{},
+			wantExists: false,
+		},
+		{
+			name: "invalid payload",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]byte) = []byte(`{`)
+						return nil
+					})
+			},
+			wantErr: true,
+		},
+		{
+			name: "db error",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newSessionMock(t, ctrl, tt.f)
+
+			got, exists, err := repo.GetQuiz(context.Background(), key)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExists, exists)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSessionR_TakeWord(t *testing.T) {
+	t.Parallel()
+
+	key := models.SessionKey{ChatID: 1, MessageID: 10}
+
+	tests := []struct {
+		name       string
+		f          func(*mock_repository.MockQueryI)
+		want       models.WordCard
+		wantExists bool
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), 10, models.SessionWord).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						assert.Contains(t, query, "RETURNING payload")
+						*dest.(*[]byte) = []byte(`{"UserID":2,"WordText":"hello"}`)
+						return nil
+					})
+			},
+			want:       models.WordCard{UserID: 2, WordText: "hello"},
+			wantExists: true,
+		},
+		{
+			name: "already taken",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+			},
+			want:       models.WordCard{},
+			wantExists: false,
+		},
+		{
+			name: "db error",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newSessionMock(t, ctrl, tt.f)
+
+			got, exists, err := repo.TakeWord(context.Background(), key)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExists, exists)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSessionR_DeleteExpired(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := newSessionMock(t, ctrl, func(mqi *mock_repository.MockQueryI) {
+		mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	})
+
+	require.NoError(t, repo.DeleteExpired(context.Background()))
+}
+
+func TestSessionR_GetPoll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		f          func(*mock_repository.MockQueryI)
+		want       models.SessionKey
+		wantExists bool
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), "poll").
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*models.SessionKey) = models.SessionKey{ChatID: 1, MessageID: 10}
+						return nil
+					})
+			},
+			want:       models.SessionKey{ChatID: 1, MessageID: 10},
+			wantExists: true,
+		},
+		{
+			name: "no rows",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+			},
+			wantExists: false,
+		},
+		{
+			name: "db error",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newSessionMock(t, ctrl, tt.f)
+
+			got, exists, err := repo.GetPoll(context.Background(), "poll")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExists, exists)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type SettingsR struct {
+	db QueryI
+}
+
+func NewSettingsRepository(db QueryI) *SettingsR {
+	return &SettingsR{
+		db: db,
+	}
+}
+
+func (s *SettingsR) Language(ctx context.Context, userID int64) (string, error) {
+	query := `SELECT COALESCE(settings->>'language', '') FROM users WHERE user_id = $1`
+
+	var lang string
+	err := s.db.GetContext(ctx, &lang, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("database error: %w", err)
+	}
+
+	return lang, nil
+}
+
+func (s *SettingsR) SetLanguage(ctx context.Context, userID int64, lang string) error {
+	query := `INSERT INTO users (user_id, settings)
+		VALUES ($1, jsonb_build_object('language', $2::text))
+		ON CONFLICT (user_id)
+		DO UPDATE SET settings = users.settings || EXCLUDED.settings
+		`
+	_, err := s.db.ExecContext(ctx, query, userID, lang)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
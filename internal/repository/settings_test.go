@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSettingsMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_repository.MockQueryI)) *SettingsR {
+	db := mock_repository.NewMockQueryI(ctrl)
+	if setupMock != nil {
+		setupMock(db)
+	}
+
+	return &SettingsR{db: db}
+}
+
+func TestSettingsR_Language(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*string) = "en"
+						return nil
+					})
+			},
+			want: "en",
+		},
+		{
+			name: "not set",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).Return(sql.ErrNoRows)
+			},
+			want: "",
+		},
+		{
+			name: "error get",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).Return(errors.New("error get"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newSettingsMock(t, ctrl, tt.f)
+
+			got, err := repo.Language(context.Background(), 1)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSettingsR_SetLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), "en").Return(nil, nil)
+			},
+		},
+		{
+			name: "error exec",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), "en").Return(nil, errors.New("error exec"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newSettingsMock(t, ctrl, tt.f)
+
+			err := repo.SetLanguage(context.Background(), 1, "en")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type StatsR struct {
+	db QueryI
+}
+
+func NewStatsRepository(db QueryI) *StatsR {
+	return &StatsR{db: db}
+}
+
+// ActivityDays returns the distinct days the user answered a quiz or
+// marked a word, newest first.
+func (s *StatsR) ActivityDays(ctx context.Context, userID int64) ([]time.Time, error) {
+	query := `
+		SELECT day FROM (
+			SELECT created_at::date AS day FROM user_quiz_results WHERE user_id = $1
+			UNION
+			SELECT created_at::date FROM user_words WHERE user_id = $1
+			UNION
+			SELECT last_seen::date FROM user_words WHERE user_id = $1 AND last_seen IS NOT NULL
+		) AS activity
+		ORDER BY day DESC
+	`
+
+	days := make([]time.Time, 0)
+	if err := s.db.SelectContext(ctx, &days, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get activity days for user %d: %w", userID, err)
+	}
+
+	return days, nil
+}
+
+func (s *StatsR) LearnedWords(ctx context.Context, userID int64, since time.Time, bucket string) ([]models.DayCount, error) {
+	query := `
+		SELECT date_trunc($3::text, learned_at) AS day, COUNT(*) AS count
+		FROM user_words
+		WHERE user_id = $1 AND known AND learned_at >= $2
+		GROUP BY 1
+		ORDER BY 1
+	`
+
+	counts := make([]models.DayCount, 0)
+	if err := s.db.SelectContext(ctx, &counts, query, userID, since, bucket); err != nil {
+		return nil, fmt.Errorf("failed to get learned words for user %d: %w", userID, err)
+	}
+
+	return counts, nil
+}
+
+func (s *StatsR) QuizAccuracy(ctx context.Context, userID int64, since time.Time, bucket string) ([]models.DayAccuracy, error) {
+	query := `
+		SELECT
+			date_trunc($3::text, created_at) AS day,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE is_correct) AS correct
+		FROM user_quiz_results
+		WHERE user_id = $1 AND created_at >= $2
+		GROUP BY 1
+		ORDER BY 1
+	`
+
+	accuracy := make([]models.DayAccuracy, 0)
+	if err := s.db.SelectContext(ctx, &accuracy, query, userID, since, bucket); err != nil {
+		return nil, fmt.Errorf("failed to get quiz accuracy for user %d: %w", userID, err)
+	}
+
+	return accuracy, nil
+}
+
+func (s *StatsR) QuizAccuracyByType(ctx context.Context, userID int64, since time.Time) ([]models.TypeAccuracy, error) {
+	query := `
+		SELECT
+			type,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE is_correct) AS correct
+		FROM user_quiz_results
+		WHERE user_id = $1 AND created_at >= $2
+		GROUP BY type
+		ORDER BY total DESC, type
+	`
+
+	accuracy := make([]models.TypeAccuracy, 0)
+	if err := s.db.SelectContext(ctx, &accuracy, query, userID, since); err != nil {
+		return nil, fmt.Errorf("failed to get quiz accuracy by type for user %d: %w", userID, err)
+	}
+
+	return accuracy, nil
+}
+
+func (s *StatsR) MostMissedWords(ctx context.Context, userID int64, since time.Time, limit int) ([]models.MissedWord, error) {
+	query := `
+		SELECT
+			word,
+			MAX(translation) AS translation,
+			COUNT(*) FILTER (WHERE NOT is_correct) AS misses,
+			COUNT(*) AS attempts
+		FROM user_quiz_results
+		WHERE user_id = $1 AND created_at >= $2
+		GROUP BY word
+		HAVING COUNT(*) FILTER (WHERE NOT is_correct) > 0
+		ORDER BY misses DESC, attempts, word
+		LIMIT $3
+	`
+
+	words := make([]models.MissedWord, 0, limit)
+	if err := s.db.SelectContext(ctx, &words, query, userID, since, limit); err != nil {
+		return nil, fmt.Errorf("failed to get most missed words for user %d: %w", userID, err)
+	}
+
+	return words, nil
+}
+
+// TimeToLearn averages the time between a word being added and marked as
+// learned, over words learned since the given time.
+func (s *StatsR) TimeToLearn(ctx context.Context, userID int64, since time.Time) (models.LearnTime, error) {
+	query := `
+		SELECT
+			COUNT(*) AS count,
+			COALESCE(AVG(EXTRACT(EPOCH FROM learned_at - created_at)), 0)::float8 AS avg_seconds
+		FROM user_words
+		WHERE user_id = $1 AND learned_at IS NOT NULL AND learned_at >= $2
+	`
+
+	var learnTime models.LearnTime
+	if err := s.db.GetContext(ctx, &learnTime, query, userID, since); err != nil {
+		return models.LearnTime{}, fmt.Errorf("failed to get time to learn for user %d: %w", userID, err)
+	}
+
+	return learnTime, nil
+}
+
+// ActivityCounts returns how many quiz answers and word cards the user had
+// per day since the given time.
+func (s *StatsR) ActivityCounts(ctx context.Context, userID int64, since time.Time) ([]models.DayCount, error) {
+	query := `
+		SELECT day, SUM(count)::int AS count FROM (
+			SELECT created_at::date AS day, COUNT(*) AS count
+			FROM user_quiz_results
+			WHERE user_id = $1 AND created_at >= $2
+			GROUP BY 1
+			UNION ALL
+			SELECT last_seen::date, COUNT(*)
+			FROM user_words
+			WHERE user_id = $1 AND last_seen >= $2
+			GROUP BY 1
+		) AS activity
+		GROUP BY day
+		ORDER BY day
+	`
+
+	counts := make([]models.DayCount, 0)
+	if err := s.db.SelectContext(ctx, &counts, query, userID, since); err != nil {
+		return nil, fmt.Errorf("failed to get activity counts for user %d: %w", userID, err)
+	}
+
+	return counts, nil
+}
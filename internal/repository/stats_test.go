@@ -0,0 +1,372 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatsMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_repository.MockQueryI)) *StatsR {
+	db := mock_repository.NewMockQueryI(ctrl)
+	if setupMock != nil {
+		setupMock(db)
+	}
+
+	return &StatsR{db: db}
+}
+
+var statsSince = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestStatsR_ActivityDays(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []time.Time
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]time.Time) = []time.Time{day}
+						return nil
+					})
+			},
+			want: []time.Time{day},
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newStatsMock(t, ctrl, tt.f)
+
+			got, err := repo.ActivityDays(context.Background(), 1)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStatsR_LearnedWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.DayCount
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince, "day").
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.DayCount) = []models.DayCount{{Day: statsSince, Count: 3}}
+						return nil
+					})
+			},
+			want: []models.DayCount{{Day: statsSince, Count: 3}},
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince, "day").Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newStatsMock(t, ctrl, tt.f)
+
+			got, err := repo.LearnedWords(context.Background(), 1, statsSince, "day")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStatsR_QuizAccuracy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.DayAccuracy
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince, "week").
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.DayAccuracy) = []models.DayAccuracy{{Day: statsSince, Total: 4, Correct: 3}}
+						return nil
+					})
+			},
+			want: []models.DayAccuracy{{Day: statsSince, Total: 4, Correct: 3}},
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince, "week").Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newStatsMock(t, ctrl, tt.f)
+
+			got, err := repo.QuizAccuracy(context.Background(), 1, statsSince, "week")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStatsR_QuizAccuracyByType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.TypeAccuracy
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.TypeAccuracy) = []models.TypeAccuracy{{Type: "quiz", Total: 4, Correct: 3}}
+						return nil
+					})
+			},
+			want: []models.TypeAccuracy{{Type: "quiz", Total: 4, Correct: 3}},
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newStatsMock(t, ctrl, tt.f)
+
+			got, err := repo.QuizAccuracyByType(context.Background(), 1, statsSince)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStatsR_MostMissedWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.MissedWord
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince, 5).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.MissedWord) = []models.MissedWord{{Word: "apple", Translation: "яблоко", Misses: 2, Attempts: 3}}
+						return nil
+					})
+			},
+			want: []models.MissedWord{{Word: "apple", Translation: "яблоко", Misses: 2, Attempts: 3}},
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince, 5).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newStatsMock(t, ctrl, tt.f)
+
+			got, err := repo.MostMissedWords(context.Background(), 1, statsSince, 5)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStatsR_TimeToLearn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    models.LearnTime
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*models.LearnTime) = models.LearnTime{Count: 2, AvgSeconds: 3600}
+						return nil
+					})
+			},
+			want: models.LearnTime{Count: 2, AvgSeconds: 3600},
+		},
+		{
+			name: "error get",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince).Return(errors.New("error get"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newStatsMock(t, ctrl, tt.f)
+
+			got, err := repo.TimeToLearn(context.Background(), 1, statsSince)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStatsR_ActivityCounts(t *testing.T) {
+	t.Parallel()
+
+	counts := []models.DayCount{{Day: statsSince, Count: 5}}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.DayCount
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.DayCount) = counts
+						return nil
+					})
+			},
+			want: counts,
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), statsSince).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newStatsMock(t, ctrl, tt.f)
+
+			got, err := repo.ActivityCounts(context.Background(), 1, statsSince)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,9 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/lib/pq"
 )
 
 type WordsR struct {
@@ -16,18 +20,49 @@ func NewWordsRepository(db QueryI) *WordsR {
 	return &WordsR{db: db}
 }
 
+// wordRow is a user_words row with the details still encoded.
+type wordRow struct {
+	models.WordCard
+	Details []byte `db:"details"`
+}
+
+func (r wordRow) card() (models.WordCard, error) {
+	card := r.WordCard
+	if r.Details != nil {
+		card.Details = &models.WordDetails{}
+		if err := json.Unmarshal(r.Details, card.Details); err != nil {
+			return models.WordCard{}, fmt.Errorf("failed to decode details of word %q: %w", card.WordText, err)
+		}
+	}
+	return card, nil
+}
+
+// AddWord saves the word with its details if it has them. A word saved again
+// without details, like from a quiz, keeps the ones it has. The first time the
+// user chooses the word is kept in chosen_at.
 func (w *WordsR) AddWord(ctx context.Context, word models.WordCard) error {
-	query := `INSERT INTO user_words (user_id, word_text, translation, known, last_seen)
-		VALUES ($1, $2, $3, $4, NOW())
+	var details []byte
+	if word.Details != nil {
+		var err error
+		if details, err = json.Marshal(word.Details); err != nil {
+			return fmt.Errorf("failed to encode details of word %q: %w", word.WordText, err)
+		}
+	}
+
+	query := `INSERT INTO user_words (user_id, word_text, translation, known, last_seen, learned_at, details, chosen_at)
+		VALUES ($1, $2, $3, $4, NOW(), CASE WHEN $4::boolean THEN NOW() END, $5, CASE WHEN $6::boolean THEN NOW() END)
 		ON CONFLICT (user_id, word_text)
 		DO UPDATE SET
 			known = CASE 
 				WHEN EXCLUDED.known THEN true  
 				ELSE user_words.known            
 			END,
+			learned_at = COALESCE(user_words.learned_at, EXCLUDED.learned_at),
+			details = COALESCE(EXCLUDED.details, user_words.details),
+			chosen_at = COALESCE(user_words.chosen_at, EXCLUDED.chosen_at),
 			last_seen = NOW()
 		`
-	_, err := w.db.ExecContext(ctx, query, word.UserID, word.WordText, word.Translation, word.Known)
+	_, err := w.db.ExecContext(ctx, query, word.UserID, word.WordText, word.Translation, word.Known, details, word.Chosen)
 	if err != nil {
 		return err
 	}
@@ -35,6 +70,29 @@ func (w *WordsR) AddWord(ctx context.Context, word models.WordCard) error {
 	return nil
 }
 
+// Word returns one of the user's words with its details.
+func (w *WordsR) Word(ctx context.Context, userID int64, word string) (models.WordCard, bool, error) {
+	query := `
+		SELECT user_id, word_text, translation, last_seen, known, details
+		FROM user_words
+		WHERE user_id = $1 AND word_text = $2
+	`
+
+	var row wordRow
+	if err := w.db.GetContext(ctx, &row, query, userID, word); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WordCard{}, false, nil
+		}
+		return models.WordCard{}, false, fmt.Errorf("failed to get word %q of user %d: %w", word, userID, err)
+	}
+
+	card, err := row.card()
+	if err != nil {
+		return models.WordCard{}, false, err
+	}
+	return card, true, nil
+}
+
 func (w *WordsR) RandomUnknownWord(ctx context.Context, userID int64) (models.WordCard, error) {
 	query := `
 	SELECT word_text, translation
@@ -55,10 +113,48 @@ func (w *WordsR) RandomUnknownWord(ctx context.Context, userID int64) (models.Wo
 	return word, nil
 }
 
-func (w *WordsR) Words(ctx context.Context, userID int64, offset int, known bool) ([]models.WordCard, int, error) {
+// wordListFilter is the WHERE clause of the word list. An empty prefix or
+// part of speech, a NULL known and zero days match every word.
+const wordListFilter = `
+	WHERE w.user_id = $1
+		AND ($2::boolean IS NULL OR w.known = $2)
+		AND ($3 = '' OR lower(w.word_text) LIKE $3 OR lower(w.translation) LIKE $3)
+		AND ($4 = '' OR w.details->'dictionary'->'definitions' @> jsonb_build_array(jsonb_build_object('part-of-speech', $4::text)))
+		AND ($5::int = 0 OR w.created_at >= NOW() - make_interval(days => $5::int))
+`
+
+var wordListOrder = map[string]string{
+	models.WordSortRecent: "w.last_seen DESC NULLS LAST",
+	models.WordSortAlpha:  "lower(w.word_text)",
+	models.WordSortAdded:  "w.created_at DESC",
+	models.WordSortDue:    "w.last_seen NULLS FIRST",
+	models.WordSortErrors: "e.error_rate DESC NULLS LAST, w.last_seen DESC NULLS LAST",
+}
+
+// Words returns a page of the user's words picked by list, and how many words
+// match it in total.
+func (w *WordsR) Words(ctx context.Context, userID int64, list models.WordList) ([]models.WordCard, int, error) {
+	order, ok := wordListOrder[list.Sort]
+	if !ok {
+		order = wordListOrder[models.WordSortRecent]
+	}
+
+	var known *bool
+	if list.Deck == models.DeckLearned || list.Deck == models.DeckLearning {
+		learned := list.Deck == models.DeckLearned
+		known = &learned
+	}
+
+	var prefix string
+	if list.Prefix != "" {
+		prefix = escapeLike(strings.ToLower(list.Prefix)) + "%"
+	}
+
+	args := []any{userID, known, prefix, list.PartOfSpeech, list.AddedDays}
+
 	var total int
-	countQuery := `SELECT COUNT(*) FROM user_words WHERE user_id = $1 AND known = $2`
-	err := w.db.GetContext(ctx, &total, countQuery, userID, known)
+	countQuery := `SELECT COUNT(*) FROM user_words AS w` + wordListFilter
+	err := w.db.GetContext(ctx, &total, countQuery, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -68,21 +164,40 @@ func (w *WordsR) Words(ctx context.Context, userID int64, offset int, known bool
 	}
 
 	query := `
-		SELECT user_id, word_text, translation, last_seen, known
-		FROM user_words
-		WHERE user_id = $1 AND known = $2
-		ORDER BY last_seen DESC
-		LIMIT 10 OFFSET $3
+		SELECT w.user_id, w.word_text, w.translation, w.last_seen, w.known, w.details
+		FROM user_words AS w
+		LEFT JOIN (
+			SELECT word, AVG(CASE WHEN is_correct THEN 0 ELSE 1 END) AS error_rate
+			FROM user_quiz_results
+			WHERE user_id = $1
+			GROUP BY word
+		) AS e ON e.word = w.word_text` + wordListFilter + `
+		ORDER BY ` + order + `, w.word_text
+		LIMIT $6 OFFSET $7
 	`
-	words := make([]models.WordCard, 0, 10)
-	err = w.db.SelectContext(ctx, &words, query, userID, known, offset)
+	rows := make([]wordRow, 0, list.PageSize)
+	err = w.db.SelectContext(ctx, &rows, query, append(args, list.PageSize, list.Page*list.PageSize)...)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	words := make([]models.WordCard, 0, len(rows))
+	for _, row := range rows {
+		card, err := row.card()
+		if err != nil {
+			return nil, 0, err
+		}
+		words = append(words, card)
+	}
+
 	return words, total, nil
 }
 
+// escapeLike makes the LIKE wildcards in s match themselves.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (w *WordsR) WordStat(ctx context.Context, userID int64) (models.WordStats, error) {
 	query := `
 		SELECT
@@ -102,3 +217,39 @@ func (w *WordsR) WordStat(ctx context.Context, userID int64) (models.WordStats,
 
 	return stats, nil
 }
+
+// SavedWords returns which of the given lowercase words are in the user's
+// list.
+func (w *WordsR) SavedWords(ctx context.Context, userID int64, words []string) ([]string, error) {
+	query := `
+		SELECT lower(word_text)
+		FROM user_words
+		WHERE user_id = $1 AND lower(word_text) = ANY($2)
+	`
+
+	saved := make([]string, 0, len(words))
+	if err := w.db.SelectContext(ctx, &saved, query, userID, pq.Array(words)); err != nil {
+		return nil, fmt.Errorf("failed to get saved words for user %d: %w", userID, err)
+	}
+
+	return saved, nil
+}
+
+// RandomWords returns up to limit of the user's words other than the given
+// one, with their translations.
+func (w *WordsR) RandomWords(ctx context.Context, userID int64, exclude string, limit int) ([]models.QuizWord, error) {
+	query := `
+		SELECT word_text, translation
+		FROM user_words
+		WHERE user_id = $1 AND word_text <> $2 AND translation <> ''
+		ORDER BY RANDOM()
+		LIMIT $3
+	`
+
+	words := make([]models.QuizWord, 0, limit)
+	if err := w.db.SelectContext(ctx, &words, query, userID, exclude, limit); err != nil {
+		return nil, fmt.Errorf("failed to get random words for user %d: %w", userID, err)
+	}
+
+	return words, nil
+}
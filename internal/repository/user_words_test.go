@@ -10,6 +10,7 @@ import (
 	"github.com/DanRulev/vocabot.git/internal/models"
 	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -47,6 +48,24 @@ func TestWordsR_AddWord(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "with details",
+			args: args{
+				ctx: context.Background(),
+				word: models.WordCard{WordText: "apple", Details: &models.WordDetails{
+					Dictionary: models.TranslationResponse{SourceText: "apple"},
+				}},
+			},
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+						require.Len(t, args, 6)
+						assert.Contains(t, string(args[4].([]byte)), `"source-text":"apple"`)
+						return nil, nil
+					})
+			},
+			wantErr: false,
+		},
 		{
 			name: "error exec",
 			args: args{
@@ -170,13 +189,18 @@ func TestWordsR_RandomUnknownWord(t *testing.T) {
 func TestWordsR_Words(t *testing.T) {
 	t.Parallel()
 
-	expectedWords := []models.WordCard{{UserID: 1, WordText: "hello", Translation: "привет", Known: true}}
+	expectedWords := []models.WordCard{
+		{UserID: 1, WordText: "hello", Translation: "привет", Known: true},
+		{UserID: 1, WordText: "apple", Translation: "яблоко", Known: true, Details: &models.WordDetails{
+			Dictionary: models.TranslationResponse{SourceText: "apple"},
+		}},
+	}
+	var rows []wordRow
 
 	type args struct {
 		ctx    context.Context
 		userID int64
-		offset int
-		known  bool
+		list   models.WordList
 	}
 	tests := []struct {
 		name    string
@@ -191,8 +215,7 @@ func TestWordsR_Words(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: 1,
-				offset: 0,
-				known:  true,
+				list:   models.WordList{Deck: models.DeckLearned, PageSize: 10},
 			},
 			f: func(mqi *mock_repository.MockQueryI) {
 				var total int
@@ -202,10 +225,15 @@ func TestWordsR_Words(t *testing.T) {
 						return nil
 					})
 
-				mqi.EXPECT().SelectContext(gomock.Any(), gomock.AssignableToTypeOf(&expectedWords), gomock.Any(), gomock.Any()).
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.AssignableToTypeOf(&rows), gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-						slice := dest.(*[]models.WordCard)
-						*slice = append(*slice, expectedWords...)
+						*dest.(*[]wordRow) = []wordRow{
+							{WordCard: models.WordCard{UserID: 1, WordText: "hello", Translation: "привет", Known: true}},
+							{
+								WordCard: models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Known: true},
+								Details:  []byte(`{"dictionary": {"source-text": "apple"}}`),
+							},
+						}
 						return nil
 					})
 			},
@@ -213,13 +241,48 @@ func TestWordsR_Words(t *testing.T) {
 			want2:   1,
 			wantErr: false,
 		},
+		{
+			name: "filters and order",
+			args: args{
+				ctx:    context.Background(),
+				userID: 1,
+				list: models.WordList{
+					Deck:         models.DeckAll,
+					Prefix:       "App_",
+					PartOfSpeech: "noun",
+					AddedDays:    7,
+					Sort:         models.WordSortErrors,
+					Page:         2,
+					PageSize:     5,
+				},
+			},
+			f: func(mqi *mock_repository.MockQueryI) {
+				var total int
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.AssignableToTypeOf(&total), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						assert.Equal(t, []interface{}{int64(1), (*bool)(nil), `app\_%`, "noun", 7}, args)
+						*dest.(*int) = 11
+						return nil
+					})
+
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.AssignableToTypeOf(&rows), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						assert.Contains(t, query, "ORDER BY e.error_rate DESC NULLS LAST")
+						assert.Equal(t, []interface{}{5, 10}, args[5:])
+						*dest.(*[]wordRow) = []wordRow{{WordCard: models.WordCard{WordText: "apple"}}}
+						return nil
+					})
+			},
+			want1:   []models.WordCard{{WordText: "apple"}},
+			want2:   11,
+			wantErr: false,
+		},
 		{
 			name: "failed get total",
 			args: args{
 				ctx:    context.Background(),
 				userID: 1,
-				offset: 0,
-				known:  true,
+				list:   models.WordList{Deck: models.DeckLearned, PageSize: 10},
 			},
 			f: func(mqi *mock_repository.MockQueryI) {
 				var total int
@@ -238,8 +301,7 @@ func TestWordsR_Words(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: 1,
-				offset: 0,
-				known:  true,
+				list:   models.WordList{Deck: models.DeckLearned, PageSize: 10},
 			},
 			f: func(mqi *mock_repository.MockQueryI) {
 				var total int
@@ -258,8 +320,7 @@ func TestWordsR_Words(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: 1,
-				offset: 0,
-				known:  true,
+				list:   models.WordList{Deck: models.DeckLearned, PageSize: 10},
 			},
 			f: func(mqi *mock_repository.MockQueryI) {
 				var total int
@@ -269,7 +330,7 @@ func TestWordsR_Words(t *testing.T) {
 						return nil
 					})
 
-				mqi.EXPECT().SelectContext(gomock.Any(), gomock.AssignableToTypeOf(&expectedWords), gomock.Any(), gomock.Any()).
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.AssignableToTypeOf(&rows), gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
 						return errors.New("db error")
 					})
@@ -288,7 +349,7 @@ func TestWordsR_Words(t *testing.T) {
 
 			repo := newWordsMock(t, ctrl, tt.f)
 
-			got1, got2, err := repo.Words(tt.args.ctx, tt.args.userID, tt.args.offset, tt.args.known)
+			got1, got2, err := repo.Words(tt.args.ctx, tt.args.userID, tt.args.list)
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Equal(t, got2, tt.want2)
@@ -376,3 +437,177 @@ func TestWordsR_WordStat(t *testing.T) {
 		})
 	}
 }
+
+func TestWordsR_SavedWords(t *testing.T) {
+	t.Parallel()
+
+	words := []string{"meadow", "brook"}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), pq.Array(words)).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]string) = []string{"brook"}
+						return nil
+					})
+			},
+			want: []string{"brook"},
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newWordsMock(t, ctrl, tt.f)
+
+			got, err := repo.SavedWords(context.Background(), 1, words)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWordsR_RandomWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.QuizWord
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "apple", 3).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.QuizWord) = []models.QuizWord{{Word: "house", Translation: "дом"}}
+						return nil
+					})
+			},
+			want: []models.QuizWord{{Word: "house", Translation: "дом"}},
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "apple", 3).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newWordsMock(t, ctrl, tt.f)
+
+			got, err := repo.RandomWords(context.Background(), 1, "apple", 3)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWordsR_Word(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		f         func(*mock_repository.MockQueryI)
+		want      models.WordCard
+		wantFound bool
+		wantErr   bool
+	}{
+		{
+			name: "with details",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "apple").
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*wordRow) = wordRow{
+							WordCard: models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко"},
+							Details:  []byte(`{"translation": {"Text": "яблоко"}}`),
+						}
+						return nil
+					})
+			},
+			want: models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Details: &models.WordDetails{
+				Translation: models.MyMemoryTranslationResult{Text: "яблоко"},
+			}},
+			wantFound: true,
+		},
+		{
+			name: "not found",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "apple").Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "broken details",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "apple").
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*wordRow) = wordRow{Details: []byte(`{`)}
+						return nil
+					})
+			},
+			wantErr: true,
+		},
+		{
+			name: "db error",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "apple").Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newWordsMock(t, ctrl, tt.f)
+
+			got, found, err := repo.Word(context.Background(), 1, "apple")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type UsersR struct {
+	db QueryI
+}
+
+func NewUsersRepository(db QueryI) *UsersR {
+	return &UsersR{
+		db: db,
+	}
+}
+
+// TouchUser registers the user on first contact and refreshes the profile
+// and activity time afterwards.
+func (u *UsersR) TouchUser(ctx context.Context, user models.User) error {
+	query := `INSERT INTO users (user_id, username, first_name, language_code, last_active_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			username = EXCLUDED.username,
+			first_name = EXCLUDED.first_name,
+			language_code = EXCLUDED.language_code,
+			last_active_at = EXCLUDED.last_active_at
+		`
+	_, err := u.db.ExecContext(ctx, query, user.ID, user.UserName, user.FirstName, user.LanguageCode)
+	if err != nil {
+		return fmt.Errorf("failed to touch user %d: %w", user.ID, err)
+	}
+
+	return nil
+}
+
+func (u *UsersR) UserSettings(ctx context.Context, userID int64) (models.UserSettings, error) {
+	query := `SELECT settings FROM users WHERE user_id = $1`
+
+	var raw []byte
+	err := u.db.GetContext(ctx, &raw, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserSettings{}, nil
+		}
+		return models.UserSettings{}, fmt.Errorf("failed to get settings for user %d: %w", userID, err)
+	}
+
+	var settings models.UserSettings
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return models.UserSettings{}, fmt.Errorf("failed to decode settings for user %d: %w", userID, err)
+	}
+
+	return settings, nil
+}
+
+// UpdateUserSettings merges the non-empty fields of patch into the stored
+// settings.
+func (u *UsersR) UpdateUserSettings(ctx context.Context, userID int64, patch models.UserSettings) error {
+	raw, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	query := `INSERT INTO users (user_id, settings)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id)
+		DO UPDATE SET settings = users.settings || EXCLUDED.settings
+		`
+	if _, err := u.db.ExecContext(ctx, query, userID, raw); err != nil {
+		return fmt.Errorf("failed to update settings for user %d: %w", userID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUsersMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_repository.MockQueryI)) *UsersR {
+	db := mock_repository.NewMockQueryI(ctrl)
+	if setupMock != nil {
+		setupMock(db)
+	}
+
+	return &UsersR{db: db}
+}
+
+func TestUsersR_TouchUser(t *testing.T) {
+	t.Parallel()
+
+	user := models.User{ID: 1, UserName: "bob", FirstName: "Bob", LanguageCode: "en"}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), "bob", "Bob", "en").Return(nil, nil)
+			},
+		},
+		{
+			name: "error exec",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), "bob", "Bob", "en").Return(nil, errors.New("error exec"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newUsersMock(t, ctrl, tt.f)
+
+			err := repo.TouchUser(context.Background(), user)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUsersR_UserSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    models.UserSettings
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]byte) = []byte(`{"language":"uk","level":"B1","daily_goal":10,"onboarded":true}`)
+						return nil
+					})
+			},
+			want: models.UserSettings{Language: "uk", Level: "B1", DailyGoal: 10, Onboarded: true},
+		},
+		{
+			name: "unknown user",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "broken settings",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]byte) = []byte(`{`)
+						return nil
+					})
+			},
+			wantErr: true,
+		},
+		{
+			name: "error get",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).Return(errors.New("error get"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newUsersMock(t, ctrl, tt.f)
+
+			got, err := repo.UserSettings(context.Background(), 1)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUsersR_UpdateUserSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		wantErr bool
+	}{
+		{
+			name: "only set fields are sent",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), []byte(`{"level":"B1"}`)).Return(nil, nil)
+			},
+		},
+		{
+			name: "error exec",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), gomock.Any()).Return(nil, errors.New("error exec"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newUsersMock(t, ctrl, tt.f)
+
+			err := repo.UpdateUserSettings(context.Background(), 1, models.UserSettings{Level: "B1"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type WotdR struct {
+	db QueryI
+}
+
+func NewWotdRepository(db QueryI) *WotdR {
+	return &WotdR{db: db}
+}
+
+type wordOfDayRow struct {
+	Day         time.Time `db:"day"`
+	LangPair    string    `db:"lang_pair"`
+	Word        string    `db:"word"`
+	Translation string    `db:"translation"`
+	Card        []byte    `db:"card"`
+}
+
+func (w *WotdR) WordOfDay(ctx context.Context, day time.Time, langPair string) (models.WordOfDay, bool, error) {
+	query := `
+		SELECT day, lang_pair, word, translation, card
+		FROM word_of_the_day
+		WHERE day = $1::date AND lang_pair = $2
+	`
+
+	var row wordOfDayRow
+	err := w.db.GetContext(ctx, &row, query, day, langPair)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WordOfDay{}, false, nil
+		}
+		return models.WordOfDay{}, false, fmt.Errorf("database error: %w", err)
+	}
+
+	wotd := models.WordOfDay{
+		Day:         row.Day,
+		LangPair:    row.LangPair,
+		Word:        row.Word,
+		Translation: row.Translation,
+	}
+	if err := json.Unmarshal(row.Card, &wotd.Card); err != nil {
+		return models.WordOfDay{}, false, fmt.Errorf("failed to unmarshal word of the day card: %w", err)
+	}
+
+	return wotd, true, nil
+}
+
+// SaveWordOfDay keeps the first word stored for the day, so concurrent
+// instances can't replace a word that was already sent out.
+func (w *WotdR) SaveWordOfDay(ctx context.Context, wotd models.WordOfDay) error {
+	card, err := json.Marshal(wotd.Card)
+	if err != nil {
+		return fmt.Errorf("failed to marshal word of the day card: %w", err)
+	}
+
+	query := `INSERT INTO word_of_the_day (day, lang_pair, word, translation, card)
+		VALUES ($1::date, $2, $3, $4, $5)
+		ON CONFLICT (day, lang_pair) DO NOTHING
+		`
+	_, err = w.db.ExecContext(ctx, query, wotd.Day, wotd.LangPair, wotd.Word, wotd.Translation, card)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *WotdR) SubscribeWotd(ctx context.Context, userID, chatID int64) error {
+	query := `INSERT INTO wotd_subscriptions (user_id, chat_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id)
+		DO UPDATE SET chat_id = EXCLUDED.chat_id
+		`
+	_, err := w.db.ExecContext(ctx, query, userID, chatID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *WotdR) UnsubscribeWotd(ctx context.Context, userID int64) error {
+	query := `DELETE FROM wotd_subscriptions WHERE user_id = $1`
+	_, err := w.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (w *WotdR) WotdSubscribed(ctx context.Context, userID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM wotd_subscriptions WHERE user_id = $1)`
+
+	var subscribed bool
+	if err := w.db.GetContext(ctx, &subscribed, query, userID); err != nil {
+		return false, fmt.Errorf("failed to check wotd subscription for user %d: %w", userID, err)
+	}
+
+	return subscribed, nil
+}
+
+// WotdSubscribers returns subscribers that haven't been sent the word of the
+// given day yet, with their interface language if they picked one.
+func (w *WotdR) WotdSubscribers(ctx context.Context, day time.Time) ([]models.WotdSubscriber, error) {
+	query := `
+		SELECT s.user_id, s.chat_id, COALESCE(u.settings->>'language', '') AS language
+		FROM wotd_subscriptions s
+		LEFT JOIN users u ON u.user_id = s.user_id
+		WHERE s.last_sent IS NULL OR s.last_sent < $1::date
+		ORDER BY s.user_id
+	`
+
+	subscribers := make([]models.WotdSubscriber, 0)
+	if err := w.db.SelectContext(ctx, &subscribers, query, day); err != nil {
+		return nil, fmt.Errorf("failed to get wotd subscribers: %w", err)
+	}
+
+	return subscribers, nil
+}
+
+func (w *WotdR) MarkWotdSent(ctx context.Context, userID int64, day time.Time) error {
+	query := `UPDATE wotd_subscriptions SET last_sent = $2::date WHERE user_id = $1`
+	_, err := w.db.ExecContext(ctx, query, userID, day)
+	return err
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_repository "github.com/DanRulev/vocabot.git/internal/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWotdMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_repository.MockQueryI)) *WotdR {
+	db := mock_repository.NewMockQueryI(ctrl)
+	if setupMock != nil {
+		setupMock(db)
+	}
+
+	return &WotdR{db: db}
+}
+
+var wotdDay = time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+func TestWotdR_WordOfDay(t *testing.T) {
+	t.Parallel()
+
+	want := models.WordOfDay{
+		Day:         wotdDay,
+		LangPair:    "en-ru",
+		Word:        "apple",
+		Translation: "яблоко",
+		Card: models.WordDetails{
+			Translation: models.MyMemoryTranslationResult{Text: "яблоко", Match: 1},
+			Dictionary:  models.TranslationResponse{SourceText: "apple"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		f         func(*mock_repository.MockQueryI)
+		want      models.WordOfDay
+		wantFound bool
+		wantErr   bool
+	}{
+		{
+			name: "found",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), wotdDay, "en-ru").
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*wordOfDayRow) = wordOfDayRow{
+							Day:         wotdDay,
+							LangPair:    "en-ru",
+							Word:        "apple",
+							Translation: "яблоко",
+							Card:        []byte(`{"translation":{"Text":"яблоко","Match":1},"dictionary":{"source-text":"apple"}}`),
+						}
+						return nil
+					})
+			},
+			want:      want,
+			wantFound: true,
+		},
+		{
+			name: "not found",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), wotdDay, "en-ru").Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "broken card",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), wotdDay, "en-ru").
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*wordOfDayRow) = wordOfDayRow{Card: []byte(`{`)}
+						return nil
+					})
+			},
+			wantErr: true,
+		},
+		{
+			name: "error get",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), wotdDay, "en-ru").Return(errors.New("error get"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newWotdMock(t, ctrl, tt.f)
+
+			got, found, err := repo.WordOfDay(context.Background(), wotdDay, "en-ru")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWotdR_SaveWordOfDay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), wotdDay, "en-ru", "apple", "яблоко", gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name: "failed exec",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), wotdDay, "en-ru", "apple", "яблоко", gomock.Any()).Return(nil, errors.New("exec error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newWotdMock(t, ctrl, tt.f)
+
+			err := repo.SaveWordOfDay(context.Background(), models.WordOfDay{
+				Day: wotdDay, LangPair: "en-ru", Word: "apple", Translation: "яблоко",
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWotdR_WotdSubscribers(t *testing.T) {
+	t.Parallel()
+
+	subscribers := []models.WotdSubscriber{{UserID: 1, ChatID: 1, Language: "en"}}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_repository.MockQueryI)
+		want    []models.WotdSubscriber
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), wotdDay).
+					DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+						*dest.(*[]models.WotdSubscriber) = subscribers
+						return nil
+					})
+			},
+			want: subscribers,
+		},
+		{
+			name: "error select",
+			f: func(mqi *mock_repository.MockQueryI) {
+				mqi.EXPECT().SelectContext(gomock.Any(), gomock.Any(), gomock.Any(), wotdDay).Return(errors.New("error select"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := newWotdMock(t, ctrl, tt.f)
+
+			got, err := repo.WotdSubscribers(context.Background(), wotdDay)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWotdR_Subscription(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := newWotdMock(t, ctrl, func(mqi *mock_repository.MockQueryI) {
+		gomock.InOrder(
+			mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), int64(2)).Return(nil, nil),
+			mqi.EXPECT().GetContext(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).
+				DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+					*dest.(*bool) = true
+					return nil
+				}),
+			mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1), wotdDay).Return(nil, nil),
+			mqi.EXPECT().ExecContext(gomock.Any(), gomock.Any(), int64(1)).Return(nil, errors.New("exec error")),
+		)
+	})
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.SubscribeWotd(ctx, 1, 2))
+
+	subscribed, err := repo.WotdSubscribed(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, subscribed)
+
+	require.NoError(t, repo.MarkWotdSent(ctx, 1, wotdDay))
+	require.Error(t, repo.UnsubscribeWotd(ctx, 1))
+}
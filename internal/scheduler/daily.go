@@ -0,0 +1,59 @@
+// Package scheduler runs background jobs at a fixed local time of day.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Clock is a time of day in the location of the times it is applied to.
+type Clock struct {
+	Hour   int
+	Minute int
+}
+
+// ParseClock parses "HH:MM".
+func ParseClock(s string) (Clock, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return Clock{}, fmt.Errorf("invalid time of day %q: %w", s, err)
+	}
+
+	return Clock{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+// Today returns the clock's time on the day of now.
+func (c Clock) Today(now time.Time) time.Time {
+	y, m, d := now.Date()
+	return time.Date(y, m, d, c.Hour, c.Minute, 0, 0, now.Location())
+}
+
+// Next returns the first time after now the clock shows.
+func (c Clock) Next(now time.Time) time.Time {
+	next := c.Today(now)
+	if !next.After(now) {
+		next = c.Today(now.AddDate(0, 0, 1))
+	}
+	return next
+}
+
+// Daily calls job every day at the given clock until ctx is done. If the
+// time has already passed today when Daily starts, job runs right away, so
+// jobs must tolerate being run twice a day.
+func Daily(ctx context.Context, at Clock, job func(ctx context.Context)) {
+	if now := time.Now(); !now.Before(at.Today(now)) {
+		job(ctx)
+	}
+
+	for {
+		timer := time.NewTimer(time.Until(at.Next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			job(ctx)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClock(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    Clock
+		wantErr bool
+	}{
+		{name: "morning", in: "09:05", want: Clock{Hour: 9, Minute: 5}},
+		{name: "midnight", in: "00:00", want: Clock{}},
+		{name: "out of range", in: "24:00", wantErr: true},
+		{name: "garbage", in: "nine", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseClock(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClock_Next(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*3600)
+	at := Clock{Hour: 9}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "later today",
+			now:  time.Date(2025, 3, 10, 8, 59, 0, 0, loc),
+			want: time.Date(2025, 3, 10, 9, 0, 0, 0, loc),
+		},
+		{
+			name: "exactly now runs tomorrow",
+			now:  time.Date(2025, 3, 10, 9, 0, 0, 0, loc),
+			want: time.Date(2025, 3, 11, 9, 0, 0, 0, loc),
+		},
+		{
+			name: "across month end",
+			now:  time.Date(2025, 3, 31, 20, 0, 0, 0, loc),
+			want: time.Date(2025, 4, 1, 9, 0, 0, 0, loc),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, at.Next(tt.now))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+type AdminRI interface {
+	GlobalStats(ctx context.Context, today, week time.Time) (models.GlobalStats, error)
+	UserIDs(ctx context.Context) ([]int64, error)
+	UserInfo(ctx context.Context, userID int64) (models.UserInfo, error)
+	Ban(ctx context.Context, userID, bannedBy int64, reason string) error
+	Unban(ctx context.Context, userID int64) error
+	IsBanned(ctx context.Context, userID int64) (bool, error)
+}
+
+type AdminS struct {
+	repo AdminRI
+	log  *zap.Logger
+	now  func() time.Time
+}
+
+func NewAdminService(repo AdminRI, log *zap.Logger) *AdminS {
+	return &AdminS{
+		repo: repo,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+func (s *AdminS) GlobalStats(ctx context.Context, lang string) (string, error) {
+	today := models.StartOfDay(s.now())
+
+	stats, err := s.repo.GlobalStats(ctx, today, today.AddDate(0, 0, -6))
+	if err != nil {
+		s.log.Warn("failed to get global stats", zap.Error(err))
+		return "", err
+	}
+
+	lines := []string{
+		i18n.T(lang, "admin.stats_title"),
+		"",
+		i18n.T(lang, "admin.stats_users", stats.Users, stats.Banned),
+		i18n.T(lang, "admin.stats_active", stats.ActiveToday, stats.ActiveWeek),
+		i18n.T(lang, "admin.stats_words", stats.WordsTotal, stats.WordsToday),
+		i18n.T(lang, "admin.stats_quiz", stats.QuizTotal, stats.QuizToday),
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (s *AdminS) UserInfo(ctx context.Context, userID int64, lang string) (string, error) {
+	info, err := s.repo.UserInfo(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to get user info", zap.Int64("user_id", userID), zap.Error(err))
+		return "", err
+	}
+
+	lastActive := i18n.T(lang, "admin.never")
+	if info.LastActive != nil {
+		lastActive = info.LastActive.Format("02.01.2006 15:04")
+	}
+	language := info.Language
+	if language == "" {
+		language = "—"
+	}
+
+	lines := []string{
+		i18n.T(lang, "admin.user_title", info.UserID),
+		"",
+		i18n.T(lang, "admin.user_language", language),
+		i18n.T(lang, "admin.user_words", info.Words, info.Learned),
+		i18n.T(lang, "admin.user_quiz", info.Quizzes, info.Correct),
+		i18n.T(lang, "admin.user_last_active", lastActive),
+		i18n.T(lang, "admin.user_wotd", yesNo(lang, info.WotdSubscribed)),
+		i18n.T(lang, "admin.user_banned", yesNo(lang, info.Banned)),
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (s *AdminS) BroadcastRecipients(ctx context.Context) ([]int64, error) {
+	ids, err := s.repo.UserIDs(ctx)
+	if err != nil {
+		s.log.Warn("failed to get broadcast recipients", zap.Error(err))
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (s *AdminS) Ban(ctx context.Context, userID, bannedBy int64, reason string) error {
+	if err := s.repo.Ban(ctx, userID, bannedBy, reason); err != nil {
+		s.log.Warn("failed to ban user", zap.Int64("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	s.log.Info("user banned", zap.Int64("user_id", userID), zap.Int64("by", bannedBy), zap.String("reason", reason))
+	return nil
+}
+
+func (s *AdminS) Unban(ctx context.Context, userID int64) error {
+	if err := s.repo.Unban(ctx, userID); err != nil {
+		s.log.Warn("failed to unban user", zap.Int64("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *AdminS) IsBanned(ctx context.Context, userID int64) (bool, error) {
+	return s.repo.IsBanned(ctx, userID)
+}
+
+func yesNo(lang string, v bool) string {
+	if v {
+		return i18n.T(lang, "admin.yes")
+	}
+	return i18n.T(lang, "admin.no")
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newAdminServiceMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *AdminS {
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return &AdminS{
+		repo: repo,
+		log:  zap.NewNop(),
+		now:  func() time.Time { return statsNow },
+	}
+}
+
+func TestAdminS_GlobalStats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().GlobalStats(gomock.Any(), day(10), day(4)).Return(models.GlobalStats{
+					Users: 12, Banned: 1, ActiveToday: 3, ActiveWeek: 7,
+					WordsTotal: 100, WordsToday: 5, QuizTotal: 40, QuizToday: 4,
+				}, nil)
+			},
+			want: []string{
+				i18n.T("en", "admin.stats_users", 12, 1),
+				i18n.T("en", "admin.stats_active", 3, 7),
+				i18n.T("en", "admin.stats_words", 100, 5),
+				i18n.T("en", "admin.stats_quiz", 40, 4),
+			},
+		},
+		{
+			name: "error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().GlobalStats(gomock.Any(), day(10), day(4)).Return(models.GlobalStats{}, errors.New("error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := newAdminServiceMock(t, ctrl, tt.f)
+
+			got, err := s.GlobalStats(context.Background(), "en")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			for _, line := range tt.want {
+				assert.Contains(t, got, line)
+			}
+		})
+	}
+}
+
+func TestAdminS_UserInfo(t *testing.T) {
+	t.Parallel()
+
+	lastActive := time.Date(2025, 3, 9, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "active user",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().UserInfo(gomock.Any(), int64(5)).Return(models.UserInfo{
+					UserID: 5, Language: "uk", Words: 10, Learned: 4, Quizzes: 8, Correct: 6,
+					LastActive: &lastActive, WotdSubscribed: true,
+				}, nil)
+			},
+			want: []string{
+				i18n.T("en", "admin.user_title", 5),
+				i18n.T("en", "admin.user_language", "uk"),
+				i18n.T("en", "admin.user_words", 10, 4),
+				i18n.T("en", "admin.user_last_active", "09.03.2025 18:30"),
+				i18n.T("en", "admin.user_wotd", i18n.T("en", "admin.yes")),
+				i18n.T("en", "admin.user_banned", i18n.T("en", "admin.no")),
+			},
+		},
+		{
+			name: "unknown user",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().UserInfo(gomock.Any(), int64(5)).Return(models.UserInfo{UserID: 5}, nil)
+			},
+			want: []string{
+				i18n.T("en", "admin.user_language", "—"),
+				i18n.T("en", "admin.user_last_active", i18n.T("en", "admin.never")),
+			},
+		},
+		{
+			name: "error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().UserInfo(gomock.Any(), int64(5)).Return(models.UserInfo{}, errors.New("error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := newAdminServiceMock(t, ctrl, tt.f)
+
+			got, err := s.UserInfo(context.Background(), 5, "en")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			for _, line := range tt.want {
+				assert.Contains(t, got, line)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+type AudioRI interface {
+	AudioFileID(ctx context.Context, kind, word string) (string, bool, error)
+	SaveAudioFileID(ctx context.Context, kind, word, fileID string) error
+}
+
+type AudioS struct {
+	pythonAnyWhere PythonAnyWhereAPII
+	source         AudioSourceI
+	repo           AudioRI
+	log            *zap.Logger
+}
+
+func NewAudioService(api APII, source AudioSourceI, repo AudioRI, log *zap.Logger) *AudioS {
+	return &AudioS{
+		pythonAnyWhere: api,
+		source:         source,
+		repo:           repo,
+		log:            log,
+	}
+}
+
+// Pronunciation returns the file id of a word's audio of the given kind once
+// the frontend has one, otherwise the audio itself from the source. url is
+// the dictionary's link to it, when empty it is looked up.
+func (s *AudioS) Pronunciation(ctx context.Context, kind, word, url string) (models.Audio, error) {
+	fileID, found, err := s.repo.AudioFileID(ctx, kind, word)
+	if err != nil {
+		s.log.Warn("failed to get audio file id", zap.String("word", word), zap.Error(err))
+	}
+	if found {
+		return models.Audio{FileID: fileID}, nil
+	}
+
+	if url == "" {
+		dictData, err := s.pythonAnyWhere.DictionaryData(ctx, word)
+		if err != nil {
+			s.log.Warn("failed to get dictionary data for audio", zap.String("word", word), zap.Error(err))
+		}
+		url = dictData.Pronunciation.SourceTextAudio
+	}
+
+	audio, err := s.source.Audio(ctx, word, url)
+	if err != nil {
+		s.log.Warn("failed to get audio", zap.String("word", word), zap.Error(err))
+		return models.Audio{}, err
+	}
+
+	return audio, nil
+}
+
+// SaveAudioFileID remembers the file id the frontend gave to a word's audio,
+// so it is uploaded only once.
+func (s *AudioS) SaveAudioFileID(ctx context.Context, kind, word, fileID string) error {
+	return s.repo.SaveAudioFileID(ctx, kind, word, fileID)
+}
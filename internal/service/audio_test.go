@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAudioS_Pronunciation(t *testing.T) {
+	t.Parallel()
+
+	const url = "https://example.com/apple.mp3"
+
+	tests := []struct {
+		name    string
+		url     string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockAPII, *mock_service.MockAudioSourceI)
+		want    models.Audio
+		wantErr bool
+	}{
+		{
+			name: "cached file id",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII, mas *mock_service.MockAudioSourceI) {
+				mri.EXPECT().AudioFileID(gomock.Any(), models.AudioCard, "apple").Return("file", true, nil)
+			},
+			url:  url,
+			want: models.Audio{FileID: "file"},
+		},
+		{
+			name: "first request downloads the audio",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII, mas *mock_service.MockAudioSourceI) {
+				mri.EXPECT().AudioFileID(gomock.Any(), models.AudioCard, "apple").Return("", false, nil)
+				mas.EXPECT().Audio(gomock.Any(), "apple", url).Return(models.Audio{Name: "apple.mp3", Data: []byte{1}}, nil)
+			},
+			url:  url,
+			want: models.Audio{Name: "apple.mp3", Data: []byte{1}},
+		},
+		{
+			name: "cache error falls back to the source",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII, mas *mock_service.MockAudioSourceI) {
+				mri.EXPECT().AudioFileID(gomock.Any(), models.AudioCard, "apple").Return("", false, errors.New("db error"))
+				mas.EXPECT().Audio(gomock.Any(), "apple", url).Return(models.Audio{Name: "apple.mp3", Data: []byte{1}}, nil)
+			},
+			url:  url,
+			want: models.Audio{Name: "apple.mp3", Data: []byte{1}},
+		},
+		{
+			name: "no audio",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII, mas *mock_service.MockAudioSourceI) {
+				mri.EXPECT().AudioFileID(gomock.Any(), models.AudioCard, "apple").Return("", false, nil)
+				mas.EXPECT().Audio(gomock.Any(), "apple", url).Return(models.Audio{}, models.ErrNoAudio)
+			},
+			url:     url,
+			wantErr: true,
+		},
+		{
+			name: "url is looked up in the dictionary",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII, mas *mock_service.MockAudioSourceI) {
+				mri.EXPECT().AudioFileID(gomock.Any(), models.AudioCard, "apple").Return("", false, nil)
+				dict := models.TranslationResponse{SourceText: "apple"}
+				dict.Pronunciation.SourceTextAudio = url
+				ma.EXPECT().DictionaryData(gomock.Any(), "apple").Return(dict, nil)
+				mas.EXPECT().Audio(gomock.Any(), "apple", url).Return(models.Audio{Name: "apple.mp3", Data: []byte{1}}, nil)
+			},
+			want: models.Audio{Name: "apple.mp3", Data: []byte{1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockRepositoryI(ctrl)
+			api := mock_service.NewMockAPII(ctrl)
+			source := mock_service.NewMockAudioSourceI(ctrl)
+			tt.f(repo, api, source)
+
+			got, err := NewAudioService(api, source, repo, zap.NewNop()).Pronunciation(context.Background(), models.AudioCard, "apple", tt.url)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"image"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chart"
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	accuracyChartDays  = 30
+	learnedChartWeeks  = 12
+	activityChartWeeks = 16
+)
+
+// Charts renders the accuracy trend, words learned per week and the activity
+// heatmap as PNG images.
+func (s *StatsS) Charts(ctx context.Context, userID int64, lang string) ([]models.Chart, error) {
+	charts, err := s.charts(ctx, userID, lang)
+	if err != nil {
+		s.log.Warn("failed to render charts", zap.Int64("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	return charts, nil
+}
+
+func (s *StatsS) charts(ctx context.Context, userID int64, lang string) ([]models.Chart, error) {
+	now := s.now()
+	noData := i18n.T(lang, "chart.no_data")
+
+	accuracy, err := s.repo.QuizAccuracy(ctx, userID, models.StartOfDay(now.AddDate(0, 0, 1-accuracyChartDays)), "day")
+	if err != nil {
+		return nil, err
+	}
+	accuracyPoints := make([]chart.Point, 0, len(accuracy))
+	for _, day := range accuracy {
+		if day.Total == 0 {
+			continue
+		}
+		accuracyPoints = append(accuracyPoints, chart.Point{
+			Label: day.Day.Format("02.01"),
+			Value: float64(day.Correct) * 100 / float64(day.Total),
+		})
+	}
+	accuracyImg, err := chart.Line(i18n.T(lang, "chart.accuracy_title"), noData, accuracyPoints, 100, "%.0f%%")
+	if err != nil {
+		return nil, err
+	}
+
+	firstWeek := models.StartOfWeek(now).AddDate(0, 0, -7*(learnedChartWeeks-1))
+	learned, err := s.repo.LearnedWords(ctx, userID, firstWeek, "week")
+	if err != nil {
+		return nil, err
+	}
+	learnedImg, err := chart.Bars(i18n.T(lang, "chart.learned_title"), noData, weeklyPoints(learned, firstWeek, learnedChartWeeks))
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.repo.ActivityCounts(ctx, userID, models.StartOfWeek(now).AddDate(0, 0, -7*(activityChartWeeks-1)))
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[time.Time]int, len(activity))
+	for _, day := range activity {
+		counts[day.Day] += day.Count
+	}
+	activityImg, err := chart.Heatmap(i18n.T(lang, "chart.activity_title"), now, activityChartWeeks, counts, weekdayLabels(lang))
+	if err != nil {
+		return nil, err
+	}
+
+	charts := make([]models.Chart, 0, 3)
+	for _, c := range []struct {
+		name  string
+		title string
+		img   image.Image
+	}{
+		{"accuracy", "chart.accuracy_title", accuracyImg},
+		{"learned", "chart.learned_title", learnedImg},
+		{"activity", "chart.activity_title", activityImg},
+	} {
+		data, err := chart.PNG(c.img)
+		if err != nil {
+			return nil, err
+		}
+		charts = append(charts, models.Chart{
+			Name:    c.name + ".png",
+			Caption: i18n.T(lang, c.title),
+			PNG:     data,
+		})
+	}
+
+	return charts, nil
+}
+
+// weeklyPoints lays out weeks starting on Mondays from first, filling weeks
+// without learned words with zeros.
+func weeklyPoints(counts []models.DayCount, first time.Time, weeks int) []chart.Point {
+	byWeek := make(map[int64]int, len(counts))
+	for _, c := range counts {
+		byWeek[dayNumber(c.Day)] += c.Count
+	}
+
+	total := 0
+	points := make([]chart.Point, 0, weeks)
+	for w := 0; w < weeks; w++ {
+		monday := first.AddDate(0, 0, 7*w)
+		n := byWeek[dayNumber(monday)]
+		total += n
+		points = append(points, chart.Point{Label: monday.Format("02.01"), Value: float64(n)})
+	}
+
+	if total == 0 {
+		return nil
+	}
+	return points
+}
+
+func weekdayLabels(lang string) [7]string {
+	var labels [7]string
+	for i, name := range strings.Split(i18n.T(lang, "chart.weekdays"), ",") {
+		if i >= len(labels) {
+			break
+		}
+		// Every other row is labelled, like on GitHub's contribution graph.
+		if i%2 == 0 {
+			labels[i] = strings.TrimSpace(name)
+		}
+	}
+	return labels
+}
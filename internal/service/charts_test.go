@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/chart"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsS_Charts(t *testing.T) {
+	t.Parallel()
+
+	accuracySince := time.Date(2025, 2, 9, 0, 0, 0, 0, time.UTC)
+	learnedSince := time.Date(2024, 12, 23, 0, 0, 0, 0, time.UTC)
+	activitySince := time.Date(2024, 11, 25, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		f         func(*mock_service.MockRepositoryI)
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().QuizAccuracy(gomock.Any(), int64(1), accuracySince, "day").Return([]models.DayAccuracy{
+					{Day: day(9), Total: 4, Correct: 3},
+					{Day: day(10), Total: 2, Correct: 2},
+				}, nil)
+				mri.EXPECT().LearnedWords(gomock.Any(), int64(1), learnedSince, "week").Return([]models.DayCount{
+					{Day: day(3), Count: 4},
+				}, nil)
+				mri.EXPECT().ActivityCounts(gomock.Any(), int64(1), activitySince).Return([]models.DayCount{
+					{Day: day(9), Count: 6},
+				}, nil)
+			},
+			wantNames: []string{"accuracy.png", "learned.png", "activity.png"},
+		},
+		{
+			name: "no data",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().QuizAccuracy(gomock.Any(), int64(1), accuracySince, "day").Return(nil, nil)
+				mri.EXPECT().LearnedWords(gomock.Any(), int64(1), learnedSince, "week").Return(nil, nil)
+				mri.EXPECT().ActivityCounts(gomock.Any(), int64(1), activitySince).Return(nil, nil)
+			},
+			wantNames: []string{"accuracy.png", "learned.png", "activity.png"},
+		},
+		{
+			name: "repo error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().QuizAccuracy(gomock.Any(), int64(1), accuracySince, "day").Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stats := newStatsServiceMock(t, ctrl, tt.f)
+
+			got, err := stats.Charts(context.Background(), 1, "en")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.wantNames))
+			for i, c := range got {
+				assert.Equal(t, tt.wantNames[i], c.Name)
+				assert.NotEmpty(t, c.Caption)
+
+				img, err := png.Decode(bytes.NewReader(c.PNG))
+				require.NoError(t, err)
+				assert.Equal(t, chart.Width, img.Bounds().Dx())
+				assert.Equal(t, chart.Height, img.Bounds().Dy())
+			}
+		})
+	}
+}
+
+func Test_weeklyPoints(t *testing.T) {
+	t.Parallel()
+
+	first := day(3).AddDate(0, 0, -7)
+
+	got := weeklyPoints([]models.DayCount{{Day: day(3), Count: 4}}, first, 3)
+	assert.Equal(t, []chart.Point{
+		{Label: "24.02", Value: 0},
+		{Label: "03.03", Value: 4},
+		{Label: "10.03", Value: 0},
+	}, got)
+
+	assert.Nil(t, weeklyPoints(nil, first, 3))
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/DanRulev/vocabot.git/internal/lemma"
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+// clozeBlank takes the place of the word in the sentence. It has no markdown
+// characters, so it shows the same in a message and in a poll.
+const clozeBlank = "＿＿＿"
+
+// NewCloze takes an example sentence of a random word from the dictionary
+// and blanks the word out, in any of its forms. The options are the word and
+// words from the distractor strategy.
+func (q *QuizS) NewCloze(ctx context.Context, userID int64) (models.WordQuiz, error) {
+	return q.newDistractorQuiz(ctx, userID, q.randomCloze)
+}
+
+func (q *QuizS) randomCloze(ctx context.Context) (models.WordQuiz, models.QuizWord, error) {
+	word, err := q.randomWord(ctx)
+	if err != nil {
+		return models.WordQuiz{}, models.QuizWord{}, err
+	}
+
+	dictData, err := q.pythonAnyWhere.DictionaryData(ctx, word)
+	if err != nil {
+		return models.WordQuiz{}, models.QuizWord{}, fmt.Errorf("DictionaryData failed: %w", err)
+	}
+
+	var sentence, partOfSpeech string
+	for _, def := range dictData.Definitions {
+		for _, example := range append([]string{def.Example}, def.OtherExamples...) {
+			if blanked, ok := blankWord(example, word); ok {
+				sentence, partOfSpeech = blanked, def.PartOfSpeech
+				break
+			}
+		}
+		if sentence != "" {
+			break
+		}
+	}
+	if sentence == "" {
+		return models.WordQuiz{}, models.QuizWord{}, fmt.Errorf("no example with the word: %v", word)
+	}
+
+	translation, err := q.dictTranslation(ctx, word, dictData)
+	if err != nil {
+		return models.WordQuiz{}, models.QuizWord{}, err
+	}
+
+	quiz := models.WordQuiz{
+		Word:        word,
+		Translation: translation,
+		Question:    sentence,
+		Options:     map[string]bool{word: true},
+	}
+	target := models.QuizWord{Word: word, Translation: translation, PartOfSpeech: partOfSpeech}
+
+	return quiz, target, nil
+}
+
+// blankWord replaces every form of the word in the sentence with clozeBlank.
+// It reports false if the word isn't there.
+func blankWord(sentence, word string) (string, bool) {
+	forms := lemma.Inflections(word)
+
+	var (
+		sb    strings.Builder
+		found bool
+	)
+
+	runes := []rune(sentence)
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) {
+			sb.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && unicode.IsLetter(runes[j]) {
+			j++
+		}
+
+		token := string(runes[i:j])
+		if forms[strings.ToLower(token)] {
+			sb.WriteString(clozeBlank)
+			found = true
+		} else {
+			sb.WriteString(token)
+		}
+		i = j
+	}
+
+	return sb.String(), found
+}
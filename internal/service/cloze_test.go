@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dictEntry builds dictionary data with one definition per example.
+func dictEntry(word, translation string, examples ...string) models.TranslationResponse {
+	resp := models.TranslationResponse{SourceText: word, DestinationText: translation}
+	for _, example := range examples {
+		resp.Definitions = append(resp.Definitions, struct {
+			PartOfSpeech  string              `json:"part-of-speech"`
+			Definition    string              `json:"definition"`
+			Example       string              `json:"example"`
+			OtherExamples []string            `json:"other-examples"`
+			Synonyms      map[string][]string `json:"synonyms"`
+		}{Example: example})
+	}
+	return resp
+}
+
+func Test_blankWord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sentence string
+		word     string
+		want     string
+		wantOk   bool
+	}{
+		{sentence: "She ate an apple.", word: "apple", want: "She ate an ＿＿＿.", wantOk: true},
+		{sentence: "Apples are red, an apple is sweet.", word: "apple", want: "＿＿＿ are red, an ＿＿＿ is sweet.", wantOk: true},
+		{sentence: "He watches TV.", word: "watch", want: "He ＿＿＿ TV.", wantOk: true},
+		{sentence: "We walked home.", word: "walk", want: "We ＿＿＿ home.", wantOk: true},
+		{sentence: "They are making tea.", word: "make", want: "They are ＿＿＿ tea.", wantOk: true},
+		{sentence: "She studied hard.", word: "study", want: "She ＿＿＿ hard.", wantOk: true},
+		{sentence: "The bus stopped.", word: "stop", want: "The bus ＿＿＿.", wantOk: true},
+		{sentence: "It is used daily.", word: "use", want: "It is ＿＿＿ daily.", wantOk: true},
+		{sentence: "A pineapple pie.", word: "apple", want: "A pineapple pie.", wantOk: false},
+		{sentence: "", word: "apple", want: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sentence, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := blankWord(tt.sentence, tt.word)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuizS_NewCloze(t *testing.T) {
+	t.Parallel()
+
+	walkDict := dictEntry("walk", "", "A short trip.", "We walked home.")
+	walkDict.Definitions[1].PartOfSpeech = "verb"
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		want    models.WordQuiz
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				gomock.InOrder(
+					ma.EXPECT().RandomWord(gomock.Any()).Return("Walk", nil),
+					ma.EXPECT().DictionaryData(gomock.Any(), "walk").Return(walkDict, nil),
+					ma.EXPECT().TranslateEnToRu(gomock.Any(), "walk").Return(models.MyMemoryTranslationResult{Text: "гулять"}, nil),
+					mri.EXPECT().RandomWords(gomock.Any(), int64(1), "walk", ownWordsPool).
+						Return([]models.QuizWord{{Word: "sing", Translation: "петь"}}, nil),
+				)
+			},
+			want: models.WordQuiz{
+				Word:        "walk",
+				Translation: "гулять",
+				Question:    "We ＿＿＿ home.",
+				// A verb of the corpus, the user's own word and a word close
+				// in length.
+				Options: map[string]bool{"walk": true, "run": false, "sing": false, "house": false},
+			},
+		},
+		{
+			name: "word without examples is skipped",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				gomock.InOrder(
+					ma.EXPECT().RandomWord(gomock.Any()).Return("ouch", nil),
+					ma.EXPECT().DictionaryData(gomock.Any(), "ouch").Return(dictEntry("ouch", "ой"), nil),
+					ma.EXPECT().RandomWord(gomock.Any()).Return("apple", nil),
+					ma.EXPECT().DictionaryData(gomock.Any(), "apple").
+						Return(dictEntry("apple", "яблоко", "An apple a day."), nil),
+					mri.EXPECT().RandomWords(gomock.Any(), int64(1), "apple", ownWordsPool).Return(nil, nil),
+				)
+			},
+			want: models.WordQuiz{
+				Word:        "apple",
+				Translation: "яблоко",
+				Question:    "An ＿＿＿ a day.",
+				Options:     map[string]bool{"apple": true, "house": false, "table": false, "great": false},
+			},
+		},
+		{
+			name: "no examples",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				ma.EXPECT().RandomWord(gomock.Any()).Return("ouch", nil).Times(wordQuizAttempts)
+				ma.EXPECT().DictionaryData(gomock.Any(), "ouch").Return(dictEntry("ouch", "ой"), nil).Times(wordQuizAttempts)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quiz := newQuizServiceMock(t, ctrl, tt.f)
+
+			got, err := quiz.NewCloze(context.Background(), 1)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+// Weights of what makes a wrong option close to the right one. A word of the
+// same part of speech beats everything else, then the user's own words, then
+// words of similar length and frequency.
+const (
+	samePartOfSpeechScore = 4
+	ownWordScore          = 2
+	lengthPenalty         = 0.3
+	rankPenalty           = 0.5
+	// distractorJitter shuffles words with close scores, so the same target
+	// doesn't always get the same options.
+	distractorJitter = 1.0
+
+	// ownWordsPool is how many of the user's words are considered.
+	ownWordsPool = 20
+)
+
+//go:embed data/quiz_words.tsv
+var quizWordsFile string
+
+var quizWords = parseQuizWords(quizWordsFile)
+
+// DistractorI picks the wrong options of a quiz about target.
+type DistractorI interface {
+	Distractors(ctx context.Context, userID int64, target models.QuizWord, n int) ([]models.QuizWord, error)
+}
+
+type DistractorRI interface {
+	RandomWords(ctx context.Context, userID int64, exclude string, limit int) ([]models.QuizWord, error)
+}
+
+// RankedDistractors takes the wrong options from the user's own words and a
+// built-in frequency list, preferring words that look like the right answer.
+// An option whose translation overlaps one of the target's translations is
+// never offered, as it would be right too.
+type RankedDistractors struct {
+	repo   DistractorRI
+	corpus []models.QuizWord
+	known  map[string]models.QuizWord
+	jitter func() float64
+	log    *zap.Logger
+}
+
+func NewRankedDistractors(repo DistractorRI, corpus []models.QuizWord, log *zap.Logger) *RankedDistractors {
+	known := make(map[string]models.QuizWord, len(corpus))
+	for _, w := range corpus {
+		if _, ok := known[w.Word]; !ok {
+			known[w.Word] = w
+		}
+	}
+
+	return &RankedDistractors{
+		repo:   repo,
+		corpus: corpus,
+		known:  known,
+		jitter: func() float64 { return rand.Float64() * distractorJitter },
+		log:    log,
+	}
+}
+
+func (d *RankedDistractors) Distractors(ctx context.Context, userID int64, target models.QuizWord, n int) ([]models.QuizWord, error) {
+	target = d.describe(target)
+
+	own, err := d.repo.RandomWords(ctx, userID, target.Word, ownWordsPool)
+	if err != nil {
+		// The frequency list is enough on its own.
+		d.log.Warn("failed to get user's words for distractors", zap.Int64("user_id", userID), zap.Error(err))
+	}
+
+	type scored struct {
+		word  models.QuizWord
+		score float64
+	}
+
+	candidates := make([]scored, 0, len(own)+len(d.corpus))
+	for _, w := range own {
+		w = d.describe(w)
+		candidates = append(candidates, scored{w, distractorScore(target, w) + ownWordScore + d.jitter()})
+	}
+	for _, w := range d.corpus {
+		candidates = append(candidates, scored{w, distractorScore(target, w) + d.jitter()})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	taken := translationWords(append([]string{target.Translation}, target.Alternatives...)...)
+	picked := make([]models.QuizWord, 0, n)
+
+	for _, c := range candidates {
+		if len(picked) == n {
+			break
+		}
+		if c.word.Word == target.Word || slices.Contains(target.Synonyms, c.word.Word) {
+			continue
+		}
+
+		words := translationWords(c.word.Translation)
+		if len(words) == 0 || overlaps(taken, words) {
+			continue
+		}
+
+		picked = append(picked, c.word)
+		for w := range words {
+			taken[w] = true
+		}
+	}
+
+	return picked, nil
+}
+
+// describe fills in the part of speech and rank of a word from the frequency
+// list, if they aren't known.
+func (d *RankedDistractors) describe(w models.QuizWord) models.QuizWord {
+	w.Word = strings.ToLower(w.Word)
+
+	known, ok := d.known[w.Word]
+	if !ok {
+		return w
+	}
+	if w.PartOfSpeech == "" {
+		w.PartOfSpeech = known.PartOfSpeech
+	}
+	if w.Rank == 0 {
+		w.Rank = known.Rank
+	}
+	return w
+}
+
+// distractorScore is higher the more the candidate looks like the target.
+func distractorScore(target, candidate models.QuizWord) float64 {
+	var score float64
+
+	if target.PartOfSpeech != "" && candidate.PartOfSpeech == target.PartOfSpeech {
+		score += samePartOfSpeechScore
+	}
+
+	lengthDiff := utf8.RuneCountInString(target.Word) - utf8.RuneCountInString(candidate.Word)
+	score -= math.Abs(float64(lengthDiff)) * lengthPenalty
+
+	if target.Rank > 0 && candidate.Rank > 0 {
+		score -= math.Abs(math.Log(float64(target.Rank))-math.Log(float64(candidate.Rank))) * rankPenalty
+	}
+
+	return score
+}
+
+// translationWords splits translations into lowercase words. Words shorter
+// than three letters, like prepositions, are left out.
+func translationWords(translations ...string) map[string]bool {
+	words := make(map[string]bool)
+	for _, t := range translations {
+		for _, w := range strings.FieldsFunc(strings.ToLower(t), func(r rune) bool {
+			return !unicode.IsLetter(r) && r != '-'
+		}) {
+			if utf8.RuneCountInString(w) >= 3 {
+				words[strings.ReplaceAll(w, "ё", "е")] = true
+			}
+		}
+	}
+	return words
+}
+
+func overlaps(a, b map[string]bool) bool {
+	for w := range b {
+		if a[w] {
+			return true
+		}
+	}
+	return false
+}
+
+// parseQuizWords reads the frequency list: a word, its part of speech and
+// translation per line, most frequent first within each part of speech.
+func parseQuizWords(data string) []models.QuizWord {
+	var (
+		words = make([]models.QuizWord, 0, strings.Count(data, "\n"))
+		ranks = make(map[string]int)
+	)
+
+	for _, line := range strings.Split(data, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+
+		ranks[fields[1]]++
+		words = append(words, models.QuizWord{
+			Word:         fields[0],
+			PartOfSpeech: fields[1],
+			Translation:  fields[2],
+			Rank:         ranks[fields[1]],
+		})
+	}
+
+	return words
+}
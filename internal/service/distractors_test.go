@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testQuizWords = parseQuizWords(`# a small corpus for tests
+house	noun	дом
+cat	noun	кот
+table	noun	стол
+big	adjective	большой
+great	adjective	великий, большой
+large	adjective	крупный
+small	adjective	маленький
+red	adjective	красный
+old	adjective	старый
+run	verb	бежать
+quickly	adverb	быстро
+`)
+
+// newTestDistractors picks from the test corpus without jitter, so the
+// options are always the same.
+func newTestDistractors(repo DistractorRI) *RankedDistractors {
+	d := NewRankedDistractors(repo, testQuizWords, zap.NewNop())
+	d.jitter = func() float64 { return 0 }
+	return d
+}
+
+func words(ws []models.QuizWord) []string {
+	result := make([]string, len(ws))
+	for i, w := range ws {
+		result[i] = w.Word
+	}
+	return result
+}
+
+func TestRankedDistractors_Distractors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target models.QuizWord
+		f      func(*mock_service.MockRepositoryI)
+		want   []string
+	}{
+		{
+			name:   "same part of speech, similar length and frequency",
+			target: models.QuizWord{Word: "big", Translation: "большой"},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil)
+			},
+			// great is a synonym by translation.
+			want: []string{"red", "old", "large"},
+		},
+		{
+			name:   "synonyms and alternative translations are left out",
+			target: models.QuizWord{Word: "big", Translation: "большой", Alternatives: []string{"крупный"}, Synonyms: []string{"old"}},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil)
+			},
+			want: []string{"red", "small", "run"},
+		},
+		{
+			name:   "user's words first",
+			target: models.QuizWord{Word: "House", Translation: "дом"},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "house", ownWordsPool).Return([]models.QuizWord{
+					{Word: "Table", Translation: "стол"},
+					{Word: "dog", Translation: "собака"},
+				}, nil)
+			},
+			want: []string{"table", "cat", "dog"},
+		},
+		{
+			name:   "repository error",
+			target: models.QuizWord{Word: "cat", Translation: "кот"},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "cat", ownWordsPool).Return(nil, assert.AnError)
+			},
+			want: []string{"table", "house", "big"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockRepositoryI(ctrl)
+			tt.f(repo)
+
+			got, err := newTestDistractors(repo).Distractors(context.Background(), 1, tt.target, 3)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, words(got))
+		})
+	}
+}
+
+func Test_translationWords(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]bool{"великий": true, "большой": true}, translationWords("Великий, большой"))
+	assert.Equal(t, map[string]bool{"зеленый": true, "чай": true}, translationWords("зелёный чай", "в"))
+	assert.Empty(t, translationWords(""))
+}
+
+func Test_parseQuizWords(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, models.QuizWord{Word: "cat", PartOfSpeech: "noun", Translation: "кот", Rank: 2}, testQuizWords[1])
+	assert.Equal(t, models.QuizWord{Word: "great", PartOfSpeech: "adjective", Translation: "великий, большой", Rank: 2}, testQuizWords[4])
+
+	require.Len(t, quizWords, strings.Count(quizWordsFile, "\n")-1)
+	for _, w := range quizWords {
+		assert.NotEmpty(t, w.Word)
+		assert.NotEmpty(t, w.Translation, w.Word)
+		assert.Contains(t, []string{"noun", "verb", "adjective", "adverb"}, w.PartOfSpeech, w.Word)
+	}
+}
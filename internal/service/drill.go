@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// drillStreak is how many correct answers in a row take a word out of
+	// the hard words list.
+	drillStreak = 3
+
+	hardWordsLimit = 10
+	// drillPool is how many of the hardest words a drill question is picked
+	// from, so the same word doesn't come up every time.
+	drillPool = 5
+	// A miss counts half as much after hardWordHalfLife.
+	hardWordHalfLife = 7 * 24 * time.Hour
+
+	quizOptions = 4
+)
+
+func (q *QuizS) HardWords(ctx context.Context, userID int64, lang string) (string, bool, error) {
+	words, err := q.rankedHardWords(ctx, userID)
+	if err != nil {
+		q.log.Warn("failed to get hard words", zap.Int64("user_id", userID), zap.Error(err))
+		return "", false, err
+	}
+
+	if len(words) == 0 {
+		return i18n.T(lang, "hard_words.empty"), false, nil
+	}
+
+	return hardWordsFormat(lang, words[:min(len(words), hardWordsLimit)]), true, nil
+}
+
+// NewDrill builds a quiz question for one of the user's hardest words. The
+// wrong options are picked by the same distractor strategy as in NewQuiz. It
+// returns models.ErrNoHardWords once every word has been drilled.
+func (q *QuizS) NewDrill(ctx context.Context, userID int64) (string, map[string]bool, error) {
+	words, err := q.rankedHardWords(ctx, userID)
+	if err != nil {
+		q.log.Warn("failed to get hard words", zap.Int64("user_id", userID), zap.Error(err))
+		return "", nil, err
+	}
+	if len(words) == 0 {
+		return "", nil, models.ErrNoHardWords
+	}
+
+	pick, err := randomPosition(int64(min(len(words), drillPool)))
+	if err != nil {
+		q.log.Warn("crypto/rand failed, using math/rand fallback", zap.Error(err))
+		pick = rand.Intn(min(len(words), drillPool))
+	}
+	hard := words[pick]
+
+	target, err := q.withDictionaryData(ctx, models.QuizWord{Word: hard.Word, Translation: hard.Translation})
+	if err != nil {
+		// The drill works without it, the options are just less alike.
+		q.log.Debug("no dictionary data for drill word", zap.String("word", hard.Word), zap.Error(err))
+	}
+
+	distractors, err := q.distractors.Distractors(ctx, userID, target, quizOptions-1)
+	if err != nil {
+		q.log.Warn("failed to get distractors", zap.Int64("user_id", userID), zap.Error(err))
+		return "", nil, err
+	}
+
+	options := map[string]bool{target.Translation: true}
+	for _, d := range distractors {
+		if _, ok := options[d.Translation]; !ok {
+			options[d.Translation] = false
+		}
+	}
+
+	if len(options) < quizOptions {
+		q.log.Warn("not enough options for drill", zap.Int64("user_id", userID), zap.Int("got", len(options)))
+		return "", nil, errors.New("not enough unique translations")
+	}
+
+	return target.Word, options, nil
+}
+
+func (q *QuizS) rankedHardWords(ctx context.Context, userID int64) ([]models.HardWord, error) {
+	words, err := q.repo.HardWords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankHardWords(words, q.now()), nil
+}
+
+// rankHardWords drops words answered correctly drillStreak times in a row
+// and sorts the rest hardest first.
+func rankHardWords(words []models.HardWord, now time.Time) []models.HardWord {
+	ranked := make([]models.HardWord, 0, len(words))
+	for _, w := range words {
+		if w.Misses > 0 && w.Streak < drillStreak {
+			ranked = append(ranked, w)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		si, sj := hardWordScore(ranked[i], now), hardWordScore(ranked[j], now)
+		if si != sj {
+			return si > sj
+		}
+		return ranked[i].Word < ranked[j].Word
+	})
+
+	return ranked
+}
+
+// hardWordScore is the word's error rate, decayed by the time since the last
+// miss and lowered by every correct answer given since.
+func hardWordScore(w models.HardWord, now time.Time) float64 {
+	errorRate := float64(w.Misses) / float64(w.Attempts)
+	recency := math.Pow(0.5, now.Sub(w.LastMiss).Hours()/hardWordHalfLife.Hours())
+	progress := float64(drillStreak-w.Streak) / drillStreak
+
+	return errorRate * recency * progress
+}
+
+func hardWordsFormat(lang string, words []models.HardWord) string {
+	var sb strings.Builder
+
+	sb.WriteString(i18n.T(lang, "hard_words.title"))
+	sb.WriteString("\n\n")
+
+	for i, w := range words {
+		sb.WriteString(i18n.T(lang, "hard_words.line", i+1, escapeMarkdown(w.Word), escapeMarkdown(w.Translation),
+			w.Misses, w.Attempts, w.Streak, drillStreak))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(i18n.T(lang, "hard_words.hint", drillStreak))
+
+	return sb.String()
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rankHardWords(t *testing.T) {
+	t.Parallel()
+
+	words := []models.HardWord{
+		{Word: "old", Attempts: 2, Misses: 2, LastMiss: statsNow.Add(-30 * 24 * time.Hour)},
+		{Word: "fresh", Attempts: 4, Misses: 2, LastMiss: statsNow.Add(-time.Hour)},
+		{Word: "learned", Attempts: 5, Misses: 2, Streak: drillStreak, LastMiss: statsNow.Add(-time.Hour)},
+		{Word: "improving", Attempts: 4, Misses: 2, Streak: 2, LastMiss: statsNow.Add(-time.Hour)},
+		{Word: "always", Attempts: 3, Misses: 3, LastMiss: statsNow.Add(-time.Hour)},
+	}
+
+	got := rankHardWords(words, statsNow)
+
+	names := make([]string, 0, len(got))
+	for _, w := range got {
+		names = append(names, w.Word)
+	}
+	assert.Equal(t, []string{"always", "fresh", "improving", "old"}, names)
+}
+
+func TestQuizS_HardWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		lang      string
+		f         func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		want      string
+		wantWords bool
+		wantErr   bool
+	}{
+		{
+			name: "list",
+			lang: "en",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().HardWords(gomock.Any(), int64(1)).Return([]models.HardWord{
+					{Word: "apple", Translation: "яблоко", Attempts: 3, Misses: 2, Streak: 1, LastMiss: statsNow},
+				}, nil)
+			},
+			want: `🔥 *Hard words*:
+
+1. apple — яблоко (missed 2 of 3, correct in a row: 1/3)
+
+🎯 The drill asks only these words. A word leaves the list after 3 correct answers in a row.`,
+			wantWords: true,
+		},
+		{
+			name: "empty",
+			lang: i18n.Default,
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().HardWords(gomock.Any(), int64(1)).Return(nil, nil)
+			},
+			want: i18n.T(i18n.Default, "hard_words.empty"),
+		},
+		{
+			name: "repo error",
+			lang: i18n.Default,
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().HardWords(gomock.Any(), int64(1)).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quiz := newQuizServiceMock(t, ctrl, tt.f)
+
+			got, hasWords, err := quiz.HardWords(context.Background(), 1, tt.lang)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantWords, hasWords)
+		})
+	}
+}
+
+func TestQuizS_NewDrill(t *testing.T) {
+	t.Parallel()
+
+	apple := models.HardWord{Word: "apple", Translation: "яблоко", Attempts: 2, Misses: 1, LastMiss: statsNow}
+	big := models.HardWord{Word: "big", Translation: "большой", Attempts: 2, Misses: 1, LastMiss: statsNow}
+
+	tests := []struct {
+		name     string
+		f        func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		wantWord string
+		want     map[string]bool
+		wantErr  error
+	}{
+		{
+			name: "options of the same part of speech",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().HardWords(gomock.Any(), int64(1)).Return([]models.HardWord{apple}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "apple").Return(dictDefinition("apple", "яблоко", "noun", "a round fruit"), nil)
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "apple", ownWordsPool).
+					Return([]models.QuizWord{{Word: "pear", Translation: "груша"}, {Word: "green apple", Translation: "зелёное яблоко"}}, nil)
+			},
+			wantWord: "apple",
+			want:     map[string]bool{"яблоко": true, "дом": false, "кот": false, "стол": false},
+		},
+		{
+			name: "synonyms and other translations are not offered",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().HardWords(gomock.Any(), int64(1)).Return([]models.HardWord{big}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "big").Return(dictDefinition("big", "большой", "adjective", "of large size", "large"), nil)
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil)
+			},
+			wantWord: "big",
+			want:     map[string]bool{"большой": true, "красный": false, "старый": false, "маленький": false},
+		},
+		{
+			name: "no dictionary data",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().HardWords(gomock.Any(), int64(1)).Return([]models.HardWord{big}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "big").Return(models.TranslationResponse{}, errors.New("service unavailable"))
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil)
+			},
+			wantWord: "big",
+			// Without the synonyms "large" can't be told apart.
+			want: map[string]bool{"большой": true, "красный": false, "старый": false, "крупный": false},
+		},
+		{
+			name: "no hard words",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().HardWords(gomock.Any(), int64(1)).Return([]models.HardWord{
+					{Word: "apple", Attempts: 4, Misses: 1, Streak: drillStreak, LastMiss: statsNow},
+				}, nil)
+			},
+			wantErr: models.ErrNoHardWords,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quiz := newQuizServiceMock(t, ctrl, tt.f)
+
+			word, options, err := quiz.NewDrill(context.Background(), 1)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWord, word)
+			assert.Equal(t, tt.want, options)
+		})
+	}
+}
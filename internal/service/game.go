@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+// XP awarded per activity.
+var activityXP = map[models.Activity]int{
+	models.ActivityWordKnown:  5,
+	models.ActivityWordRepeat: 2,
+	models.ActivityQuizRight:  10,
+	models.ActivityQuizWrong:  2,
+	models.ActivityDailyGoal:  25,
+}
+
+// Activities a quiz goal counts. A words goal counts the words added to the
+// list, so pressing the buttons of a card again doesn't count twice.
+var quizGoalActivities = []models.Activity{models.ActivityQuizRight, models.ActivityQuizWrong}
+
+type achievement struct {
+	code    string
+	reached func(models.GameStats) bool
+}
+
+// achievements are listed in the order they are shown in the profile.
+var achievements = []achievement{
+	{"words_10", func(s models.GameStats) bool { return s.Learned >= 10 }},
+	{"words_100", func(s models.GameStats) bool { return s.Learned >= 100 }},
+	{"words_500", func(s models.GameStats) bool { return s.Learned >= 500 }},
+	{"quiz_100", func(s models.GameStats) bool { return s.QuizAnswers >= 100 }},
+	{"quiz_1000", func(s models.GameStats) bool { return s.QuizAnswers >= 1000 }},
+	{"streak_7", func(s models.GameStats) bool { return s.Streak >= 7 }},
+	{"streak_30", func(s models.GameStats) bool { return s.Streak >= 30 }},
+	{"goal_1", func(s models.GameStats) bool { return s.GoalsReached >= 1 }},
+	{"goal_30", func(s models.GameStats) bool { return s.GoalsReached >= 30 }},
+}
+
+type GameRI interface {
+	AddXP(ctx context.Context, userID int64, activity models.Activity, xp int) (int, error)
+	TotalXP(ctx context.Context, userID int64) (int, error)
+	ActivitiesSince(ctx context.Context, userID int64, since time.Time, activities []models.Activity) (int, error)
+	NewWordsSince(ctx context.Context, userID int64, since time.Time) (int, error)
+	CompleteGoal(ctx context.Context, userID int64, day time.Time, target int) (bool, error)
+	GameStats(ctx context.Context, userID int64) (models.GameStats, error)
+	Achievements(ctx context.Context, userID int64) ([]models.UserAchievement, error)
+	UnlockAchievement(ctx context.Context, userID int64, code string) (bool, error)
+}
+
+type GameS struct {
+	repo  GameRI
+	stats StatsRI
+	users UsersRI
+	log   *zap.Logger
+	now   func() time.Time
+}
+
+func NewGameService(repo GameRI, stats StatsRI, users UsersRI, log *zap.Logger) *GameS {
+	return &GameS{
+		repo:  repo,
+		stats: stats,
+		users: users,
+		log:   log,
+		now:   time.Now,
+	}
+}
+
+// RecordActivity awards XP for the activity and checks the daily goal, the
+// level and the achievements. It returns a progress line for the answered
+// message and an announcement of anything reached, which may be empty.
+func (s *GameS) RecordActivity(ctx context.Context, userID int64, activity models.Activity, lang string) (string, string, error) {
+	gained := activityXP[activity]
+	total, err := s.repo.AddXP(ctx, userID, activity, gained)
+	if err != nil {
+		s.log.Warn("failed to add xp", zap.Int64("user_id", userID), zap.Error(err))
+		return "", "", err
+	}
+	// The total comes back with the award, so of two concurrent awards only
+	// one crosses a level.
+	before := total - gained
+
+	var announcements []string
+
+	today := models.StartOfDay(s.now())
+	done, goal, goalType, err := s.goalProgress(ctx, userID, today)
+	if err != nil {
+		return "", "", err
+	}
+
+	if done >= goal {
+		first, err := s.repo.CompleteGoal(ctx, userID, today, goal)
+		if err != nil {
+			s.log.Warn("failed to complete daily goal", zap.Int64("user_id", userID), zap.Error(err))
+			return "", "", err
+		}
+		if first {
+			bonus := activityXP[models.ActivityDailyGoal]
+			total, err = s.repo.AddXP(ctx, userID, models.ActivityDailyGoal, bonus)
+			if err != nil {
+				s.log.Warn("failed to add goal xp", zap.Int64("user_id", userID), zap.Error(err))
+				return "", "", err
+			}
+			gained += bonus
+			announcements = append(announcements, i18n.T(lang, goalKey("game.goal_reached", goalType), goal, bonus))
+		}
+	}
+
+	if level := levelForXP(total); level > levelForXP(before) {
+		announcements = append(announcements, i18n.T(lang, "game.level_up", level))
+	}
+
+	unlocked, err := s.unlockAchievements(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	for _, code := range unlocked {
+		announcements = append(announcements, i18n.T(lang, "game.achievement_unlocked", i18n.T(lang, "achievement."+code)))
+	}
+
+	progress := i18n.T(lang, goalKey("game.progress", goalType), gained, done, goal)
+
+	return progress, strings.Join(announcements, "\n"), nil
+}
+
+// Profile shows the level, XP, today's goal and all achievements.
+func (s *GameS) Profile(ctx context.Context, userID int64, lang string) (string, error) {
+	xp, err := s.repo.TotalXP(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to get xp", zap.Int64("user_id", userID), zap.Error(err))
+		return "", err
+	}
+
+	done, goal, goalType, err := s.goalProgress(ctx, userID, models.StartOfDay(s.now()))
+	if err != nil {
+		return "", err
+	}
+
+	owned, err := s.repo.Achievements(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to get achievements", zap.Int64("user_id", userID), zap.Error(err))
+		return "", err
+	}
+
+	unlocked := make(map[string]bool, len(owned))
+	for _, a := range owned {
+		unlocked[a.Code] = true
+	}
+
+	level := levelForXP(xp)
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "game.profile_title"))
+	sb.WriteString("\n\n")
+	sb.WriteString(i18n.T(lang, "game.profile_level", level, xp, xpForLevel(level+1)-xp))
+	sb.WriteString("\n")
+	sb.WriteString(i18n.T(lang, goalKey("game.profile_goal", goalType), done, goal))
+	sb.WriteString("\n\n")
+	sb.WriteString(i18n.T(lang, "game.profile_achievements", len(unlocked), len(achievements)))
+	sb.WriteString("\n")
+	for _, a := range achievements {
+		mark := "🔒"
+		if unlocked[a.code] {
+			mark = "✅"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n", mark, i18n.T(lang, "achievement."+a.code)))
+	}
+
+	return sb.String(), nil
+}
+
+// goalProgress returns what the user did towards today's goal, the goal and
+// its type.
+func (s *GameS) goalProgress(ctx context.Context, userID int64, today time.Time) (int, int, string, error) {
+	settings, err := s.users.UserSettings(ctx, userID)
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	goal, goalType := settings.DailyGoal, settings.GoalType
+	if goal == 0 {
+		goal = models.DefaultDailyGoal
+	}
+	if goalType != models.GoalQuiz {
+		goalType = models.GoalWords
+	}
+
+	var done int
+	if goalType == models.GoalQuiz {
+		done, err = s.repo.ActivitiesSince(ctx, userID, today, quizGoalActivities)
+	} else {
+		done, err = s.repo.NewWordsSince(ctx, userID, today)
+	}
+	if err != nil {
+		s.log.Warn("failed to get daily goal progress", zap.Int64("user_id", userID), zap.Error(err))
+		return 0, 0, "", err
+	}
+
+	return done, goal, goalType, nil
+}
+
+// goalKey picks the message of key for the goal type, the words ones have no
+// suffix.
+func goalKey(key, goalType string) string {
+	if goalType == models.GoalQuiz {
+		return key + "_quiz"
+	}
+	return key
+}
+
+// unlockAchievements stores the achievements the user has just reached and
+// returns their codes.
+func (s *GameS) unlockAchievements(ctx context.Context, userID int64) ([]string, error) {
+	owned, err := s.repo.Achievements(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to get achievements", zap.Int64("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	if len(owned) == len(achievements) {
+		return nil, nil
+	}
+
+	has := make(map[string]bool, len(owned))
+	for _, a := range owned {
+		has[a.Code] = true
+	}
+
+	stats, err := s.repo.GameStats(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to get game stats", zap.Int64("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	days, err := s.stats.ActivityDays(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to get activity days", zap.Int64("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	_, stats.Streak = streaks(days, s.now())
+
+	var unlocked []string
+	for _, a := range achievements {
+		if has[a.code] || !a.reached(stats) {
+			continue
+		}
+
+		first, err := s.repo.UnlockAchievement(ctx, userID, a.code)
+		if err != nil {
+			s.log.Warn("failed to unlock achievement", zap.Int64("user_id", userID), zap.String("code", a.code), zap.Error(err))
+			return unlocked, err
+		}
+		if first {
+			unlocked = append(unlocked, a.code)
+		}
+	}
+
+	return unlocked, nil
+}
+
+// xpForLevel is the total XP needed to reach a level: 0, 100, 300, 600, ...
+func xpForLevel(level int) int {
+	return 50 * level * (level - 1)
+}
+
+func levelForXP(xp int) int {
+	level := 1
+	for xpForLevel(level+1) <= xp {
+		level++
+	}
+	return level
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newGameServiceMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *GameS {
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return &GameS{
+		repo:  repo,
+		stats: repo,
+		users: repo,
+		log:   zap.NewNop(),
+		now:   func() time.Time { return statsNow },
+	}
+}
+
+func Test_levelForXP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		xp   int
+		want int
+	}{
+		{xp: 0, want: 1},
+		{xp: 99, want: 1},
+		{xp: 100, want: 2},
+		{xp: 299, want: 2},
+		{xp: 300, want: 3},
+		{xp: 1000, want: 5},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, levelForXP(tt.xp), "xp %d", tt.xp)
+	}
+}
+
+func TestGameS_RecordActivity(t *testing.T) {
+	t.Parallel()
+
+	allUnlocked := make([]models.UserAchievement, 0, len(achievements))
+	for _, a := range achievements {
+		allUnlocked = append(allUnlocked, models.UserAchievement{Code: a.code})
+	}
+
+	tests := []struct {
+		name             string
+		activity         models.Activity
+		f                func(*mock_service.MockRepositoryI)
+		wantProgress     string
+		wantAnnouncement []string
+		wantErr          bool
+	}{
+		{
+			name:     "quiz goal in progress",
+			activity: models.ActivityQuizRight,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().AddXP(gomock.Any(), int64(1), models.ActivityQuizRight, 10).Return(50, nil)
+				mri.EXPECT().UserSettings(gomock.Any(), int64(1)).Return(models.UserSettings{DailyGoal: 20, GoalType: models.GoalQuiz}, nil)
+				mri.EXPECT().ActivitiesSince(gomock.Any(), int64(1), day(10), []models.Activity{models.ActivityQuizRight, models.ActivityQuizWrong}).Return(6, nil)
+				mri.EXPECT().Achievements(gomock.Any(), int64(1)).Return(allUnlocked, nil)
+			},
+			wantProgress: i18n.T("en", "game.progress_quiz", 10, 6, 20),
+		},
+		{
+			name:     "goal reached with level up and achievements",
+			activity: models.ActivityWordKnown,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().AddXP(gomock.Any(), int64(1), models.ActivityWordKnown, 5).Return(85, nil)
+				mri.EXPECT().UserSettings(gomock.Any(), int64(1)).Return(models.UserSettings{}, nil)
+				mri.EXPECT().NewWordsSince(gomock.Any(), int64(1), day(10)).Return(10, nil)
+				mri.EXPECT().CompleteGoal(gomock.Any(), int64(1), day(10), models.DefaultDailyGoal).Return(true, nil)
+				mri.EXPECT().AddXP(gomock.Any(), int64(1), models.ActivityDailyGoal, 25).Return(110, nil)
+				mri.EXPECT().Achievements(gomock.Any(), int64(1)).Return([]models.UserAchievement{{Code: "words_10"}}, nil)
+				mri.EXPECT().GameStats(gomock.Any(), int64(1)).Return(models.GameStats{Learned: 12, GoalsReached: 1}, nil)
+				mri.EXPECT().ActivityDays(gomock.Any(), int64(1)).Return([]time.Time{day(10), day(9)}, nil)
+				mri.EXPECT().UnlockAchievement(gomock.Any(), int64(1), "goal_1").Return(true, nil)
+			},
+			wantProgress: i18n.T("en", "game.progress", 30, 10, models.DefaultDailyGoal),
+			wantAnnouncement: []string{
+				i18n.T("en", "game.goal_reached", models.DefaultDailyGoal, 25),
+				i18n.T("en", "game.level_up", 2),
+				i18n.T("en", "game.achievement_unlocked", i18n.T("en", "achievement.goal_1")),
+			},
+		},
+		{
+			name:     "goal already reached today",
+			activity: models.ActivityWordRepeat,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().AddXP(gomock.Any(), int64(1), models.ActivityWordRepeat, 2).Return(502, nil)
+				mri.EXPECT().UserSettings(gomock.Any(), int64(1)).Return(models.UserSettings{DailyGoal: 5}, nil)
+				mri.EXPECT().NewWordsSince(gomock.Any(), int64(1), day(10)).Return(7, nil)
+				mri.EXPECT().CompleteGoal(gomock.Any(), int64(1), day(10), 5).Return(false, nil)
+				mri.EXPECT().Achievements(gomock.Any(), int64(1)).Return(allUnlocked, nil)
+			},
+			wantProgress: i18n.T("en", "game.progress", 2, 7, 5),
+		},
+		{
+			name:     "level crossed by a concurrent award",
+			activity: models.ActivityQuizRight,
+			f: func(mri *mock_service.MockRepositoryI) {
+				// Another award took the total from 90 to 100 first.
+				mri.EXPECT().AddXP(gomock.Any(), int64(1), models.ActivityQuizRight, 10).Return(110, nil)
+				mri.EXPECT().UserSettings(gomock.Any(), int64(1)).Return(models.UserSettings{DailyGoal: 20}, nil)
+				mri.EXPECT().NewWordsSince(gomock.Any(), int64(1), day(10)).Return(3, nil)
+				mri.EXPECT().Achievements(gomock.Any(), int64(1)).Return(allUnlocked, nil)
+			},
+			wantProgress: i18n.T("en", "game.progress", 10, 3, 20),
+		},
+		{
+			name:     "error add xp",
+			activity: models.ActivityQuizWrong,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().AddXP(gomock.Any(), int64(1), models.ActivityQuizWrong, 2).Return(0, errors.New("error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := newGameServiceMock(t, ctrl, tt.f)
+
+			progress, announcement, err := s.RecordActivity(context.Background(), 1, tt.activity, "en")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProgress, progress)
+			if len(tt.wantAnnouncement) == 0 {
+				assert.Empty(t, announcement)
+			}
+			for _, line := range tt.wantAnnouncement {
+				assert.Contains(t, announcement, line)
+			}
+		})
+	}
+}
+
+func TestGameS_Profile(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newGameServiceMock(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+		mri.EXPECT().TotalXP(gomock.Any(), int64(1)).Return(150, nil)
+		mri.EXPECT().UserSettings(gomock.Any(), int64(1)).Return(models.UserSettings{DailyGoal: 5}, nil)
+		mri.EXPECT().NewWordsSince(gomock.Any(), int64(1), day(10)).Return(3, nil)
+		mri.EXPECT().Achievements(gomock.Any(), int64(1)).Return([]models.UserAchievement{{Code: "streak_7"}}, nil)
+	})
+
+	got, err := s.Profile(context.Background(), 1, "en")
+	require.NoError(t, err)
+
+	assert.Contains(t, got, i18n.T("en", "game.profile_level", 2, 150, 150))
+	assert.Contains(t, got, i18n.T("en", "game.profile_goal", 3, 5))
+	assert.Contains(t, got, i18n.T("en", "game.profile_achievements", 1, len(achievements)))
+	assert.Contains(t, got, "✅ "+i18n.T("en", "achievement.streak_7"))
+	assert.Contains(t, got, "🔒 "+i18n.T("en", "achievement.words_100"))
+}
+
+func TestGameS_Profile_UTCDay(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newGameServiceMock(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+		mri.EXPECT().TotalXP(gomock.Any(), int64(1)).Return(0, nil)
+		mri.EXPECT().UserSettings(gomock.Any(), int64(1)).Return(models.UserSettings{DailyGoal: 5}, nil)
+		// 01:00 on the 11th in UTC+3 is still the 10th in UTC.
+		mri.EXPECT().NewWordsSince(gomock.Any(), int64(1), day(10)).Return(0, nil)
+		mri.EXPECT().Achievements(gomock.Any(), int64(1)).Return(nil, nil)
+	})
+	s.now = func() time.Time { return time.Date(2025, 3, 11, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)) }
+
+	_, err := s.Profile(context.Background(), 1, "en")
+	require.NoError(t, err)
+}
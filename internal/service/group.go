@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+const leaderboardSize = 10
+
+var leaderboardMedals = []string{"🥇", "🥈", "🥉"}
+
+type GroupRI interface {
+	AddGroupResult(ctx context.Context, result models.QuizCard) error
+	Leaderboard(ctx context.Context, chatID int64, since time.Time, limit int) ([]models.LeaderboardEntry, error)
+}
+
+type GroupS struct {
+	repo GroupRI
+	log  *zap.Logger
+	now  func() time.Time
+}
+
+func NewGroupService(repo GroupRI, log *zap.Logger) *GroupS {
+	return &GroupS{
+		repo: repo,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+// AddGroupResult records a group quiz answer for the leaderboard. Unlike a
+// private quiz it leaves the member's own word list and statistics alone: a
+// tap in a group chat isn't a word the member chose to learn.
+func (s *GroupS) AddGroupResult(ctx context.Context, result models.QuizCard) error {
+	if err := s.repo.AddGroupResult(ctx, result); err != nil {
+		s.log.Warn("failed to add group quiz result", zap.Int64("chat_id", result.ChatID), zap.Int64("user_id", result.UserID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Leaderboard shows the chat's best group quiz players since Monday.
+func (s *GroupS) Leaderboard(ctx context.Context, chatID int64, lang string) (string, error) {
+	entries, err := s.repo.Leaderboard(ctx, chatID, models.StartOfWeek(s.now()), leaderboardSize)
+	if err != nil {
+		s.log.Warn("failed to get leaderboard", zap.Int64("chat_id", chatID), zap.Error(err))
+		return "", err
+	}
+
+	if len(entries) == 0 {
+		return i18n.T(lang, "leaderboard.empty"), nil
+	}
+
+	lines := []string{i18n.T(lang, "leaderboard.title"), ""}
+	for i, e := range entries {
+		place := strconv.Itoa(i+1) + "."
+		if i < len(leaderboardMedals) {
+			place = leaderboardMedals[i]
+		}
+		lines = append(lines, i18n.T(lang, "leaderboard.line", place, displayName(e), e.Points, e.Answers))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func displayName(e models.LeaderboardEntry) string {
+	switch {
+	case e.FirstName != "":
+		return e.FirstName
+	case e.UserName != "":
+		return "@" + e.UserName
+	default:
+		return strconv.FormatInt(e.UserID, 10)
+	}
+}
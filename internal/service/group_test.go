@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newGroupServiceMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *GroupS {
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return &GroupS{
+		repo: repo,
+		log:  zap.NewNop(),
+		// Thursday, the week started on Monday the 10th.
+		now: func() time.Time { return statsNow.AddDate(0, 0, 3) },
+	}
+}
+
+func TestGroupS_Leaderboard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Leaderboard(gomock.Any(), int64(-100), day(10), leaderboardSize).Return([]models.LeaderboardEntry{
+					{UserID: 1, FirstName: "Ann", UserName: "ann", Points: 5, Answers: 7},
+					{UserID: 2, UserName: "bob", Points: 3, Answers: 3},
+					{UserID: 3, Points: 2, Answers: 4},
+					{UserID: 4, FirstName: "Dan", Points: 1, Answers: 1},
+				}, nil)
+			},
+			want: []string{
+				i18n.T("en", "leaderboard.title"),
+				"",
+				i18n.T("en", "leaderboard.line", "🥇", "Ann", 5, 7),
+				i18n.T("en", "leaderboard.line", "🥈", "@bob", 3, 3),
+				i18n.T("en", "leaderboard.line", "🥉", "3", 2, 4),
+				i18n.T("en", "leaderboard.line", "4.", "Dan", 1, 1),
+			},
+		},
+		{
+			name: "no winners yet",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Leaderboard(gomock.Any(), int64(-100), day(10), leaderboardSize).Return([]models.LeaderboardEntry{}, nil)
+			},
+			want: []string{i18n.T("en", "leaderboard.empty")},
+		},
+		{
+			name: "error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Leaderboard(gomock.Any(), int64(-100), day(10), leaderboardSize).Return(nil, errors.New("error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := newGroupServiceMock(t, ctrl, tt.f)
+
+			got, err := s.Leaderboard(context.Background(), -100, "en")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, strings.Join(tt.want, "\n"), got)
+		})
+	}
+}
+
+func TestGroupS_AddGroupResult(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	result := models.QuizCard{UserID: 2, ChatID: -100, Word: "hello", Translation: "привет", Type: "quiz", IsCorrect: true}
+
+	// Only the group result is stored, the member's word list and quiz
+	// results aren't touched.
+	s := newGroupServiceMock(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+		mri.EXPECT().AddGroupResult(gomock.Any(), result).Return(nil)
+		mri.EXPECT().AddGroupResult(gomock.Any(), result).Return(errors.New("error"))
+	})
+
+	require.NoError(t, s.AddGroupResult(context.Background(), result))
+	assert.Error(t, s.AddGroupResult(context.Background(), result))
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// Inline queries come a keystroke at a time and the same words are looked
+	// up by many users, so translations are kept for a while.
+	lookupCacheTTL  = time.Hour
+	lookupCacheSize = 1000
+
+	// lookupSavedWords is how many of the user's own words are offered.
+	lookupSavedWords = 5
+
+	// maxLookupWord keeps a word short enough for the callback data of its
+	// save button.
+	maxLookupWord = 48
+)
+
+// LookupWord finds what inline mode offers for text: the translation of the
+// word, if it is an English word, and the user's own words starting with it.
+func (w *WordS) LookupWord(ctx context.Context, userID int64, text, lang string) ([]models.LookupResult, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return nil, nil
+	}
+
+	saved, _, err := w.repo.Words(ctx, userID, models.WordList{
+		Prefix:   text,
+		Sort:     models.WordSortAlpha,
+		PageSize: lookupSavedWords,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.LookupResult, 0, len(saved)+1)
+
+	if isEnglishWord(text) && !savedWord(saved, text) {
+		details, err := w.lookup(ctx, text)
+		if err != nil {
+			w.log.Warn("failed to look up word", zap.String("word", text), zap.Error(err))
+		} else {
+			card := lookupCard(userID, text, details)
+			results = append(results, models.LookupResult{
+				Card: card,
+				Text: formatTranslation(lang, details.Translation, details.Dictionary),
+			})
+		}
+	}
+
+	for _, card := range saved {
+		results = append(results, models.LookupResult{
+			Card:  card,
+			Text:  savedWordText(lang, card),
+			Saved: true,
+		})
+	}
+
+	return results, nil
+}
+
+// SaveLookupWord adds a word found by inline mode to the user's list, with
+// the details it was shown with.
+func (w *WordS) SaveLookupWord(ctx context.Context, userID int64, word string) (models.WordCard, error) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if !isEnglishWord(word) {
+		return models.WordCard{}, fmt.Errorf("not an English word: %q", word)
+	}
+
+	details, err := w.lookup(ctx, word)
+	if err != nil {
+		return models.WordCard{}, err
+	}
+
+	card := lookupCard(userID, word, details)
+	card.Chosen = true
+	if err := w.repo.AddWord(ctx, card); err != nil {
+		return models.WordCard{}, err
+	}
+
+	return card, nil
+}
+
+// lookup translates the word and gets its dictionary data, or takes them
+// from the cache.
+func (w *WordS) lookup(ctx context.Context, word string) (models.WordDetails, error) {
+	if details, ok := w.lookups.get(word); ok {
+		return details, nil
+	}
+
+	translate, err := w.myMemory.TranslateEnToRu(ctx, word)
+	if err != nil {
+		w.log.Warn("failed to translate word", zap.String("word", word), zap.Error(err))
+	}
+
+	dictData, err := w.pythonAnyWhere.DictionaryData(ctx, word)
+	if err != nil {
+		w.log.Warn("failed to get dictionary data for word", zap.String("word", word), zap.Error(err))
+		dictData = models.TranslationResponse{}
+	}
+
+	if translate.Text == "" && dictData.DestinationText == "" {
+		return models.WordDetails{}, fmt.Errorf("failed to translate word %q", word)
+	}
+
+	dictData.SourceText = word
+	if dictData.DestinationText == "" {
+		dictData.DestinationText = translate.Text
+	}
+
+	details := models.WordDetails{Translation: translate, Dictionary: dictData}
+	w.lookups.set(word, details)
+
+	return details, nil
+}
+
+func lookupCard(userID int64, word string, details models.WordDetails) models.WordCard {
+	translation := details.Translation.Text
+	if translation == "" {
+		translation = details.Dictionary.DestinationText
+	}
+
+	return models.WordCard{
+		UserID:      userID,
+		WordText:    word,
+		Translation: translation,
+		Audio:       details.Dictionary.Pronunciation.SourceTextAudio,
+		Details:     &details,
+	}
+}
+
+func savedWordText(lang string, card models.WordCard) string {
+	if card.Details != nil {
+		return formatTranslation(lang, card.Details.Translation, card.Details.Dictionary)
+	}
+	return fmt.Sprintf("**%s** → *%s*", escapeMarkdown(card.WordText), escapeMarkdown(card.Translation))
+}
+
+func savedWord(words []models.WordCard, word string) bool {
+	for _, w := range words {
+		if w.WordText == word {
+			return true
+		}
+	}
+	return false
+}
+
+// isEnglishWord reports whether text looks like an English word or phrase
+// worth translating.
+func isEnglishWord(text string) bool {
+	if text == "" || len(text) > maxLookupWord {
+		return false
+	}
+	for _, r := range text {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || r == ' ' || r == '-' || r == '\'') {
+			return false
+		}
+	}
+	return true
+}
+
+type lookupEntry struct {
+	details   models.WordDetails
+	expiresAt time.Time
+}
+
+type lookupCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	size  int
+	items map[string]lookupEntry
+}
+
+func newLookupCache(ttl time.Duration, size int) *lookupCache {
+	return &lookupCache{
+		ttl:   ttl,
+		size:  size,
+		items: make(map[string]lookupEntry),
+	}
+}
+
+func (c *lookupCache) get(word string) (models.WordDetails, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[word]
+	if !ok || time.Now().After(e.expiresAt) {
+		return models.WordDetails{}, false
+	}
+	return e.details, true
+}
+
+// set stores the details of a word. When the cache is full the expired
+// entries are dropped, and if that isn't enough, an arbitrary one.
+func (c *lookupCache) set(word string, details models.WordDetails) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.items[word]; !ok && len(c.items) >= c.size {
+		now := time.Now()
+		for k, e := range c.items {
+			if now.After(e.expiresAt) {
+				delete(c.items, k)
+			}
+		}
+		for k := range c.items {
+			if len(c.items) < c.size {
+				break
+			}
+			delete(c.items, k)
+		}
+	}
+
+	c.items[word] = lookupEntry{details: details, expiresAt: time.Now().Add(c.ttl)}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordS_LookupWord(t *testing.T) {
+	t.Parallel()
+
+	savedList := models.WordList{Prefix: "apple", Sort: models.WordSortAlpha, PageSize: lookupSavedWords}
+	appleDict := dictEntry("apple", "яблоко")
+	appleDetails := models.WordDetails{
+		Translation: models.MyMemoryTranslationResult{Text: "яблоко"},
+		Dictionary:  appleDict,
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		want    []models.LookupResult
+		wantErr bool
+	}{
+		{
+			name: "translation and saved words",
+			text: " Apple ",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Words(gomock.Any(), int64(1), savedList).Return([]models.WordCard{
+					{UserID: 1, WordText: "applesauce", Translation: "яблочное пюре"},
+				}, 1, nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), "apple").Return(models.MyMemoryTranslationResult{Text: "яблоко"}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "apple").Return(dictEntry("", ""), nil)
+			},
+			want: []models.LookupResult{
+				{
+					Card: models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Details: &appleDetails},
+					Text: formatTranslation(i18n.Default, appleDetails.Translation, appleDetails.Dictionary),
+				},
+				{
+					Card:  models.WordCard{UserID: 1, WordText: "applesauce", Translation: "яблочное пюре"},
+					Text:  "**applesauce** → *яблочное пюре*",
+					Saved: true,
+				},
+			},
+		},
+		{
+			name: "saved word isn't translated again",
+			text: "apple",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Words(gomock.Any(), int64(1), savedList).Return([]models.WordCard{
+					{UserID: 1, WordText: "apple", Translation: "яблоко", Details: &appleDetails},
+				}, 1, nil)
+			},
+			want: []models.LookupResult{{
+				Card:  models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Details: &appleDetails},
+				Text:  formatTranslation(i18n.Default, appleDetails.Translation, appleDetails.Dictionary),
+				Saved: true,
+			}},
+		},
+		{
+			name: "only saved words for other text",
+			text: "ябл",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Words(gomock.Any(), int64(1), gomock.Any()).Return(nil, 0, nil)
+			},
+			want: []models.LookupResult{},
+		},
+		{
+			name: "untranslated word is left out",
+			text: "qwzx",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Words(gomock.Any(), int64(1), gomock.Any()).Return(nil, 0, nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), "qwzx").Return(models.MyMemoryTranslationResult{}, assert.AnError)
+				ma.EXPECT().DictionaryData(gomock.Any(), "qwzx").Return(models.TranslationResponse{}, assert.AnError)
+			},
+			want: []models.LookupResult{},
+		},
+		{
+			name: "empty text",
+			text: "  ",
+		},
+		{
+			name: "repository error",
+			text: "apple",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Words(gomock.Any(), int64(1), gomock.Any()).Return(nil, 0, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wordService := newWordServiceMock(t, ctrl, tt.f)
+
+			got, err := wordService.LookupWord(context.Background(), 1, tt.text, i18n.Default)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWordS_SaveLookupWord(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wordService := newWordServiceMock(t, ctrl, func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+		// The word is looked up once, saving it uses the cached result.
+		ma.EXPECT().TranslateEnToRu(gomock.Any(), "serendipity").Return(models.MyMemoryTranslationResult{Text: "интуитивная прозорливость"}, nil)
+		ma.EXPECT().DictionaryData(gomock.Any(), "serendipity").Return(dictEntry("serendipity", ""), nil)
+		mri.EXPECT().Words(gomock.Any(), int64(1), gomock.Any()).Return(nil, 0, nil)
+		mri.EXPECT().AddWord(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, card models.WordCard) error {
+			assert.Equal(t, int64(2), card.UserID)
+			assert.Equal(t, "serendipity", card.WordText)
+			assert.Equal(t, "интуитивная прозорливость", card.Translation)
+			require.NotNil(t, card.Details)
+			assert.Equal(t, "интуитивная прозорливость", card.Details.Dictionary.DestinationText)
+			assert.True(t, card.Chosen)
+			return nil
+		})
+	})
+
+	found, err := wordService.LookupWord(context.Background(), 1, "serendipity", i18n.Default)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	card, err := wordService.SaveLookupWord(context.Background(), 2, "Serendipity")
+	require.NoError(t, err)
+	assert.Equal(t, "serendipity", card.WordText)
+
+	_, err = wordService.SaveLookupWord(context.Background(), 2, "яблоко")
+	require.Error(t, err)
+}
+
+func Test_lookupCache(t *testing.T) {
+	t.Parallel()
+
+	c := newLookupCache(time.Hour, 2)
+	c.set("a", models.WordDetails{Translation: models.MyMemoryTranslationResult{Text: "а"}})
+	c.set("b", models.WordDetails{})
+	c.set("c", models.WordDetails{})
+
+	assert.Len(t, c.items, 2, "the cache doesn't grow past its size")
+	_, ok := c.get("c")
+	assert.True(t, ok)
+
+	expired := newLookupCache(-time.Second, 2)
+	expired.set("a", models.WordDetails{})
+	_, ok = expired.get("a")
+	assert.False(t, ok)
+}
+
+func Test_isEnglishWord(t *testing.T) {
+	t.Parallel()
+
+	for text, want := range map[string]bool{
+		"apple":         true,
+		"give up":       true,
+		"mother-in-law": true,
+		"don't":         true,
+		"яблоко":        false,
+		"apple1":        false,
+		"":              false,
+		"a very long query that goes on and on and on beyond": false,
+	} {
+		assert.Equal(t, want, isEnglishWord(text), text)
+	}
+}
@@ -79,3 +79,18 @@ func (mr *MockAPIIMockRecorder) TranslateEnToRu(arg0, arg1 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateEnToRu", reflect.TypeOf((*MockAPII)(nil).TranslateEnToRu), arg0, arg1)
 }
+
+// TranslateEnToRuBatch mocks base method.
+func (m *MockAPII) TranslateEnToRuBatch(arg0 context.Context, arg1 []string) ([]models.MyMemoryTranslationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateEnToRuBatch", arg0, arg1)
+	ret0, _ := ret[0].([]models.MyMemoryTranslationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TranslateEnToRuBatch indicates an expected call of TranslateEnToRuBatch.
+func (mr *MockAPIIMockRecorder) TranslateEnToRuBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateEnToRuBatch", reflect.TypeOf((*MockAPII)(nil).TranslateEnToRuBatch), arg0, arg1)
+}
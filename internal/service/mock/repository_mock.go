@@ -7,6 +7,7 @@ package mock_service
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/DanRulev/vocabot.git/internal/models"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,80 @@ func (m *MockRepositoryI) EXPECT() *MockRepositoryIMockRecorder {
 	return m.recorder
 }
 
+// Achievements mocks base method.
+func (m *MockRepositoryI) Achievements(arg0 context.Context, arg1 int64) ([]models.UserAchievement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Achievements", arg0, arg1)
+	ret0, _ := ret[0].([]models.UserAchievement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Achievements indicates an expected call of Achievements.
+func (mr *MockRepositoryIMockRecorder) Achievements(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Achievements", reflect.TypeOf((*MockRepositoryI)(nil).Achievements), arg0, arg1)
+}
+
+// ActivitiesSince mocks base method.
+func (m *MockRepositoryI) ActivitiesSince(arg0 context.Context, arg1 int64, arg2 time.Time, arg3 []models.Activity) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivitiesSince", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivitiesSince indicates an expected call of ActivitiesSince.
+func (mr *MockRepositoryIMockRecorder) ActivitiesSince(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivitiesSince", reflect.TypeOf((*MockRepositoryI)(nil).ActivitiesSince), arg0, arg1, arg2, arg3)
+}
+
+// ActivityCounts mocks base method.
+func (m *MockRepositoryI) ActivityCounts(arg0 context.Context, arg1 int64, arg2 time.Time) ([]models.DayCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivityCounts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.DayCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivityCounts indicates an expected call of ActivityCounts.
+func (mr *MockRepositoryIMockRecorder) ActivityCounts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivityCounts", reflect.TypeOf((*MockRepositoryI)(nil).ActivityCounts), arg0, arg1, arg2)
+}
+
+// ActivityDays mocks base method.
+func (m *MockRepositoryI) ActivityDays(arg0 context.Context, arg1 int64) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivityDays", arg0, arg1)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivityDays indicates an expected call of ActivityDays.
+func (mr *MockRepositoryIMockRecorder) ActivityDays(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivityDays", reflect.TypeOf((*MockRepositoryI)(nil).ActivityDays), arg0, arg1)
+}
+
+// AddGroupResult mocks base method.
+func (m *MockRepositoryI) AddGroupResult(arg0 context.Context, arg1 models.QuizCard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupResult", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGroupResult indicates an expected call of AddGroupResult.
+func (mr *MockRepositoryIMockRecorder) AddGroupResult(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupResult", reflect.TypeOf((*MockRepositoryI)(nil).AddGroupResult), arg0, arg1)
+}
+
 // AddQuizResult mocks base method.
 func (m *MockRepositoryI) AddQuizResult(arg0 context.Context, arg1 models.QuizCard) error {
 	m.ctrl.T.Helper()
@@ -63,6 +138,245 @@ func (mr *MockRepositoryIMockRecorder) AddWord(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWord", reflect.TypeOf((*MockRepositoryI)(nil).AddWord), arg0, arg1)
 }
 
+// AddXP mocks base method.
+func (m *MockRepositoryI) AddXP(arg0 context.Context, arg1 int64, arg2 models.Activity, arg3 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddXP", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddXP indicates an expected call of AddXP.
+func (mr *MockRepositoryIMockRecorder) AddXP(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddXP", reflect.TypeOf((*MockRepositoryI)(nil).AddXP), arg0, arg1, arg2, arg3)
+}
+
+// AudioFileID mocks base method.
+func (m *MockRepositoryI) AudioFileID(arg0 context.Context, arg1, arg2 string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AudioFileID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AudioFileID indicates an expected call of AudioFileID.
+func (mr *MockRepositoryIMockRecorder) AudioFileID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AudioFileID", reflect.TypeOf((*MockRepositoryI)(nil).AudioFileID), arg0, arg1, arg2)
+}
+
+// Ban mocks base method.
+func (m *MockRepositoryI) Ban(arg0 context.Context, arg1, arg2 int64, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ban", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ban indicates an expected call of Ban.
+func (mr *MockRepositoryIMockRecorder) Ban(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ban", reflect.TypeOf((*MockRepositoryI)(nil).Ban), arg0, arg1, arg2, arg3)
+}
+
+// CompleteGoal mocks base method.
+func (m *MockRepositoryI) CompleteGoal(arg0 context.Context, arg1 int64, arg2 time.Time, arg3 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteGoal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteGoal indicates an expected call of CompleteGoal.
+func (mr *MockRepositoryIMockRecorder) CompleteGoal(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteGoal", reflect.TypeOf((*MockRepositoryI)(nil).CompleteGoal), arg0, arg1, arg2, arg3)
+}
+
+// GameStats mocks base method.
+func (m *MockRepositoryI) GameStats(arg0 context.Context, arg1 int64) (models.GameStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GameStats", arg0, arg1)
+	ret0, _ := ret[0].(models.GameStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GameStats indicates an expected call of GameStats.
+func (mr *MockRepositoryIMockRecorder) GameStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GameStats", reflect.TypeOf((*MockRepositoryI)(nil).GameStats), arg0, arg1)
+}
+
+// GlobalStats mocks base method.
+func (m *MockRepositoryI) GlobalStats(arg0 context.Context, arg1, arg2 time.Time) (models.GlobalStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GlobalStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.GlobalStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GlobalStats indicates an expected call of GlobalStats.
+func (mr *MockRepositoryIMockRecorder) GlobalStats(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GlobalStats", reflect.TypeOf((*MockRepositoryI)(nil).GlobalStats), arg0, arg1, arg2)
+}
+
+// HardWords mocks base method.
+func (m *MockRepositoryI) HardWords(arg0 context.Context, arg1 int64) ([]models.HardWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardWords", arg0, arg1)
+	ret0, _ := ret[0].([]models.HardWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HardWords indicates an expected call of HardWords.
+func (mr *MockRepositoryIMockRecorder) HardWords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardWords", reflect.TypeOf((*MockRepositoryI)(nil).HardWords), arg0, arg1)
+}
+
+// IsBanned mocks base method.
+func (m *MockRepositoryI) IsBanned(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockRepositoryIMockRecorder) IsBanned(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*MockRepositoryI)(nil).IsBanned), arg0, arg1)
+}
+
+// Language mocks base method.
+func (m *MockRepositoryI) Language(arg0 context.Context, arg1 int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Language", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Language indicates an expected call of Language.
+func (mr *MockRepositoryIMockRecorder) Language(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Language", reflect.TypeOf((*MockRepositoryI)(nil).Language), arg0, arg1)
+}
+
+// Leaderboard mocks base method.
+func (m *MockRepositoryI) Leaderboard(arg0 context.Context, arg1 int64, arg2 time.Time, arg3 int) ([]models.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Leaderboard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Leaderboard indicates an expected call of Leaderboard.
+func (mr *MockRepositoryIMockRecorder) Leaderboard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leaderboard", reflect.TypeOf((*MockRepositoryI)(nil).Leaderboard), arg0, arg1, arg2, arg3)
+}
+
+// LearnedWords mocks base method.
+func (m *MockRepositoryI) LearnedWords(arg0 context.Context, arg1 int64, arg2 time.Time, arg3 string) ([]models.DayCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LearnedWords", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.DayCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LearnedWords indicates an expected call of LearnedWords.
+func (mr *MockRepositoryIMockRecorder) LearnedWords(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LearnedWords", reflect.TypeOf((*MockRepositoryI)(nil).LearnedWords), arg0, arg1, arg2, arg3)
+}
+
+// MarkWotdSent mocks base method.
+func (m *MockRepositoryI) MarkWotdSent(arg0 context.Context, arg1 int64, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWotdSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWotdSent indicates an expected call of MarkWotdSent.
+func (mr *MockRepositoryIMockRecorder) MarkWotdSent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWotdSent", reflect.TypeOf((*MockRepositoryI)(nil).MarkWotdSent), arg0, arg1, arg2)
+}
+
+// MostMissedWords mocks base method.
+func (m *MockRepositoryI) MostMissedWords(arg0 context.Context, arg1 int64, arg2 time.Time, arg3 int) ([]models.MissedWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MostMissedWords", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.MissedWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MostMissedWords indicates an expected call of MostMissedWords.
+func (mr *MockRepositoryIMockRecorder) MostMissedWords(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MostMissedWords", reflect.TypeOf((*MockRepositoryI)(nil).MostMissedWords), arg0, arg1, arg2, arg3)
+}
+
+// NewWordsSince mocks base method.
+func (m *MockRepositoryI) NewWordsSince(arg0 context.Context, arg1 int64, arg2 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWordsSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewWordsSince indicates an expected call of NewWordsSince.
+func (mr *MockRepositoryIMockRecorder) NewWordsSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWordsSince", reflect.TypeOf((*MockRepositoryI)(nil).NewWordsSince), arg0, arg1, arg2)
+}
+
+// QuizAccuracy mocks base method.
+func (m *MockRepositoryI) QuizAccuracy(arg0 context.Context, arg1 int64, arg2 time.Time, arg3 string) ([]models.DayAccuracy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuizAccuracy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.DayAccuracy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuizAccuracy indicates an expected call of QuizAccuracy.
+func (mr *MockRepositoryIMockRecorder) QuizAccuracy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuizAccuracy", reflect.TypeOf((*MockRepositoryI)(nil).QuizAccuracy), arg0, arg1, arg2, arg3)
+}
+
+// QuizAccuracyByType mocks base method.
+func (m *MockRepositoryI) QuizAccuracyByType(arg0 context.Context, arg1 int64, arg2 time.Time) ([]models.TypeAccuracy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuizAccuracyByType", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.TypeAccuracy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuizAccuracyByType indicates an expected call of QuizAccuracyByType.
+func (mr *MockRepositoryIMockRecorder) QuizAccuracyByType(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuizAccuracyByType", reflect.TypeOf((*MockRepositoryI)(nil).QuizAccuracyByType), arg0, arg1, arg2)
+}
+
 // QuizStats mocks base method.
 func (m *MockRepositoryI) QuizStats(arg0 context.Context, arg1 int64) (models.QuizStats, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +407,270 @@ func (mr *MockRepositoryIMockRecorder) RandomUnknownWord(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomUnknownWord", reflect.TypeOf((*MockRepositoryI)(nil).RandomUnknownWord), arg0, arg1)
 }
 
+// RandomWords mocks base method.
+func (m *MockRepositoryI) RandomWords(arg0 context.Context, arg1 int64, arg2 string, arg3 int) ([]models.QuizWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RandomWords", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.QuizWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RandomWords indicates an expected call of RandomWords.
+func (mr *MockRepositoryIMockRecorder) RandomWords(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomWords", reflect.TypeOf((*MockRepositoryI)(nil).RandomWords), arg0, arg1, arg2, arg3)
+}
+
+// SaveAudioFileID mocks base method.
+func (m *MockRepositoryI) SaveAudioFileID(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAudioFileID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAudioFileID indicates an expected call of SaveAudioFileID.
+func (mr *MockRepositoryIMockRecorder) SaveAudioFileID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAudioFileID", reflect.TypeOf((*MockRepositoryI)(nil).SaveAudioFileID), arg0, arg1, arg2, arg3)
+}
+
+// SaveWordOfDay mocks base method.
+func (m *MockRepositoryI) SaveWordOfDay(arg0 context.Context, arg1 models.WordOfDay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWordOfDay", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWordOfDay indicates an expected call of SaveWordOfDay.
+func (mr *MockRepositoryIMockRecorder) SaveWordOfDay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWordOfDay", reflect.TypeOf((*MockRepositoryI)(nil).SaveWordOfDay), arg0, arg1)
+}
+
+// SavedWords mocks base method.
+func (m *MockRepositoryI) SavedWords(arg0 context.Context, arg1 int64, arg2 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavedWords", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavedWords indicates an expected call of SavedWords.
+func (mr *MockRepositoryIMockRecorder) SavedWords(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavedWords", reflect.TypeOf((*MockRepositoryI)(nil).SavedWords), arg0, arg1, arg2)
+}
+
+// SetLanguage mocks base method.
+func (m *MockRepositoryI) SetLanguage(arg0 context.Context, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLanguage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLanguage indicates an expected call of SetLanguage.
+func (mr *MockRepositoryIMockRecorder) SetLanguage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLanguage", reflect.TypeOf((*MockRepositoryI)(nil).SetLanguage), arg0, arg1, arg2)
+}
+
+// SubscribeWotd mocks base method.
+func (m *MockRepositoryI) SubscribeWotd(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeWotd", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeWotd indicates an expected call of SubscribeWotd.
+func (mr *MockRepositoryIMockRecorder) SubscribeWotd(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeWotd", reflect.TypeOf((*MockRepositoryI)(nil).SubscribeWotd), arg0, arg1, arg2)
+}
+
+// TimeToLearn mocks base method.
+func (m *MockRepositoryI) TimeToLearn(arg0 context.Context, arg1 int64, arg2 time.Time) (models.LearnTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeToLearn", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.LearnTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimeToLearn indicates an expected call of TimeToLearn.
+func (mr *MockRepositoryIMockRecorder) TimeToLearn(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeToLearn", reflect.TypeOf((*MockRepositoryI)(nil).TimeToLearn), arg0, arg1, arg2)
+}
+
+// TotalXP mocks base method.
+func (m *MockRepositoryI) TotalXP(arg0 context.Context, arg1 int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalXP", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalXP indicates an expected call of TotalXP.
+func (mr *MockRepositoryIMockRecorder) TotalXP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalXP", reflect.TypeOf((*MockRepositoryI)(nil).TotalXP), arg0, arg1)
+}
+
+// TouchUser mocks base method.
+func (m *MockRepositoryI) TouchUser(arg0 context.Context, arg1 models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchUser indicates an expected call of TouchUser.
+func (mr *MockRepositoryIMockRecorder) TouchUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchUser", reflect.TypeOf((*MockRepositoryI)(nil).TouchUser), arg0, arg1)
+}
+
+// Unban mocks base method.
+func (m *MockRepositoryI) Unban(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unban", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unban indicates an expected call of Unban.
+func (mr *MockRepositoryIMockRecorder) Unban(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unban", reflect.TypeOf((*MockRepositoryI)(nil).Unban), arg0, arg1)
+}
+
+// UnlockAchievement mocks base method.
+func (m *MockRepositoryI) UnlockAchievement(arg0 context.Context, arg1 int64, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAchievement", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockAchievement indicates an expected call of UnlockAchievement.
+func (mr *MockRepositoryIMockRecorder) UnlockAchievement(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAchievement", reflect.TypeOf((*MockRepositoryI)(nil).UnlockAchievement), arg0, arg1, arg2)
+}
+
+// UnsubscribeWotd mocks base method.
+func (m *MockRepositoryI) UnsubscribeWotd(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeWotd", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsubscribeWotd indicates an expected call of UnsubscribeWotd.
+func (mr *MockRepositoryIMockRecorder) UnsubscribeWotd(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeWotd", reflect.TypeOf((*MockRepositoryI)(nil).UnsubscribeWotd), arg0, arg1)
+}
+
+// UpdateUserSettings mocks base method.
+func (m *MockRepositoryI) UpdateUserSettings(arg0 context.Context, arg1 int64, arg2 models.UserSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSettings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserSettings indicates an expected call of UpdateUserSettings.
+func (mr *MockRepositoryIMockRecorder) UpdateUserSettings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSettings", reflect.TypeOf((*MockRepositoryI)(nil).UpdateUserSettings), arg0, arg1, arg2)
+}
+
+// UserIDs mocks base method.
+func (m *MockRepositoryI) UserIDs(arg0 context.Context) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserIDs", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserIDs indicates an expected call of UserIDs.
+func (mr *MockRepositoryIMockRecorder) UserIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserIDs", reflect.TypeOf((*MockRepositoryI)(nil).UserIDs), arg0)
+}
+
+// UserInfo mocks base method.
+func (m *MockRepositoryI) UserInfo(arg0 context.Context, arg1 int64) (models.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserInfo", arg0, arg1)
+	ret0, _ := ret[0].(models.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserInfo indicates an expected call of UserInfo.
+func (mr *MockRepositoryIMockRecorder) UserInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserInfo", reflect.TypeOf((*MockRepositoryI)(nil).UserInfo), arg0, arg1)
+}
+
+// UserSettings mocks base method.
+func (m *MockRepositoryI) UserSettings(arg0 context.Context, arg1 int64) (models.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSettings", arg0, arg1)
+	ret0, _ := ret[0].(models.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSettings indicates an expected call of UserSettings.
+func (mr *MockRepositoryIMockRecorder) UserSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSettings", reflect.TypeOf((*MockRepositoryI)(nil).UserSettings), arg0, arg1)
+}
+
+// Word mocks base method.
+func (m *MockRepositoryI) Word(arg0 context.Context, arg1 int64, arg2 string) (models.WordCard, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Word", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.WordCard)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Word indicates an expected call of Word.
+func (mr *MockRepositoryIMockRecorder) Word(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Word", reflect.TypeOf((*MockRepositoryI)(nil).Word), arg0, arg1, arg2)
+}
+
+// WordOfDay mocks base method.
+func (m *MockRepositoryI) WordOfDay(arg0 context.Context, arg1 time.Time, arg2 string) (models.WordOfDay, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WordOfDay", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.WordOfDay)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WordOfDay indicates an expected call of WordOfDay.
+func (mr *MockRepositoryIMockRecorder) WordOfDay(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WordOfDay", reflect.TypeOf((*MockRepositoryI)(nil).WordOfDay), arg0, arg1, arg2)
+}
+
 // WordStat mocks base method.
 func (m *MockRepositoryI) WordStat(arg0 context.Context, arg1 int64) (models.WordStats, error) {
 	m.ctrl.T.Helper()
@@ -109,9 +687,9 @@ func (mr *MockRepositoryIMockRecorder) WordStat(arg0, arg1 interface{}) *gomock.
 }
 
 // Words mocks base method.
-func (m *MockRepositoryI) Words(arg0 context.Context, arg1 int64, arg2 int, arg3 bool) ([]models.WordCard, int, error) {
+func (m *MockRepositoryI) Words(arg0 context.Context, arg1 int64, arg2 models.WordList) ([]models.WordCard, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Words", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "Words", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]models.WordCard)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -119,7 +697,37 @@ func (m *MockRepositoryI) Words(arg0 context.Context, arg1 int64, arg2 int, arg3
 }
 
 // Words indicates an expected call of Words.
-func (mr *MockRepositoryIMockRecorder) Words(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockRepositoryIMockRecorder) Words(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Words", reflect.TypeOf((*MockRepositoryI)(nil).Words), arg0, arg1, arg2)
+}
+
+// WotdSubscribed mocks base method.
+func (m *MockRepositoryI) WotdSubscribed(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WotdSubscribed", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WotdSubscribed indicates an expected call of WotdSubscribed.
+func (mr *MockRepositoryIMockRecorder) WotdSubscribed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WotdSubscribed", reflect.TypeOf((*MockRepositoryI)(nil).WotdSubscribed), arg0, arg1)
+}
+
+// WotdSubscribers mocks base method.
+func (m *MockRepositoryI) WotdSubscribers(arg0 context.Context, arg1 time.Time) ([]models.WotdSubscriber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WotdSubscribers", arg0, arg1)
+	ret0, _ := ret[0].([]models.WotdSubscriber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WotdSubscribers indicates an expected call of WotdSubscribers.
+func (mr *MockRepositoryIMockRecorder) WotdSubscribers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Words", reflect.TypeOf((*MockRepositoryI)(nil).Words), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WotdSubscribers", reflect.TypeOf((*MockRepositoryI)(nil).WotdSubscribers), arg0, arg1)
 }
@@ -6,23 +6,26 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"math/rand"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
+	"github.com/DanRulev/vocabot.git/internal/i18n"
 	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
 	"go.uber.org/zap"
 )
 
 type QuizRI interface {
 	AddQuizResult(ctx context.Context, result models.QuizCard) error
 	QuizStats(ctx context.Context, userID int64) (models.QuizStats, error)
+	HardWords(ctx context.Context, userID int64) ([]models.HardWord, error)
 }
 
 type AuxiliaryWord interface {
 	AddWord(ctx context.Context, word models.WordCard) error
 	RandomUnknownWord(ctx context.Context, userID int64) (models.WordCard, error)
+	DistractorRI
 }
 
 type QuizS struct {
@@ -31,118 +34,129 @@ type QuizS struct {
 	vercel         VercelAPII
 	repo           QuizRI
 	aux            AuxiliaryWord
+	distractors    DistractorI
 	log            *zap.Logger
+	now            func() time.Time
 }
 
-func NewQuizService(api APII, repo QuizRI, aux AuxiliaryWord, log *zap.Logger) *QuizS {
+func NewQuizService(api APII, repo QuizRI, aux AuxiliaryWord, distractors DistractorI, log *zap.Logger) *QuizS {
 	return &QuizS{
 		myMemory:       api,
 		pythonAnyWhere: api,
 		vercel:         api,
 		repo:           repo,
 		aux:            aux,
+		distractors:    distractors,
 		log:            log,
+		now:            time.Now,
 	}
 }
 
+// NewQuiz asks for the translation of a random word. The wrong options come
+// from the distractor strategy, so only the word itself costs API calls.
 func (q *QuizS) NewQuiz(ctx context.Context, userID int64) (string, map[string]bool, error) {
-	var target string
+	var errs []error
+
+	for attempts := 0; attempts < wordQuizAttempts; attempts++ {
+		target, err := q.quizTarget(ctx)
+		if errors.Is(err, ratelimit.ErrLimited) {
+			return "", nil, err
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		distractors, err := q.distractors.Distractors(ctx, userID, target, quizOptions-1)
+		if err != nil {
+			q.log.Warn("failed to get distractors", zap.Int64("user_id", userID), zap.Error(err))
+			return "", nil, err
+		}
+
+		options := map[string]bool{target.Translation: true}
+		for _, d := range distractors {
+			if _, ok := options[d.Translation]; !ok {
+				options[d.Translation] = false
+			}
+		}
 
-	quiz := make(map[string]bool)
-	used := make(map[string]bool)
+		if len(options) < quizOptions {
+			q.log.Warn("not enough unique translations", zap.Int("got", len(options)), zap.Int("required", quizOptions))
+			return "", nil, errors.New("not enough unique translations")
+		}
 
-	var (
-		mu          sync.Mutex
-		wg          sync.WaitGroup
-		errs        []error
-		maxAttempts = 5
-	)
+		return target.Word, options, nil
+	}
 
-	truePosition, err := randomPosition(4)
+	q.log.Warn("errors during NewQuiz", zap.Int("error_count", len(errs)), zap.Errors("errors", errs))
+	return "", nil, errors.New("no word for the quiz")
+}
+
+// quizTarget picks a random word with its translations, part of speech and
+// synonyms, which the distractors are matched against.
+func (q *QuizS) quizTarget(ctx context.Context) (models.QuizWord, error) {
+	word, err := q.randomWord(ctx)
 	if err != nil {
-		q.log.Warn("crypto/rand failed, using math/rand fallback", zap.Error(err))
-		truePosition = rand.Intn(4)
+		return models.QuizWord{}, err
 	}
 
-	for i := 0; i < 4; i++ {
-		correctness := (truePosition == i)
-		wg.Add(1)
-		go func(correctness bool) {
-			defer wg.Done()
-
-			var translation string
-
-			for attempts := 0; attempts < maxAttempts; attempts++ {
-				word, err := q.vercel.RandomWord(ctx)
-				if err != nil {
-					mu.Lock()
-					errs = append(errs, fmt.Errorf("RandomWord failed: %w", err))
-					mu.Unlock()
-					continue
-				}
-				if correctness {
-					mu.Lock()
-					target = word
-					mu.Unlock()
-				}
-
-				trans, err := q.myMemory.TranslateEnToRu(ctx, word)
-				if err != nil {
-					mu.Lock()
-					errs = append(errs, fmt.Errorf("TranslateWord failed: %w", err))
-					mu.Unlock()
-					continue
-				}
-
-				if trans.Text == "" {
-					dictData, err := q.pythonAnyWhere.DictionaryData(ctx, word)
-					if err != nil {
-						mu.Lock()
-						errs = append(errs, fmt.Errorf("DictionaryData failed: %w", err))
-						mu.Unlock()
-						continue
-					}
-					if dictData.DestinationText == "" {
-						mu.Lock()
-						errs = append(errs, fmt.Errorf("translation empty: %v", word))
-						mu.Unlock()
-						continue
-					}
-					translation = dictData.DestinationText
-				} else {
-					translation = trans.Text
-				}
-
-				mu.Lock()
-				if !used[translation] {
-					used[translation] = true
-					mu.Unlock()
-					break
-				}
-				mu.Unlock()
-			}
+	trans, err := q.myMemory.TranslateEnToRu(ctx, word)
+	if err != nil {
+		return models.QuizWord{}, fmt.Errorf("TranslateWord failed: %w", err)
+	}
+
+	target := models.QuizWord{Word: word, Translation: trans.Text, Alternatives: trans.Alternatives}
+
+	target, err = q.withDictionaryData(ctx, target)
+	if err != nil {
+		if target.Translation == "" {
+			return models.QuizWord{}, fmt.Errorf("DictionaryData failed: %w", err)
+		}
+		// The quiz works without it, the options are just less alike.
+		q.log.Debug("no dictionary data for quiz word", zap.String("word", word), zap.Error(err))
+		return target, nil
+	}
 
-			mu.Lock()
-			quiz[translation] = correctness
-			mu.Unlock()
-		}(correctness)
+	if target.Translation == "" {
+		return models.QuizWord{}, fmt.Errorf("translation empty: %v", word)
 	}
 
-	wg.Wait()
+	return target, nil
+}
 
-	if len(errs) > 0 {
-		q.log.Warn("errors during NewQuiz", zap.Int("error_count", len(errs)), zap.Errors("errors", errs))
+// withDictionaryData adds the part of speech, synonyms and other translations
+// of the target from the dictionary. On error the target is returned as is.
+func (q *QuizS) withDictionaryData(ctx context.Context, target models.QuizWord) (models.QuizWord, error) {
+	dictData, err := q.pythonAnyWhere.DictionaryData(ctx, target.Word)
+	if err != nil {
+		return target, err
 	}
 
-	if len(quiz) < 4 {
-		q.log.Warn("not enough unique translations", zap.Int("got", len(quiz)), zap.Int("required", 4))
-		return "", nil, errors.New("not enough unique translations")
+	if target.Translation == "" {
+		target.Translation = dictData.DestinationText
 	}
 
-	return target, quiz, nil
+	target.Alternatives = append(target.Alternatives, dictData.Translations.PossibleTranslations...)
+	for _, def := range dictData.Definitions {
+		if target.PartOfSpeech == "" {
+			target.PartOfSpeech = def.PartOfSpeech
+		}
+		for _, syms := range def.Synonyms {
+			for _, syn := range syms {
+				target.Synonyms = append(target.Synonyms, strings.ToLower(syn))
+			}
+		}
+	}
+
+	return target, nil
 }
 
 func (q *QuizS) AddQuizResult(ctx context.Context, result models.QuizCard) error {
+	// A private chat has the id of the user.
+	if result.ChatID == 0 {
+		result.ChatID = result.UserID
+	}
+
 	err := q.aux.AddWord(ctx, models.WordCard{
 		UserID:      result.UserID,
 		WordText:    result.Word,
@@ -155,28 +169,28 @@ func (q *QuizS) AddQuizResult(ctx context.Context, result models.QuizCard) error
 	return q.repo.AddQuizResult(ctx, result)
 }
 
-func (q *QuizS) QuizStats(ctx context.Context, userID int64) (string, error) {
+func (q *QuizS) QuizStats(ctx context.Context, userID int64, lang string) (string, error) {
 	stats, err := q.repo.QuizStats(ctx, userID)
 	if err != nil {
 		q.log.Warn("failed to get quiz stats", zap.Int64("user_id", userID), zap.Error(err))
 		return "", err
 	}
 
-	return quizStatsFormat(stats), nil
+	return quizStatsFormat(lang, stats), nil
 }
 
-func quizStatsFormat(stats models.QuizStats) string {
+func quizStatsFormat(lang string, stats models.QuizStats) string {
 	var sb strings.Builder
 
-	sb.WriteString("📚 *Всего попыток*: **")
+	sb.WriteString("📚 *" + i18n.T(lang, "stats.quiz_total") + "*: **")
 	sb.WriteString(strconv.Itoa(stats.TotalCount))
 	sb.WriteString("**\n\n")
 
-	sb.WriteString("📚 *Удачных*: **")
+	sb.WriteString("📚 *" + i18n.T(lang, "stats.quiz_right") + "*: **")
 	sb.WriteString(strconv.Itoa(stats.RightCount))
 	sb.WriteString("**\n\n")
 
-	sb.WriteString("📚 *Не удачных*: **")
+	sb.WriteString("📚 *" + i18n.T(lang, "stats.quiz_wrong") + "*: **")
 	sb.WriteString(strconv.Itoa(stats.WrongCount))
 	sb.WriteString("**")
 
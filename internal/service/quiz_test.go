@@ -3,9 +3,10 @@ package service
 import (
 	"context"
 	"errors"
-	"fmt"
 	"testing"
+	"time"
 
+	"github.com/DanRulev/vocabot.git/internal/i18n"
 	"github.com/DanRulev/vocabot.git/internal/models"
 	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
 	"github.com/golang/mock/gomock"
@@ -29,234 +30,91 @@ func newQuizServiceMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*m
 		vercel:         api,
 		repo:           repo,
 		aux:            repo,
+		distractors:    newTestDistractors(repo),
 		log:            log,
+		now:            func() time.Time { return statsNow },
 	}
 }
 
 func TestQuizS_NewQuiz(t *testing.T) {
 	t.Parallel()
 
-	type args struct {
-		ctx    context.Context
-		userID int64
-	}
+	bigDict := dictDefinition("big", "большой", "adjective", "of large size", "large")
 
 	tests := []struct {
-		name    string
-		args    args
-		f       func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
-		wantErr bool
+		name     string
+		f        func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		wantWord string
+		want     map[string]bool
+		wantErr  bool
 	}{
 		{
 			name: "success",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("home", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("sun", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("night", nil)
-
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{
-					Text: "привет",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "home").Return(models.MyMemoryTranslationResult{
-					Text: "дом",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "sun").Return(models.MyMemoryTranslationResult{
-					Text: "солнце",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "night").Return(models.MyMemoryTranslationResult{
-					Text: "ночь",
-				}, nil)
-
+				ma.EXPECT().RandomWord(gomock.Any()).Return("Big", nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), "big").Return(models.MyMemoryTranslationResult{Text: "большой"}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "big").Return(bigDict, nil)
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil)
 			},
-			wantErr: false,
+			wantWord: "big",
+			want:     map[string]bool{"большой": true, "красный": false, "старый": false, "маленький": false},
 		},
 		{
-			name: "success: vercel.RandomWord returns error",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
+			name: "success: RandomWord returns error",
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
 				ma.EXPECT().RandomWord(gomock.Any()).Return("", errors.New("service unavailable"))
-				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("home", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("sun", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("night", nil)
-
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{
-					Text: "привет",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "home").Return(models.MyMemoryTranslationResult{
-					Text: "дом",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "sun").Return(models.MyMemoryTranslationResult{
-					Text: "солнце",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "night").Return(models.MyMemoryTranslationResult{
-					Text: "ночь",
-				}, nil)
+				ma.EXPECT().RandomWord(gomock.Any()).Return("big", nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), "big").Return(models.MyMemoryTranslationResult{Text: "большой"}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "big").Return(bigDict, nil)
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil)
 			},
-			wantErr: false,
+			wantWord: "big",
+			want:     map[string]bool{"большой": true, "красный": false, "старый": false, "маленький": false},
 		},
 		{
-			name: "success: pythonAnyWhere.TranslateEnToRu returns error",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
+			name: "success: translation from the dictionary",
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("home", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("sun", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("night", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("night", nil)
-
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{
-					Text: "привет",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "home").Return(models.MyMemoryTranslationResult{
-					Text: "дом",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "sun").Return(models.MyMemoryTranslationResult{
-					Text: "солнце",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "night").Return(models.MyMemoryTranslationResult{
-					Text: "",
-				}, errors.New("service unavailable"))
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "night").Return(models.MyMemoryTranslationResult{
-					Text: "ночь",
-				}, nil)
+				ma.EXPECT().RandomWord(gomock.Any()).Return("big", nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), "big").Return(models.MyMemoryTranslationResult{}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "big").Return(bigDict, nil)
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil)
 			},
-			wantErr: false,
+			wantWord: "big",
+			want:     map[string]bool{"большой": true, "красный": false, "старый": false, "маленький": false},
 		},
 		{
-			name: "success: skip empty translations and retry",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
+			name: "success: alternative translations aren't options",
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("home", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("sun", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("night", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("night", nil)
-
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{
-					Text: "привет",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "home").Return(models.MyMemoryTranslationResult{
-					Text: "дом",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "sun").Return(models.MyMemoryTranslationResult{
-					Text: "солнце",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "night").Return(models.MyMemoryTranslationResult{}, nil)
-				ma.EXPECT().DictionaryData(gomock.Any(), gomock.Any()).Return(models.TranslationResponse{}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "night").Return(models.MyMemoryTranslationResult{
-					Text: "ночь",
-				}, nil)
-
+				ma.EXPECT().RandomWord(gomock.Any()).Return("big", nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), "big").
+					Return(models.MyMemoryTranslationResult{Text: "большой", Alternatives: []string{"старый"}}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "big").Return(models.TranslationResponse{}, errors.New("service unavailable"))
+				mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil)
 			},
-			wantErr: false,
-		},
-		{
-			name: "success: avoid duplicate translations",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
-			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("home", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("sun", nil)
-				ma.EXPECT().RandomWord(gomock.Any()).Return("night", nil)
-
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{
-					Text: "привет",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{
-					Text: "привет",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "home").Return(models.MyMemoryTranslationResult{
-					Text: "дом",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "sun").Return(models.MyMemoryTranslationResult{
-					Text: "солнце",
-				}, nil)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "night").Return(models.MyMemoryTranslationResult{
-					Text: "ночь",
-				}, nil)
-			},
-			wantErr: false,
+			wantWord: "big",
+			want:     map[string]bool{"большой": true, "красный": false, "маленький": false, "крупный": false},
 		},
 		{
 			name: "error: RandomWord fails",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
-			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				ma.EXPECT().RandomWord(gomock.Any()).Return("", errors.New("service down")).Times(20)
-			},
-			wantErr: true,
-		},
-		{
-			name: "error: RandomWord fails",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				ma.EXPECT().RandomWord(gomock.Any()).Return("", errors.New("service down")).Times(20)
+				ma.EXPECT().RandomWord(gomock.Any()).Return("", errors.New("service down")).Times(wordQuizAttempts)
 			},
 			wantErr: true,
 		},
 		{
 			name: "error: TranslateEnToRu fails",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil).Times(20)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{}, errors.New("translation failed")).Times(20)
+				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil).Times(wordQuizAttempts)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{}, errors.New("translation failed")).Times(wordQuizAttempts)
 			},
 			wantErr: true,
 		},
 		{
-			name: "error: empty translates3",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
-			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil).Times(20)
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), gomock.Any()).Return(models.MyMemoryTranslationResult{}, nil).Times(20)
-				ma.EXPECT().DictionaryData(gomock.Any(), gomock.Any()).Return(models.TranslationResponse{}, nil).Times(20)
-			},
-			wantErr: true,
-		},
-		{
-			name: "error: not enough unique translations",
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
+			name: "error: empty translations",
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				for i := range 16 {
-					ma.EXPECT().RandomWord(gomock.Any()).Return(fmt.Sprintf("hello%d", i), nil)
-
-				}
-				ma.EXPECT().TranslateEnToRu(gomock.Any(), gomock.Any()).Return(models.MyMemoryTranslationResult{
-					Text: "привет",
-				}, nil).Times(16)
+				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil).Times(wordQuizAttempts)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), gomock.Any()).Return(models.MyMemoryTranslationResult{}, nil).Times(wordQuizAttempts)
+				ma.EXPECT().DictionaryData(gomock.Any(), gomock.Any()).Return(models.TranslationResponse{}, nil).Times(wordQuizAttempts)
 			},
 			wantErr: true,
 		},
@@ -271,30 +129,17 @@ func TestQuizS_NewQuiz(t *testing.T) {
 
 			quizService := newQuizServiceMock(t, ctrl, tt.f)
 
-			target, quiz, err := quizService.NewQuiz(tt.args.ctx, tt.args.userID)
-
+			word, options, err := quizService.NewQuiz(context.Background(), 1)
 			if tt.wantErr {
 				require.Error(t, err)
-				assert.Empty(t, target)
-				assert.Nil(t, quiz)
+				assert.Empty(t, word)
+				assert.Nil(t, options)
 				return
 			}
 
 			require.NoError(t, err)
-			assert.NotEmpty(t, target)
-			assert.Len(t, quiz, 4)
-
-			var trueCnt, falseCnt int
-			for _, v := range quiz {
-				if v {
-					trueCnt++
-				} else {
-					falseCnt++
-				}
-			}
-
-			assert.Equal(t, trueCnt, 1)
-			assert.Equal(t, falseCnt, 3)
+			assert.Equal(t, tt.wantWord, word)
+			assert.Equal(t, tt.want, options)
 		})
 	}
 }
@@ -309,6 +154,9 @@ func TestQuizS_AddQuizResult(t *testing.T) {
 		Type:        "quiz",
 		IsCorrect:   true,
 	}
+	// Results without a chat come from the user's private chat.
+	saved := result
+	saved.ChatID = 1
 
 	type args struct {
 		ctx    context.Context
@@ -328,7 +176,7 @@ func TestQuizS_AddQuizResult(t *testing.T) {
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
 				mri.EXPECT().AddWord(gomock.Any(), gomock.Any()).Return(nil)
-				mri.EXPECT().AddQuizResult(gomock.Any(), result).Return(nil)
+				mri.EXPECT().AddQuizResult(gomock.Any(), saved).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -340,7 +188,7 @@ func TestQuizS_AddQuizResult(t *testing.T) {
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
 				mri.EXPECT().AddWord(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
-				mri.EXPECT().AddQuizResult(gomock.Any(), result).Return(nil)
+				mri.EXPECT().AddQuizResult(gomock.Any(), saved).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -352,10 +200,21 @@ func TestQuizS_AddQuizResult(t *testing.T) {
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
 				mri.EXPECT().AddWord(gomock.Any(), gomock.Any()).Return(nil)
-				mri.EXPECT().AddQuizResult(gomock.Any(), result).Return(errors.New("failed to save quiz result"))
+				mri.EXPECT().AddQuizResult(gomock.Any(), saved).Return(errors.New("failed to save quiz result"))
 			},
 			wantErr: true,
 		},
+		{
+			name: "success: quiz asked in a group keeps its chat",
+			args: args{
+				ctx:    context.Background(),
+				result: models.QuizCard{UserID: 1, ChatID: -100, Word: "hello", Translation: "привет", Type: "quiz"},
+			},
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().AddWord(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().AddQuizResult(gomock.Any(), models.QuizCard{UserID: 1, ChatID: -100, Word: "hello", Translation: "привет", Type: "quiz"}).Return(nil)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -383,6 +242,7 @@ func TestQuizS_QuizStats(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		userID int64
+		lang   string
 	}
 	tests := []struct {
 		name    string
@@ -396,6 +256,7 @@ func TestQuizS_QuizStats(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: 1,
+				lang:   i18n.Default,
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
 				mri.EXPECT().QuizStats(gomock.Any(), gomock.Any()).Return(models.QuizStats{
@@ -411,11 +272,33 @@ func TestQuizS_QuizStats(t *testing.T) {
 📚 *Не удачных*: **3**`,
 			wantErr: false,
 		},
+		{
+			name: "success: english",
+			args: args{
+				ctx:    context.Background(),
+				userID: 1,
+				lang:   "en",
+			},
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().QuizStats(gomock.Any(), gomock.Any()).Return(models.QuizStats{
+					TotalCount: 10,
+					RightCount: 7,
+					WrongCount: 3,
+				}, nil)
+			},
+			want: `📚 *Attempts*: **10**
+
+📚 *Correct*: **7**
+
+📚 *Wrong*: **3**`,
+			wantErr: false,
+		},
 		{
 			name: "error: repo failure",
 			args: args{
 				ctx:    context.Background(),
 				userID: 1,
+				lang:   i18n.Default,
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
 				mri.EXPECT().QuizStats(gomock.Any(), gomock.Any()).Return(models.QuizStats{}, errors.New("database unreachable"))
@@ -433,7 +316,7 @@ func TestQuizS_QuizStats(t *testing.T) {
 
 			quizService := newQuizServiceMock(t, ctrl, tt.f)
 
-			got, err := quizService.QuizStats(tt.args.ctx, tt.args.userID)
+			got, err := quizService.QuizStats(tt.args.ctx, tt.args.userID, tt.args.lang)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -9,6 +9,7 @@ import (
 
 type MyMemoryAPII interface {
 	TranslateEnToRu(ctx context.Context, text string) (models.MyMemoryTranslationResult, error)
+	TranslateEnToRuBatch(ctx context.Context, texts []string) ([]models.MyMemoryTranslationResult, error)
 }
 
 type PythonAnyWhereAPII interface {
@@ -19,6 +20,12 @@ type VercelAPII interface {
 	RandomWord(ctx context.Context) (string, error)
 }
 
+// AudioSourceI produces a word's pronunciation, from the dictionary's url or
+// on its own.
+type AudioSourceI interface {
+	Audio(ctx context.Context, word, url string) (models.Audio, error)
+}
+
 type APII interface {
 	MyMemoryAPII
 	PythonAnyWhereAPII
@@ -29,16 +36,40 @@ type RepositoryI interface {
 	AuxiliaryWord
 	QuizRI
 	WordRI
+	SettingsRI
+	StatsRI
+	WotdRI
+	AdminRI
+	UsersRI
+	GameRI
+	GroupRI
+	AudioRI
 }
 
 type Service struct {
 	*WordS
 	*QuizS
+	*SettingsS
+	*StatsS
+	*WotdS
+	*AdminS
+	*UsersS
+	*GameS
+	*GroupS
+	*AudioS
 }
 
-func InitServices(api APII, repo RepositoryI, log *zap.Logger) *Service {
+func InitServices(api APII, audio AudioSourceI, repo RepositoryI, log *zap.Logger) *Service {
 	return &Service{
-		WordS: NewWordService(api, repo, log),
-		QuizS: NewQuizService(api, repo, repo, log),
+		WordS:     NewWordService(api, repo, log),
+		QuizS:     NewQuizService(api, repo, repo, NewRankedDistractors(repo, quizWords, log), log),
+		SettingsS: NewSettingsService(repo, log),
+		StatsS:    NewStatsService(repo, log),
+		WotdS:     NewWotdService(api, repo, log),
+		AdminS:    NewAdminService(repo, log),
+		UsersS:    NewUsersService(repo, log),
+		GameS:     NewGameService(repo, repo, repo, log),
+		GroupS:    NewGroupService(repo, log),
+		AudioS:    NewAudioService(api, audio, repo, log),
 	}
 }
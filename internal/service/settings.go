@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"go.uber.org/zap"
+)
+
+type SettingsRI interface {
+	Language(ctx context.Context, userID int64) (string, error)
+	SetLanguage(ctx context.Context, userID int64, lang string) error
+}
+
+type SettingsS struct {
+	repo SettingsRI
+	log  *zap.Logger
+}
+
+func NewSettingsService(repo SettingsRI, log *zap.Logger) *SettingsS {
+	return &SettingsS{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// Language returns the interface language chosen by the user, or "" if the
+// user hasn't picked one.
+func (s *SettingsS) Language(ctx context.Context, userID int64) (string, error) {
+	lang, err := s.repo.Language(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if lang != "" && !i18n.Supported(lang) {
+		s.log.Warn("stored language is not supported", zap.Int64("user_id", userID), zap.String("lang", lang))
+		return "", nil
+	}
+
+	return lang, nil
+}
+
+func (s *SettingsS) SetLanguage(ctx context.Context, userID int64, lang string) error {
+	if !i18n.Supported(lang) {
+		return fmt.Errorf("unsupported language %q", lang)
+	}
+
+	return s.repo.SetLanguage(ctx, userID, lang)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newSettingsServiceMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *SettingsS {
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return &SettingsS{
+		repo: repo,
+		log:  zap.NewNop(),
+	}
+}
+
+func TestSettingsS_Language(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Language(gomock.Any(), int64(1)).Return("en", nil)
+			},
+			want: "en",
+		},
+		{
+			name: "not set",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Language(gomock.Any(), int64(1)).Return("", nil)
+			},
+			want: "",
+		},
+		{
+			name: "unsupported stored language",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Language(gomock.Any(), int64(1)).Return("xx", nil)
+			},
+			want: "",
+		},
+		{
+			name: "repo error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Language(gomock.Any(), int64(1)).Return("", errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			settings := newSettingsServiceMock(t, ctrl, tt.f)
+
+			got, err := settings.Language(context.Background(), 1)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSettingsS_SetLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		lang    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			lang: "uk",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().SetLanguage(gomock.Any(), int64(1), "uk").Return(nil)
+			},
+		},
+		{
+			name:    "unsupported language",
+			lang:    "xx",
+			wantErr: true,
+		},
+		{
+			name: "repo error",
+			lang: "en",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().SetLanguage(gomock.Any(), int64(1), "en").Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			settings := newSettingsServiceMock(t, ctrl, tt.f)
+
+			err := settings.SetLanguage(context.Background(), 1, tt.lang)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
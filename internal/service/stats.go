@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+const mostMissedLimit = 5
+
+type StatsRI interface {
+	ActivityDays(ctx context.Context, userID int64) ([]time.Time, error)
+	LearnedWords(ctx context.Context, userID int64, since time.Time, bucket string) ([]models.DayCount, error)
+	QuizAccuracy(ctx context.Context, userID int64, since time.Time, bucket string) ([]models.DayAccuracy, error)
+	QuizAccuracyByType(ctx context.Context, userID int64, since time.Time) ([]models.TypeAccuracy, error)
+	MostMissedWords(ctx context.Context, userID int64, since time.Time, limit int) ([]models.MissedWord, error)
+	TimeToLearn(ctx context.Context, userID int64, since time.Time) (models.LearnTime, error)
+	ActivityCounts(ctx context.Context, userID int64, since time.Time) ([]models.DayCount, error)
+}
+
+type StatsS struct {
+	repo StatsRI
+	log  *zap.Logger
+	now  func() time.Time
+}
+
+func NewStatsService(repo StatsRI, log *zap.Logger) *StatsS {
+	return &StatsS{
+		repo: repo,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+func (s *StatsS) Progress(ctx context.Context, userID int64, period models.StatsPeriod, lang string) (string, error) {
+	stats, err := s.ProgressStats(ctx, userID, period)
+	if err != nil {
+		s.log.Warn("failed to get progress stats", zap.Int64("user_id", userID), zap.Error(err))
+		return "", err
+	}
+
+	return formatProgress(lang, stats), nil
+}
+
+func (s *StatsS) ProgressStats(ctx context.Context, userID int64, period models.StatsPeriod) (models.ProgressStats, error) {
+	now := s.now()
+	since := period.Since(now)
+	bucket := period.Bucket()
+
+	stats := models.ProgressStats{Period: period}
+
+	days, err := s.repo.ActivityDays(ctx, userID)
+	if err != nil {
+		return models.ProgressStats{}, err
+	}
+	stats.CurrentStreak, stats.BestStreak = streaks(days, now)
+
+	week, err := s.repo.LearnedWords(ctx, userID, models.PeriodWeek.Since(now), "day")
+	if err != nil {
+		return models.ProgressStats{}, err
+	}
+	today := dayNumber(now)
+	for _, day := range week {
+		stats.LearnedWeek += day.Count
+		if dayNumber(day.Day) == today {
+			stats.LearnedToday += day.Count
+		}
+	}
+
+	if period == models.PeriodWeek {
+		stats.Learned = week
+	} else {
+		stats.Learned, err = s.repo.LearnedWords(ctx, userID, since, bucket)
+		if err != nil {
+			return models.ProgressStats{}, err
+		}
+	}
+
+	stats.Accuracy, err = s.repo.QuizAccuracy(ctx, userID, since, bucket)
+	if err != nil {
+		return models.ProgressStats{}, err
+	}
+
+	stats.ByType, err = s.repo.QuizAccuracyByType(ctx, userID, since)
+	if err != nil {
+		return models.ProgressStats{}, err
+	}
+
+	stats.MostMissed, err = s.repo.MostMissedWords(ctx, userID, since, mostMissedLimit)
+	if err != nil {
+		return models.ProgressStats{}, err
+	}
+
+	stats.TimeToLearn, err = s.repo.TimeToLearn(ctx, userID, since)
+	if err != nil {
+		return models.ProgressStats{}, err
+	}
+
+	return stats, nil
+}
+
+// dayNumber counts calendar days, ignoring the time zone the date is in.
+func dayNumber(t time.Time) int64 {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// streaks returns the current and the longest run of consecutive active days.
+// days must be distinct and sorted newest first. The current streak is kept
+// alive until the end of today even if the user hasn't been active yet.
+func streaks(days []time.Time, now time.Time) (int, int) {
+	best, run := 0, 0
+	for i, day := range days {
+		if i > 0 && dayNumber(days[i-1])-dayNumber(day) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		if run > best {
+			best = run
+		}
+	}
+
+	current := 0
+	if len(days) > 0 && dayNumber(now)-dayNumber(days[0]) <= 1 {
+		current = 1
+		for current < len(days) && dayNumber(days[current-1])-dayNumber(days[current]) == 1 {
+			current++
+		}
+	}
+
+	return current, best
+}
+
+func formatProgress(lang string, stats models.ProgressStats) string {
+	var sb strings.Builder
+
+	sb.WriteString(i18n.T(lang, "stats.title", i18n.T(lang, "stats.period_"+string(stats.Period))))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(i18n.T(lang, "stats.streak", stats.CurrentStreak, stats.BestStreak))
+	sb.WriteString("\n")
+	sb.WriteString(i18n.T(lang, "stats.learned_recent", stats.LearnedToday, stats.LearnedWeek))
+	sb.WriteString("\n")
+	if stats.TimeToLearn.Count > 0 {
+		sb.WriteString(i18n.T(lang, "stats.time_to_learn", formatDuration(lang, stats.TimeToLearn.AvgSeconds), stats.TimeToLearn.Count))
+		sb.WriteString("\n")
+	}
+
+	bucket := stats.Period.Bucket()
+
+	sb.WriteString("\n")
+	sb.WriteString(i18n.T(lang, "stats.learned_by_"+bucket))
+	sb.WriteString("\n")
+	if len(stats.Learned) == 0 {
+		sb.WriteString(i18n.T(lang, "stats.no_data"))
+		sb.WriteString("\n")
+	}
+	for _, day := range stats.Learned {
+		sb.WriteString(fmt.Sprintf("  %s — %d\n", day.Day.Format("02.01"), day.Count))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(i18n.T(lang, "stats.accuracy_by_"+bucket))
+	sb.WriteString("\n")
+	if len(stats.Accuracy) == 0 {
+		sb.WriteString(i18n.T(lang, "stats.no_data"))
+		sb.WriteString("\n")
+	}
+	for _, day := range stats.Accuracy {
+		sb.WriteString(fmt.Sprintf("  %s — %s\n", day.Day.Format("02.01"), formatAccuracy(day.Correct, day.Total)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(i18n.T(lang, "stats.accuracy_by_type"))
+	sb.WriteString("\n")
+	if len(stats.ByType) == 0 {
+		sb.WriteString(i18n.T(lang, "stats.no_data"))
+		sb.WriteString("\n")
+	}
+	for _, t := range stats.ByType {
+		name, ok := i18n.Lookup(lang, "quiz_type."+t.Type)
+		if !ok {
+			name = t.Type
+		}
+		sb.WriteString(fmt.Sprintf("  %s — %s\n", escapeMarkdown(name), formatAccuracy(t.Correct, t.Total)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(i18n.T(lang, "stats.most_missed"))
+	sb.WriteString("\n")
+	if len(stats.MostMissed) == 0 {
+		sb.WriteString(i18n.T(lang, "stats.no_data"))
+		sb.WriteString("\n")
+	}
+	for _, w := range stats.MostMissed {
+		sb.WriteString(i18n.T(lang, "stats.missed_line", escapeMarkdown(w.Word), escapeMarkdown(w.Translation), w.Misses, w.Attempts))
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+func formatAccuracy(correct, total int) string {
+	if total == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%d%% (%d/%d)", correct*100/total, correct, total)
+}
+
+func formatDuration(lang string, seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	switch {
+	case d < time.Hour:
+		return i18n.T(lang, "stats.duration_minutes", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return i18n.T(lang, "stats.duration_hours", int(d.Hours()))
+	default:
+		return i18n.T(lang, "stats.duration_days", d.Hours()/24)
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var statsNow = time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+
+func day(d int) time.Time {
+	return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func newStatsServiceMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *StatsS {
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return &StatsS{
+		repo: repo,
+		log:  zap.NewNop(),
+		now:  func() time.Time { return statsNow },
+	}
+}
+
+func Test_streaks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		days        []time.Time
+		wantCurrent int
+		wantBest    int
+	}{
+		{
+			name: "no activity",
+		},
+		{
+			name:        "active today",
+			days:        []time.Time{day(10), day(9), day(8), day(5), day(4)},
+			wantCurrent: 3,
+			wantBest:    3,
+		},
+		{
+			name:        "active yesterday keeps streak",
+			days:        []time.Time{day(9), day(8)},
+			wantCurrent: 2,
+			wantBest:    2,
+		},
+		{
+			name:        "broken streak",
+			days:        []time.Time{day(7), day(6), day(5), day(4)},
+			wantCurrent: 0,
+			wantBest:    4,
+		},
+		{
+			name:        "best in the past",
+			days:        []time.Time{day(10), day(6), day(5), day(4), day(3)},
+			wantCurrent: 1,
+			wantBest:    4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			current, best := streaks(tt.days, statsNow)
+			assert.Equal(t, tt.wantCurrent, current)
+			assert.Equal(t, tt.wantBest, best)
+		})
+	}
+}
+
+func TestStatsS_Progress(t *testing.T) {
+	t.Parallel()
+
+	weekSince := day(4)
+
+	tests := []struct {
+		name    string
+		period  models.StatsPeriod
+		lang    string
+		f       func(*mock_service.MockRepositoryI)
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "week",
+			period: models.PeriodWeek,
+			lang:   i18n.Default,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().ActivityDays(gomock.Any(), int64(1)).Return([]time.Time{day(10), day(9)}, nil)
+				mri.EXPECT().LearnedWords(gomock.Any(), int64(1), weekSince, "day").Return([]models.DayCount{
+					{Day: day(9), Count: 2},
+					{Day: day(10), Count: 1},
+				}, nil)
+				mri.EXPECT().QuizAccuracy(gomock.Any(), int64(1), weekSince, "day").Return([]models.DayAccuracy{
+					{Day: day(10), Total: 4, Correct: 3},
+				}, nil)
+				mri.EXPECT().QuizAccuracyByType(gomock.Any(), int64(1), weekSince).Return([]models.TypeAccuracy{
+					{Type: "quiz", Total: 4, Correct: 3},
+				}, nil)
+				mri.EXPECT().MostMissedWords(gomock.Any(), int64(1), weekSince, mostMissedLimit).Return([]models.MissedWord{
+					{Word: "apple", Translation: "яблоко", Misses: 1, Attempts: 2},
+				}, nil)
+				mri.EXPECT().TimeToLearn(gomock.Any(), int64(1), weekSince).Return(models.LearnTime{Count: 3, AvgSeconds: 2 * 3600}, nil)
+			},
+			want: `📊 *Прогресс: 7 дней*
+
+🔥 *Серия*: 2 дн. (рекорд: 2)
+📚 *Выучено слов*: сегодня 1, за неделю 3
+⏱ *Время до запоминания*: 2 ч в среднем (3 сл.)
+
+📈 *Выучено по дням*:
+  09.03 — 2
+  10.03 — 1
+
+🎯 *Точность по дням*:
+  10.03 — 75% (3/4)
+
+🧩 *Точность по типам*:
+  Перевод — 75% (3/4)
+
+❌ *Чаще всего ошибки*:
+  • apple (яблоко) — 1 из 2`,
+		},
+		{
+			name:   "all time, no data",
+			period: models.PeriodAll,
+			lang:   "en",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().ActivityDays(gomock.Any(), int64(1)).Return(nil, nil)
+				mri.EXPECT().LearnedWords(gomock.Any(), int64(1), weekSince, "day").Return(nil, nil)
+				mri.EXPECT().LearnedWords(gomock.Any(), int64(1), time.Time{}, "week").Return(nil, nil)
+				mri.EXPECT().QuizAccuracy(gomock.Any(), int64(1), time.Time{}, "week").Return(nil, nil)
+				mri.EXPECT().QuizAccuracyByType(gomock.Any(), int64(1), time.Time{}).Return(nil, nil)
+				mri.EXPECT().MostMissedWords(gomock.Any(), int64(1), time.Time{}, mostMissedLimit).Return(nil, nil)
+				mri.EXPECT().TimeToLearn(gomock.Any(), int64(1), time.Time{}).Return(models.LearnTime{}, nil)
+			},
+			want: `📊 *Progress: all time*
+
+🔥 *Streak*: 0 d (best: 0)
+📚 *Words learned*: today 0, this week 0
+
+📈 *Learned per week*:
+  no data yet
+
+🎯 *Accuracy per week*:
+  no data yet
+
+🧩 *Accuracy by quiz type*:
+  no data yet
+
+❌ *Most missed*:
+  no data yet`,
+		},
+		{
+			name:   "repo error",
+			period: models.PeriodMonth,
+			lang:   i18n.Default,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().ActivityDays(gomock.Any(), int64(1)).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stats := newStatsServiceMock(t, ctrl, tt.f)
+
+			got, err := stats.Progress(context.Background(), 1, tt.period, tt.lang)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_formatDuration(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "5 min", formatDuration("en", 300))
+	assert.Equal(t, "3 h", formatDuration("en", 3*3600+59))
+	assert.Equal(t, "1.5 d", formatDuration("en", 36*3600))
+}
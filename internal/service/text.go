@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DanRulev/vocabot.git/internal/lemma"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxTextWords is how many new words of a text are offered at once.
+	maxTextWords = 10
+
+	// minTextWordLength leaves out words too short to be worth learning.
+	minTextWordLength = 3
+)
+
+//go:embed data/frequent_words.txt
+var frequentWordsFile string
+
+var (
+	frequentWords = parseWordList(frequentWordsFile)
+	wordRanks     = rankWords(frequentWords)
+	lemmatizer    = lemma.New(knownWords())
+)
+
+// TextWords finds the words of text worth learning: their dictionary forms,
+// rarest first, without function words, names and the words the user
+// already has, with translations.
+func (w *WordS) TextWords(ctx context.Context, userID int64, text string) ([]models.TextWord, error) {
+	found := textWords(text)
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	words := make([]string, 0, len(found))
+	for _, f := range found {
+		words = append(words, f.Word)
+	}
+
+	saved, err := w.repo.SavedWords(ctx, userID, words)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(saved))
+	for _, s := range saved {
+		known[s] = true
+	}
+
+	fresh := make([]models.TextWord, 0, maxTextWords)
+	for _, f := range found {
+		if len(fresh) == maxTextWords {
+			break
+		}
+		if !known[f.Word] {
+			fresh = append(fresh, f)
+		}
+	}
+	if len(fresh) == 0 {
+		return nil, nil
+	}
+
+	texts := make([]string, len(fresh))
+	for i, f := range fresh {
+		texts[i] = f.Word
+	}
+
+	translations, err := w.myMemory.TranslateEnToRuBatch(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate words of the text: %w", err)
+	}
+
+	result := make([]models.TextWord, 0, len(fresh))
+	for i, f := range fresh {
+		t := translations[i]
+		// Words the service doesn't know come back as they are.
+		if t.Error != "" || t.Text == "" || strings.EqualFold(t.Text, f.Word) {
+			w.log.Warn("failed to translate word of the text", zap.String("word", f.Word), zap.String("error", t.Error))
+			continue
+		}
+		f.Translation = t.Text
+		result = append(result, f)
+	}
+
+	return result, nil
+}
+
+// AddTextWords adds the words picked from a text to the user's list, as not
+// learned yet.
+func (w *WordS) AddTextWords(ctx context.Context, userID int64, words []models.TextWord) error {
+	for _, word := range words {
+		card := models.WordCard{UserID: userID, WordText: word.Word, Translation: word.Translation}
+		if err := w.repo.AddWord(ctx, card); err != nil {
+			return fmt.Errorf("failed to add word %q: %w", word.Word, err)
+		}
+	}
+	return nil
+}
+
+// textWords returns the dictionary forms of the words of text, rarest first
+// and, among equally rare ones, the most repeated first. Words only ever
+// written with a capital letter in the middle of a sentence are taken for
+// names and left out.
+func textWords(text string) []models.TextWord {
+	type stat struct {
+		count int
+		first int
+		lower bool
+		name  bool
+	}
+
+	stats := make(map[string]*stat)
+	for i, token := range lemma.Tokenize(text) {
+		if lemma.IsStopWord(token.Word) {
+			continue
+		}
+		word := lemmatizer.Lemma(token.Word)
+		if len(word) < minTextWordLength || lemma.IsStopWord(word) {
+			continue
+		}
+
+		s, ok := stats[word]
+		if !ok {
+			s = &stat{first: i}
+			stats[word] = s
+		}
+		s.count++
+		switch {
+		case !token.Capitalized:
+			s.lower = true
+		case !token.SentenceStart:
+			s.name = true
+		}
+	}
+
+	words := make([]models.TextWord, 0, len(stats))
+	for word, s := range stats {
+		if s.name && !s.lower {
+			continue
+		}
+		words = append(words, models.TextWord{Word: word, Count: s.count})
+	}
+
+	sort.Slice(words, func(i, j int) bool {
+		a, b := words[i], words[j]
+		if ra, rb := wordRank(a.Word), wordRank(b.Word); ra != rb {
+			return ra > rb
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return stats[a.Word].first < stats[b.Word].first
+	})
+
+	return words
+}
+
+// wordRank is the place of a word in the frequency list, words that aren't
+// listed are the rarest.
+func wordRank(word string) int {
+	if rank, ok := wordRanks[word]; ok {
+		return rank
+	}
+	return len(wordRanks) + 1
+}
+
+func rankWords(words []string) map[string]int {
+	ranks := make(map[string]int, len(words))
+	for i, w := range words {
+		if _, ok := ranks[w]; !ok {
+			ranks[w] = i + 1
+		}
+	}
+	return ranks
+}
+
+// knownWords are the dictionary forms the lemmatizer prefers.
+func knownWords() []string {
+	known := make([]string, 0, len(frequentWords)+len(quizWords)+len(wotdWords))
+	known = append(known, frequentWords...)
+	for _, w := range quizWords {
+		known = append(known, w.Word)
+	}
+	return append(known, wotdWords...)
+}
+
+// parseWordList reads a word per line, skipping comments.
+func parseWordList(data string) []string {
+	words := make([]string, 0, strings.Count(data, "\n"))
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words
+}
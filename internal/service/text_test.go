@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_textWords(t *testing.T) {
+	t.Parallel()
+
+	got := textWords("The children walked through the meadows. Meadows were quiet, " +
+		"and Mr. Hawthorne watched the herons. Herons, meadows and a brook!")
+
+	words := make([]string, 0, len(got))
+	for _, w := range got {
+		words = append(words, w.Word)
+	}
+
+	// Unlisted words come first, the most repeated of them first, then the
+	// listed ones from the rarest. Names and function words are left out.
+	assert.Equal(t, []string{"meadow", "heron", "brook", "quiet", "walk", "watch", "child"}, words)
+	assert.Equal(t, 3, got[0].Count)
+}
+
+func TestWordS_TextWords(t *testing.T) {
+	t.Parallel()
+
+	const text = "Herons wade in the brook near the meadow."
+
+	tests := []struct {
+		name    string
+		text    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		want    []models.TextWord
+		wantErr bool
+	}{
+		{
+			name: "saved and untranslated words are left out",
+			text: text,
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().SavedWords(gomock.Any(), int64(1), []string{"heron", "wade", "brook", "meadow"}).Return([]string{"brook"}, nil)
+				ma.EXPECT().TranslateEnToRuBatch(gomock.Any(), []string{"heron", "wade", "meadow"}).Return([]models.MyMemoryTranslationResult{
+					{Text: "цапля"},
+					{Text: "wade"},
+					{Text: "луг"},
+				}, nil)
+			},
+			want: []models.TextWord{
+				{Word: "heron", Translation: "цапля", Count: 1},
+				{Word: "meadow", Translation: "луг", Count: 1},
+			},
+		},
+		{
+			name: "all words saved",
+			text: text,
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().SavedWords(gomock.Any(), int64(1), gomock.Any()).Return([]string{"heron", "wade", "brook", "meadow"}, nil)
+			},
+		},
+		{
+			name: "nothing to learn",
+			text: "It is what it is.",
+		},
+		{
+			name: "repository error",
+			text: text,
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().SavedWords(gomock.Any(), int64(1), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "translation error",
+			text: text,
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().SavedWords(gomock.Any(), int64(1), gomock.Any()).Return(nil, nil)
+				ma.EXPECT().TranslateEnToRuBatch(gomock.Any(), gomock.Any()).Return(nil, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name: "translation rate limited",
+			text: text,
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().SavedWords(gomock.Any(), int64(1), gomock.Any()).Return(nil, nil)
+				ma.EXPECT().TranslateEnToRuBatch(gomock.Any(), gomock.Any()).
+					Return(make([]models.MyMemoryTranslationResult, 4), ratelimit.ErrLimited)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wordService := newWordServiceMock(t, ctrl, tt.f)
+
+			got, err := wordService.TextWords(context.Background(), 1, tt.text)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWordS_AddTextWords(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wordService := newWordServiceMock(t, ctrl, func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+		mri.EXPECT().AddWord(gomock.Any(), models.WordCard{UserID: 1, WordText: "heron", Translation: "цапля"}).Return(nil)
+		mri.EXPECT().AddWord(gomock.Any(), models.WordCard{UserID: 1, WordText: "meadow", Translation: "луг"}).Return(assert.AnError)
+	})
+
+	err := wordService.AddTextWords(context.Background(), 1, []models.TextWord{
+		{Word: "heron", Translation: "цапля"},
+		{Word: "meadow", Translation: "луг"},
+		{Word: "brook", Translation: "ручей"},
+	})
+	require.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+type UsersRI interface {
+	TouchUser(ctx context.Context, user models.User) error
+	UserSettings(ctx context.Context, userID int64) (models.UserSettings, error)
+	UpdateUserSettings(ctx context.Context, userID int64, patch models.UserSettings) error
+}
+
+type UsersS struct {
+	repo UsersRI
+	log  *zap.Logger
+}
+
+func NewUsersService(repo UsersRI, log *zap.Logger) *UsersS {
+	return &UsersS{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (s *UsersS) TouchUser(ctx context.Context, user models.User) error {
+	return s.repo.TouchUser(ctx, user)
+}
+
+func (s *UsersS) UserSettings(ctx context.Context, userID int64) (models.UserSettings, error) {
+	settings, err := s.repo.UserSettings(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to get user settings", zap.Int64("user_id", userID), zap.Error(err))
+		return models.UserSettings{}, err
+	}
+
+	return settings, nil
+}
+
+// UpdateUserSettings validates the non-empty fields of patch and stores them.
+func (s *UsersS) UpdateUserSettings(ctx context.Context, userID int64, patch models.UserSettings) error {
+	if err := validateSettings(patch); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateUserSettings(ctx, userID, patch); err != nil {
+		s.log.Warn("failed to update user settings", zap.Int64("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func validateSettings(patch models.UserSettings) error {
+	if patch.Language != "" && !i18n.Supported(patch.Language) {
+		return fmt.Errorf("unsupported language %q", patch.Language)
+	}
+	if patch.TargetLanguage != "" && !slices.Contains(models.TargetLanguages, patch.TargetLanguage) {
+		return fmt.Errorf("unsupported target language %q", patch.TargetLanguage)
+	}
+	if patch.Level != "" && !slices.Contains(models.Levels, patch.Level) {
+		return fmt.Errorf("unknown level %q", patch.Level)
+	}
+	if patch.GoalType != "" && !slices.Contains(models.GoalTypes, patch.GoalType) {
+		return fmt.Errorf("unknown goal type %q", patch.GoalType)
+	}
+	goals := models.DailyGoals
+	if patch.GoalType == models.GoalQuiz {
+		goals = models.QuizGoals
+	}
+	if patch.DailyGoal != 0 && !slices.Contains(goals, patch.DailyGoal) {
+		return fmt.Errorf("unsupported daily goal %d", patch.DailyGoal)
+	}
+	if patch.QuizMode != "" && !slices.Contains(models.QuizModes, patch.QuizMode) {
+		return fmt.Errorf("unknown quiz mode %q", patch.QuizMode)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestUsersS_UpdateUserSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		patch   models.UserSettings
+		stored  bool
+		wantErr bool
+	}{
+		{name: "target language", patch: models.UserSettings{TargetLanguage: "en"}, stored: true},
+		{name: "level", patch: models.UserSettings{Level: "C1"}, stored: true},
+		{name: "goal and onboarded", patch: models.UserSettings{DailyGoal: 10, Onboarded: true}, stored: true},
+		{name: "unsupported target language", patch: models.UserSettings{TargetLanguage: "xx"}, wantErr: true},
+		{name: "unknown level", patch: models.UserSettings{Level: "D1"}, wantErr: true},
+		{name: "unsupported goal", patch: models.UserSettings{DailyGoal: 7}, wantErr: true},
+		{name: "quiz goal", patch: models.UserSettings{DailyGoal: 50, GoalType: models.GoalQuiz}, stored: true},
+		{name: "words goal of the quiz goal type", patch: models.UserSettings{DailyGoal: 5, GoalType: models.GoalQuiz}, wantErr: true},
+		{name: "unknown goal type", patch: models.UserSettings{DailyGoal: 10, GoalType: "xp"}, wantErr: true},
+		{name: "quiz mode", patch: models.UserSettings{QuizMode: models.QuizModePoll}, stored: true},
+		{name: "unknown quiz mode", patch: models.UserSettings{QuizMode: "voice"}, wantErr: true},
+		{name: "unsupported interface language", patch: models.UserSettings{Language: "xx"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockRepositoryI(ctrl)
+			if tt.stored {
+				repo.EXPECT().UpdateUserSettings(gomock.Any(), int64(1), tt.patch).Return(nil)
+			}
+
+			s := NewUsersService(repo, zap.NewNop())
+
+			err := s.UpdateUserSettings(context.Background(), 1, tt.patch)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
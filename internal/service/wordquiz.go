@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+	"go.uber.org/zap"
+)
+
+// wordQuizAttempts is how many random words are tried for a quiz that needs
+// dictionary data the word may lack, like an example or synonyms.
+const wordQuizAttempts = 5
+
+// definitionCandidates is how many distractor words are looked up for the
+// wrong options of a definition quiz.
+const definitionCandidates = quizOptions + 2
+
+// maxDefinitionOption is the longest definition offered as an option, the
+// limit of a quiz poll answer.
+const maxDefinitionOption = 100
+
+// NewSynonymQuiz asks for a synonym of a random word. The wrong options come
+// from the distractor strategy and are never among its synonyms.
+func (q *QuizS) NewSynonymQuiz(ctx context.Context, userID int64) (models.WordQuiz, error) {
+	return q.newDistractorQuiz(ctx, userID, func(ctx context.Context) (models.WordQuiz, models.QuizWord, error) {
+		quiz, dictData, err := q.randomDictWord(ctx)
+		if err != nil {
+			return models.WordQuiz{}, models.QuizWord{}, err
+		}
+
+		var (
+			synonyms []string
+			speech   = make(map[string]string)
+		)
+		for _, def := range dictData.Definitions {
+			for _, syms := range def.Synonyms {
+				for _, syn := range syms {
+					syn = strings.ToLower(strings.TrimSpace(syn))
+					if syn != "" && syn != quiz.Word && !slices.Contains(synonyms, syn) {
+						synonyms = append(synonyms, syn)
+						speech[syn] = def.PartOfSpeech
+					}
+				}
+			}
+		}
+		if len(synonyms) == 0 {
+			return models.WordQuiz{}, models.QuizWord{}, fmt.Errorf("no synonyms: %v", quiz.Word)
+		}
+
+		answer := synonyms[rand.Intn(len(synonyms))]
+		quiz.Question = quiz.Word
+		quiz.Options = map[string]bool{answer: true}
+
+		target := models.QuizWord{
+			Word:         quiz.Word,
+			Translation:  quiz.Translation,
+			PartOfSpeech: speech[answer],
+			Synonyms:     synonyms,
+		}
+		return quiz, target, nil
+	})
+}
+
+// NewDefinitionQuiz names a random word and asks which definition is its.
+// The wrong options are definitions of the words the distractor strategy
+// picks, which prefers the same part of speech, so the grammar of a
+// definition doesn't give the answer away.
+func (q *QuizS) NewDefinitionQuiz(ctx context.Context, userID int64) (models.WordQuiz, error) {
+	var errs []error
+
+	for attempts := 0; attempts < wordQuizAttempts; attempts++ {
+		quiz, dictData, err := q.randomDictWord(ctx)
+		if errors.Is(err, ratelimit.ErrLimited) {
+			return models.WordQuiz{}, err
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		definition, partOfSpeech := wordDefinition(dictData, quiz.Word, "")
+		if definition == "" {
+			errs = append(errs, fmt.Errorf("no definition: %v", quiz.Word))
+			continue
+		}
+
+		quiz.Question = quiz.Word
+		quiz.Options = map[string]bool{definition: true}
+
+		target := models.QuizWord{Word: quiz.Word, Translation: quiz.Translation, PartOfSpeech: partOfSpeech}
+		if err := q.addDefinitions(ctx, userID, &quiz, target); err != nil {
+			return models.WordQuiz{}, err
+		}
+		return quiz, nil
+	}
+
+	q.log.Warn("failed to build definition quiz", zap.Int64("user_id", userID), zap.Errors("errors", errs))
+	return models.WordQuiz{}, errors.New("not enough dictionary data for the quiz")
+}
+
+// addDefinitions adds the definitions of distractor words to the quiz as
+// wrong options. A few more words than needed are looked up, as the
+// dictionary may have no definition for some.
+func (q *QuizS) addDefinitions(ctx context.Context, userID int64, quiz *models.WordQuiz, target models.QuizWord) error {
+	distractors, err := q.distractors.Distractors(ctx, userID, target, definitionCandidates)
+	if err != nil {
+		q.log.Warn("failed to get distractors", zap.Int64("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	for _, d := range distractors {
+		if len(quiz.Options) == quizOptions {
+			break
+		}
+
+		dictData, err := q.pythonAnyWhere.DictionaryData(ctx, d.Word)
+		if errors.Is(err, ratelimit.ErrLimited) {
+			return err
+		}
+		if err != nil {
+			q.log.Warn("failed to get distractor definition", zap.String("word", d.Word), zap.Error(err))
+			continue
+		}
+
+		if definition, _ := wordDefinition(dictData, d.Word, target.PartOfSpeech); definition != "" {
+			if _, ok := quiz.Options[definition]; !ok {
+				quiz.Options[definition] = false
+			}
+		}
+	}
+
+	if len(quiz.Options) < quizOptions {
+		q.log.Warn("not enough definitions", zap.Int("got", len(quiz.Options)), zap.Int("required", quizOptions))
+		return errors.New("not enough definitions for the quiz")
+	}
+	return nil
+}
+
+// wordDefinition returns the first definition of the word, of the given part
+// of speech if it has one, and the definition's part of speech. The word is
+// blanked out of it, as a definition mentioning the word would give it away,
+// and a long definition is cut to fit a quiz poll answer.
+func wordDefinition(dictData models.TranslationResponse, word, partOfSpeech string) (string, string) {
+	var definition, speech string
+	for _, def := range dictData.Definitions {
+		if def.Definition == "" {
+			continue
+		}
+		if definition == "" || def.PartOfSpeech == partOfSpeech {
+			definition, speech = def.Definition, def.PartOfSpeech
+		}
+		if partOfSpeech == "" || def.PartOfSpeech == partOfSpeech {
+			break
+		}
+	}
+	if definition == "" {
+		return "", ""
+	}
+
+	definition, _ = blankWord(definition, word)
+	if runes := []rune(definition); len(runes) > maxDefinitionOption {
+		definition = strings.TrimSpace(string(runes[:maxDefinitionOption-1])) + "…"
+	}
+	return definition, speech
+}
+
+// newDistractorQuiz calls pick until it finds a word with the dictionary data
+// the quiz needs. The wrong options are the English words the distractor
+// strategy picks for the target pick returns, so they cost no API calls.
+func (q *QuizS) newDistractorQuiz(
+	ctx context.Context,
+	userID int64,
+	pick func(ctx context.Context) (models.WordQuiz, models.QuizWord, error),
+) (models.WordQuiz, error) {
+	var errs []error
+
+	for attempts := 0; attempts < wordQuizAttempts; attempts++ {
+		quiz, target, err := pick(ctx)
+		if errors.Is(err, ratelimit.ErrLimited) {
+			return models.WordQuiz{}, err
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		distractors, err := q.distractors.Distractors(ctx, userID, target, quizOptions-1)
+		if err != nil {
+			q.log.Warn("failed to get distractors", zap.Int64("user_id", userID), zap.Error(err))
+			return models.WordQuiz{}, err
+		}
+		for _, d := range distractors {
+			if _, ok := quiz.Options[d.Word]; !ok {
+				quiz.Options[d.Word] = false
+			}
+		}
+
+		if len(quiz.Options) < quizOptions {
+			q.log.Warn("not enough distractors", zap.Int("got", len(quiz.Options)), zap.Int("required", quizOptions))
+			return models.WordQuiz{}, errors.New("not enough distractors for the quiz")
+		}
+		return quiz, nil
+	}
+
+	q.log.Warn("failed to build word quiz", zap.Int64("user_id", userID), zap.Errors("errors", errs))
+	return models.WordQuiz{}, errors.New("not enough dictionary data for the quiz")
+}
+
+// randomDictWord returns a random word with its translation and dictionary
+// data.
+func (q *QuizS) randomDictWord(ctx context.Context) (models.WordQuiz, models.TranslationResponse, error) {
+	word, err := q.randomWord(ctx)
+	if err != nil {
+		return models.WordQuiz{}, models.TranslationResponse{}, err
+	}
+
+	dictData, err := q.pythonAnyWhere.DictionaryData(ctx, word)
+	if err != nil {
+		return models.WordQuiz{}, models.TranslationResponse{}, fmt.Errorf("DictionaryData failed: %w", err)
+	}
+
+	translation, err := q.dictTranslation(ctx, word, dictData)
+	if err != nil {
+		return models.WordQuiz{}, models.TranslationResponse{}, err
+	}
+
+	return models.WordQuiz{Word: word, Translation: translation}, dictData, nil
+}
+
+func (q *QuizS) randomWord(ctx context.Context) (string, error) {
+	word, err := q.vercel.RandomWord(ctx)
+	if err != nil {
+		return "", fmt.Errorf("RandomWord failed: %w", err)
+	}
+	return strings.ToLower(word), nil
+}
+
+// dictTranslation is the translation from the dictionary data, or from
+// MyMemory when the dictionary has none.
+func (q *QuizS) dictTranslation(ctx context.Context, word string, dictData models.TranslationResponse) (string, error) {
+	if dictData.DestinationText != "" {
+		return dictData.DestinationText, nil
+	}
+
+	trans, err := q.myMemory.TranslateEnToRu(ctx, word)
+	if err != nil {
+		return "", fmt.Errorf("TranslateWord failed: %w", err)
+	}
+	if trans.Text == "" {
+		return "", fmt.Errorf("translation empty: %v", word)
+	}
+	return trans.Text, nil
+}
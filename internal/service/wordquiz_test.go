@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dictDefinition builds dictionary data with a single definition.
+func dictDefinition(word, translation, partOfSpeech, definition string, synonyms ...string) models.TranslationResponse {
+	resp := dictEntry(word, translation, "")
+	resp.Definitions[0].PartOfSpeech = partOfSpeech
+	resp.Definitions[0].Definition = definition
+	if len(synonyms) > 0 {
+		resp.Definitions[0].Synonyms = map[string][]string{partOfSpeech: synonyms}
+	}
+	return resp
+}
+
+func TestQuizS_NewSynonymQuiz(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		want    models.WordQuiz
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				gomock.InOrder(
+					ma.EXPECT().RandomWord(gomock.Any()).Return("ouch", nil),
+					ma.EXPECT().DictionaryData(gomock.Any(), "ouch").Return(dictDefinition("ouch", "ой", "interjection", "used to express pain"), nil),
+					ma.EXPECT().RandomWord(gomock.Any()).Return("Big", nil),
+					ma.EXPECT().DictionaryData(gomock.Any(), "big").
+						Return(dictDefinition("big", "большой", "adjective", "of large size", "Large", "big"), nil),
+					mri.EXPECT().RandomWords(gomock.Any(), int64(1), "big", ownWordsPool).Return(nil, nil),
+				)
+			},
+			want: models.WordQuiz{
+				Word:        "big",
+				Translation: "большой",
+				Question:    "big",
+				Options:     map[string]bool{"large": true, "small": false, "red": false, "old": false},
+			},
+		},
+		{
+			name: "rate limited",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				ma.EXPECT().RandomWord(gomock.Any()).Return("", ratelimit.ErrLimited)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no synonyms",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				ma.EXPECT().RandomWord(gomock.Any()).Return("ouch", nil).Times(wordQuizAttempts)
+				ma.EXPECT().DictionaryData(gomock.Any(), "ouch").
+					Return(dictDefinition("ouch", "ой", "interjection", "used to express pain"), nil).Times(wordQuizAttempts)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quiz := newQuizServiceMock(t, ctrl, tt.f)
+
+			got, err := quiz.NewSynonymQuiz(context.Background(), 1)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuizS_NewDefinitionQuiz(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		want    models.WordQuiz
+		wantErr bool
+	}{
+		{
+			name: "definitions of words of the same part of speech",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				gomock.InOrder(
+					ma.EXPECT().RandomWord(gomock.Any()).Return("run", nil),
+					ma.EXPECT().DictionaryData(gomock.Any(), "run").
+						Return(dictDefinition("run", "бежать", "verb", "to move fast; runs are quick"), nil),
+					mri.EXPECT().RandomWords(gomock.Any(), int64(1), "run", ownWordsPool).
+						Return([]models.QuizWord{{Word: "sing", Translation: "петь", PartOfSpeech: "verb"}}, nil),
+				)
+				ma.EXPECT().DictionaryData(gomock.Any(), "sing").Return(dictDefinition("sing", "петь", "verb", "to make music"), nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "big").Return(dictDefinition("big", "большой", "adjective", "of large size"), nil)
+				// A word without a definition is skipped.
+				ma.EXPECT().DictionaryData(gomock.Any(), "cat").Return(dictDefinition("cat", "кот", "noun", ""), nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), "house").Return(dictDefinition("house", "дом", "noun", "a building to live in"), nil)
+			},
+			want: models.WordQuiz{
+				Word:        "run",
+				Translation: "бежать",
+				Question:    "run",
+				Options: map[string]bool{
+					"to move fast; ＿＿＿ are quick": true,
+					"to make music":               false,
+					"of large size":               false,
+					"a building to live in":       false,
+				},
+			},
+		},
+		{
+			name: "not enough definitions",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				gomock.InOrder(
+					ma.EXPECT().RandomWord(gomock.Any()).Return("run", nil),
+					ma.EXPECT().DictionaryData(gomock.Any(), "run").Return(dictDefinition("run", "бежать", "verb", "to move fast"), nil),
+					mri.EXPECT().RandomWords(gomock.Any(), int64(1), "run", ownWordsPool).Return(nil, nil),
+				)
+				ma.EXPECT().DictionaryData(gomock.Any(), gomock.Any()).Return(models.TranslationResponse{}, assert.AnError).Times(definitionCandidates)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no definition",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				ma.EXPECT().RandomWord(gomock.Any()).Return("run", nil).Times(wordQuizAttempts)
+				ma.EXPECT().DictionaryData(gomock.Any(), "run").
+					Return(dictDefinition("run", "бежать", "verb", ""), nil).Times(wordQuizAttempts)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			quiz := newQuizServiceMock(t, ctrl, tt.f)
+
+			got, err := quiz.NewDefinitionQuiz(context.Background(), 1)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,18 +2,24 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/DanRulev/vocabot.git/internal/i18n"
 	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
 	"go.uber.org/zap"
 )
 
 type WordRI interface {
 	AddWord(ctx context.Context, word models.WordCard) error
-	Words(ctx context.Context, userID int64, offset int, know bool) ([]models.WordCard, int, error)
+	Word(ctx context.Context, userID int64, word string) (models.WordCard, bool, error)
+	Words(ctx context.Context, userID int64, list models.WordList) ([]models.WordCard, int, error)
+	SavedWords(ctx context.Context, userID int64, words []string) ([]string, error)
 	WordStat(ctx context.Context, userID int64) (models.WordStats, error)
 }
 
@@ -22,6 +28,7 @@ type WordS struct {
 	pythonAnyWhere PythonAnyWhereAPII
 	vercel         VercelAPII
 	repo           WordRI
+	lookups        *lookupCache
 	log            *zap.Logger
 }
 
@@ -31,11 +38,12 @@ func NewWordService(api APII, repo WordRI, log *zap.Logger) *WordS {
 		pythonAnyWhere: api,
 		vercel:         api,
 		repo:           repo,
+		lookups:        newLookupCache(lookupCacheTTL, lookupCacheSize),
 		log:            log,
 	}
 }
 
-func (w *WordS) RandomWord(ctx context.Context) (string, models.WordCard, error) {
+func (w *WordS) RandomWord(ctx context.Context, lang string) (string, models.WordCard, error) {
 	var (
 		word        string
 		translate   models.MyMemoryTranslationResult
@@ -48,7 +56,7 @@ func (w *WordS) RandomWord(ctx context.Context) (string, models.WordCard, error)
 		word, err = w.vercel.RandomWord(ctx)
 		if err != nil {
 			w.log.Error("failed to get random word from Vercel", zap.Int("attempt", attempt), zap.Error(err))
-			if attempt == maxAttempts {
+			if attempt == maxAttempts || errors.Is(err, ratelimit.ErrLimited) {
 				return "", models.WordCard{}, fmt.Errorf("couldn't get the word after %d attempts: %w", maxAttempts, err)
 			}
 			continue
@@ -61,6 +69,9 @@ func (w *WordS) RandomWord(ctx context.Context) (string, models.WordCard, error)
 		translate, err = w.myMemory.TranslateEnToRu(ctx, word)
 		if err != nil {
 			w.log.Error("failed to translate word", zap.String("word", word), zap.Int("attempt", attempt), zap.Error(err))
+			if errors.Is(err, ratelimit.ErrLimited) {
+				return "", models.WordCard{}, err
+			}
 			continue
 		}
 		if translate.Text == "" {
@@ -91,17 +102,19 @@ func (w *WordS) RandomWord(ctx context.Context) (string, models.WordCard, error)
 		dictData.DestinationText = translation
 	}
 
-	formatted := formatTranslation(translate, dictData)
+	formatted := formatTranslation(lang, translate, dictData)
 
 	wordCard := models.WordCard{
 		WordText:    word,
 		Translation: translation,
+		Audio:       dictData.Pronunciation.SourceTextAudio,
+		Details:     &models.WordDetails{Translation: translate, Dictionary: dictData},
 	}
 
 	return formatted, wordCard, nil
 }
 
-func formatTranslation(translate models.MyMemoryTranslationResult, dictData models.TranslationResponse) string {
+func formatTranslation(lang string, translate models.MyMemoryTranslationResult, dictData models.TranslationResponse) string {
 	var sb strings.Builder
 
 	sourceText := dictData.SourceText
@@ -109,7 +122,7 @@ func formatTranslation(translate models.MyMemoryTranslationResult, dictData mode
 		sourceText = "Unknown"
 	}
 
-	sb.WriteString("📚 *Слово*: **")
+	sb.WriteString("📚 *" + i18n.T(lang, "card.word") + "*: **")
 	sb.WriteString(escapeMarkdown(sourceText))
 	sb.WriteString("**\n\n")
 
@@ -118,16 +131,16 @@ func formatTranslation(translate models.MyMemoryTranslationResult, dictData mode
 		if dictData.DestinationText != "" {
 			translatedText = dictData.DestinationText
 		} else {
-			translatedText = "неизвестно"
+			translatedText = i18n.T(lang, "card.translation_unknown")
 		}
 	}
 
-	sb.WriteString("🇷🇺 *Перевод*: ")
+	sb.WriteString("🇷🇺 *" + i18n.T(lang, "card.translation") + "*: ")
 	sb.WriteString(escapeMarkdown(translatedText))
 	sb.WriteString("\n")
 
 	if phonetic := dictData.Pronunciation.SourceTextPhonetic; phonetic != "" {
-		sb.WriteString("🔤 *Произношение*: `")
+		sb.WriteString("🔤 *" + i18n.T(lang, "card.pronunciation") + "*: `")
 		sb.WriteString(escapeMarkdown(phonetic))
 		sb.WriteString("`\n\n")
 	} else {
@@ -154,7 +167,7 @@ func formatTranslation(translate models.MyMemoryTranslationResult, dictData mode
 			}
 
 			if len(def.OtherExamples) > 0 {
-				sb.WriteString("\n📎 *Другие примеры*:\n")
+				sb.WriteString("\n📎 *" + i18n.T(lang, "card.other_examples") + "*:\n")
 				for _, ex := range def.OtherExamples {
 					sb.WriteString("  • `")
 					sb.WriteString(escapeMarkdown(ex))
@@ -163,7 +176,7 @@ func formatTranslation(translate models.MyMemoryTranslationResult, dictData mode
 			}
 
 			if len(def.Synonyms) > 0 {
-				sb.WriteString("🔁 *Синонимы*:\n")
+				sb.WriteString("🔁 *" + i18n.T(lang, "card.synonyms") + "*:\n")
 				for pos, syms := range def.Synonyms {
 					sb.WriteString("  ")
 					sb.WriteString(escapeMarkdown(pos))
@@ -175,24 +188,24 @@ func formatTranslation(translate models.MyMemoryTranslationResult, dictData mode
 			sb.WriteString("\n")
 		}
 	} else {
-		sb.WriteString("⚠️ Нет словарных данных.\n")
+		sb.WriteString(i18n.T(lang, "card.no_dictionary") + "\n")
 	}
 
 	if len(translate.Alternatives) > 0 {
-		sb.WriteString("🔄 *Альтернативные переводы*: ")
+		sb.WriteString("🔄 *" + i18n.T(lang, "card.alternatives") + "*: ")
 		uniqueAlts := removeDuplicates(translate.Alternatives)
 		sb.WriteString(strings.Join(escapeSlice(uniqueAlts), ", "))
 		sb.WriteString("\n")
 	}
 
 	if translate.Match > 0 {
-		quality := "низкое"
+		quality := i18n.T(lang, "card.quality_low")
 		if translate.Match >= 0.7 {
-			quality = "высокое"
+			quality = i18n.T(lang, "card.quality_high")
 		} else if translate.Match >= 0.4 {
-			quality = "среднее"
+			quality = i18n.T(lang, "card.quality_medium")
 		}
-		sb.WriteString(fmt.Sprintf("📊 *Качество перевода*: %.1f (%s)\n", translate.Match, quality))
+		sb.WriteString(fmt.Sprintf("📊 *%s*: %.1f (%s)\n", i18n.T(lang, "card.quality"), translate.Match, quality))
 	}
 
 	return strings.TrimSpace(sb.String())
@@ -229,37 +242,73 @@ func (w *WordS) AddWord(ctx context.Context, word models.WordCard) error {
 	return w.repo.AddWord(ctx, word)
 }
 
-func (w *WordS) Words(ctx context.Context, userID int64, page int, learned bool) (string, bool, error) {
-	words, total, err := w.repo.Words(ctx, userID, page*10, learned)
+// SavedWord shows the card of one of the user's words from what was saved
+// with it. Words saved before details were kept get a card with just the
+// translation.
+func (w *WordS) SavedWord(ctx context.Context, userID int64, word, lang string) (string, models.WordCard, error) {
+	card, ok, err := w.repo.Word(ctx, userID, strings.ToLower(strings.TrimSpace(word)))
+	if err != nil {
+		return "", models.WordCard{}, err
+	}
+	if !ok {
+		return "", models.WordCard{}, models.ErrNoWord
+	}
+
+	details := models.WordDetails{
+		Dictionary: models.TranslationResponse{SourceText: card.WordText, DestinationText: card.Translation},
+	}
+	if card.Details != nil {
+		details = *card.Details
+		card.Audio = details.Dictionary.Pronunciation.SourceTextAudio
+	}
+
+	return formatTranslation(lang, details.Translation, details.Dictionary), card, nil
+}
+
+// Words shows a page of the user's word list. It reports whether there is a
+// next page.
+func (w *WordS) Words(ctx context.Context, userID int64, list models.WordList, lang string) (string, bool, error) {
+	if !slices.Contains(models.PageSizes, list.PageSize) {
+		list.PageSize = models.DefaultPageSize
+	}
+
+	words, total, err := w.repo.Words(ctx, userID, list)
 	if err != nil {
 		return "", false, err
 	}
 	if total == 0 || len(words) == 0 {
-		return "", false, fmt.Errorf("empty list")
+		return "", false, fmt.Errorf("empty list: %w", models.ErrNoWord)
 	}
 
-	return formatWords(words, total, page, learned), (page+1)*10 < total, nil
+	return formatWords(lang, words, total, list.Page, list.PageSize), (list.Page+1)*list.PageSize < total, nil
 }
 
-func formatWords(words []models.WordCard, total, page int, know bool) string {
+func formatWords(lang string, words []models.WordCard, total, page, pageSize int) string {
 	var sb strings.Builder
 
-	totalPages := total / 10
-	if total%10 != 0 {
+	totalPages := total / pageSize
+	if total%pageSize != 0 {
 		totalPages += 1
 	}
 
-	sb.WriteString(fmt.Sprintf("📚 Страница (%d/%d) | Всего слов (%d):\n\n", page+1, totalPages, total))
+	sb.WriteString(i18n.T(lang, "words.page", page+1, totalPages, total))
+	sb.WriteString("\n\n")
 
 	for i, word := range words {
-		num := (page * 10) + i + 1
+		num := (page * pageSize) + i + 1
 		sb.WriteString(fmt.Sprintf("%d. **%s** → *%s*\n",
 			num,
 			escapeMarkdown(word.WordText),
 			escapeMarkdown(word.Translation),
 		))
 
-		sb.WriteString("   📖 last seen: ")
+		if word.Details != nil {
+			if summary := wordSummary(word.Details.Dictionary); summary != "" {
+				sb.WriteString("   " + summary + "\n")
+			}
+		}
+
+		sb.WriteString("   📖 " + i18n.T(lang, "words.last_seen") + ": ")
 		sb.WriteString(word.LastSeen.Format(time.DateOnly))
 
 		if i < len(words)-1 {
@@ -270,27 +319,52 @@ func formatWords(words []models.WordCard, total, page int, know bool) string {
 	return sb.String()
 }
 
-func (w *WordS) WordStat(ctx context.Context, userID int64) (string, error) {
+// wordSummary is the phonetic spelling and parts of speech of a word, for a
+// line of the word list.
+func wordSummary(dictData models.TranslationResponse) string {
+	var parts []string
+
+	if phonetic := dictData.Pronunciation.SourceTextPhonetic; phonetic != "" {
+		parts = append(parts, "`"+escapeMarkdown(phonetic)+"`")
+	}
+
+	var pos []string
+	for _, def := range dictData.Definitions {
+		if def.PartOfSpeech != "" && !slices.Contains(pos, def.PartOfSpeech) {
+			pos = append(pos, def.PartOfSpeech)
+		}
+	}
+	if len(pos) > 0 {
+		parts = append(parts, "_"+escapeMarkdown(strings.Join(pos, ", "))+"_")
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return "🔤 " + strings.Join(parts, " · ")
+}
+
+func (w *WordS) WordStat(ctx context.Context, userID int64, lang string) (string, error) {
 	stats, err := w.repo.WordStat(ctx, userID)
 	if err != nil {
 		return "", err
 	}
 
-	return formatWordStats(stats), nil
+	return formatWordStats(lang, stats), nil
 }
 
-func formatWordStats(stats models.WordStats) string {
+func formatWordStats(lang string, stats models.WordStats) string {
 	var sb strings.Builder
 
-	sb.WriteString("📚 *Всего отмечено слов*: **")
+	sb.WriteString("📚 *" + i18n.T(lang, "stats.words_total") + "*: **")
 	sb.WriteString(strconv.Itoa(stats.TotalCount))
 	sb.WriteString("**\n\n")
 
-	sb.WriteString("📚 *Выучено*: **")
+	sb.WriteString("📚 *" + i18n.T(lang, "stats.words_learned") + "*: **")
 	sb.WriteString(strconv.Itoa(stats.LearnedCount))
 	sb.WriteString("**\n\n")
 
-	sb.WriteString("📚 *Предстоит запомнить*: **")
+	sb.WriteString("📚 *" + i18n.T(lang, "stats.words_unlearned") + "*: **")
 	sb.WriteString(strconv.Itoa(stats.UnlearnedCount))
 	sb.WriteString("**")
 
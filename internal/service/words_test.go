@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DanRulev/vocabot.git/internal/i18n"
 	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/ratelimit"
 	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +31,7 @@ func newWordServiceMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*m
 		pythonAnyWhere: api,
 		vercel:         api,
 		repo:           repo,
+		lookups:        newLookupCache(lookupCacheTTL, lookupCacheSize),
 		log:            log,
 	}
 }
@@ -65,6 +68,7 @@ func TestWordS_RandomWord(t *testing.T) {
 						DestinationTextAudio string `json:"destination-text-audio"`
 					}{
 						SourceTextPhonetic: "[həˈləʊ]",
+						SourceTextAudio:    "https://example.com/hello.mp3",
 					},
 					Definitions: []struct {
 						PartOfSpeech  string              `json:"part-of-speech"`
@@ -98,6 +102,10 @@ func TestWordS_RandomWord(t *testing.T) {
 
 				assert.Equal(t, "hello", card.WordText)
 				assert.Equal(t, "привет", card.Translation)
+				assert.Equal(t, "https://example.com/hello.mp3", card.Audio)
+				require.NotNil(t, card.Details)
+				assert.Equal(t, "noun", card.Details.Dictionary.Definitions[0].PartOfSpeech)
+				assert.Equal(t, "привет", card.Details.Translation.Text)
 			},
 		},
 		{
@@ -158,6 +166,15 @@ func TestWordS_RandomWord(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error: rate limit stops the retries",
+			args: args{ctx: context.Background()},
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				ma.EXPECT().RandomWord(gomock.Any()).Return("hello", nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), "hello").Return(models.MyMemoryTranslationResult{}, ratelimit.ErrLimited)
+			},
+			wantErr: true,
+		},
 		{
 			name: "error: TranslateEnToRu fails all attempts",
 			args: args{ctx: context.Background()},
@@ -199,7 +216,7 @@ func TestWordS_RandomWord(t *testing.T) {
 
 			wordService := newWordServiceMock(t, ctrl, tt.f)
 
-			got, got1, err := wordService.RandomWord(tt.args.ctx)
+			got, got1, err := wordService.RandomWord(tt.args.ctx, i18n.Default)
 			if tt.wantErr {
 				require.Error(t, err)
 				require.Empty(t, got)
@@ -490,10 +507,9 @@ func TestWordS_Words(t *testing.T) {
 	dateStr := now.Format("2006-01-02")
 
 	type args struct {
-		ctx     context.Context
-		userID  int64
-		page    int
-		learned bool
+		ctx    context.Context
+		userID int64
+		list   models.WordList
 	}
 	tests := []struct {
 		name    string
@@ -506,13 +522,12 @@ func TestWordS_Words(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				ctx:     context.Background(),
-				userID:  1,
-				page:    0,
-				learned: true,
+				ctx:    context.Background(),
+				userID: 1,
+				list:   models.WordList{Deck: models.DeckLearned},
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{
+				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{
 					{
 						UserID:      1,
 						WordText:    "hello",
@@ -540,13 +555,12 @@ func TestWordS_Words(t *testing.T) {
 		{
 			name: "success: one word",
 			args: args{
-				ctx:     context.Background(),
-				userID:  1,
-				page:    0,
-				learned: true,
+				ctx:    context.Background(),
+				userID: 1,
+				list:   models.WordList{Deck: models.DeckLearned},
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{
+				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{
 					{
 						WordText:    "cat",
 						Translation: "кот",
@@ -561,16 +575,35 @@ func TestWordS_Words(t *testing.T) {
    📖 last seen: %s`, dateStr),
 			want1: false,
 		},
+		{
+			name: "success: with details",
+			args: args{
+				ctx:    context.Background(),
+				userID: 1,
+				list:   models.WordList{Deck: models.DeckLearning},
+			},
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				dictData := dictDefinition("run", "бежать", "verb", "To move fast.")
+				dictData.Definitions = append(dictData.Definitions, dictDefinition("run", "", "noun", "A fast move.").Definitions...)
+				dictData.Pronunciation.SourceTextPhonetic = "rʌn"
+				mri.EXPECT().Words(gomock.Any(), int64(1), models.WordList{Deck: models.DeckLearning, PageSize: 10}).Return([]models.WordCard{
+					{WordText: "run", Translation: "бежать", LastSeen: now, Details: &models.WordDetails{Dictionary: dictData}},
+				}, 1, nil)
+			},
+			want: fmt.Sprintf("📚 Страница (1/1) | Всего слов (1):\n\n"+
+				"1. **run** → *бежать*\n"+
+				"   🔤 `rʌn` · _verb, noun_\n"+
+				"   📖 last seen: %s", dateStr),
+		},
 		{
 			name: "success: page 1 of 2 (15 words)",
 			args: args{
-				ctx:     context.Background(),
-				userID:  1,
-				page:    1,
-				learned: true,
+				ctx:    context.Background(),
+				userID: 1,
+				list:   models.WordList{Deck: models.DeckLearned, Page: 1},
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				mri.EXPECT().Words(gomock.Any(), int64(1), 10, true).Return([]models.WordCard{
+				mri.EXPECT().Words(gomock.Any(), int64(1), models.WordList{Deck: models.DeckLearned, Page: 1, PageSize: 10}).Return([]models.WordCard{
 					{WordText: "apple", Translation: "яблоко", LastSeen: now},
 				}, 15, nil)
 			},
@@ -581,16 +614,33 @@ func TestWordS_Words(t *testing.T) {
    📖 last seen: %s`, dateStr),
 			want1: false,
 		},
+		{
+			name: "success: page size 5",
+			args: args{
+				ctx:    context.Background(),
+				userID: 1,
+				list:   models.WordList{Deck: models.DeckAll, Page: 1, PageSize: 5},
+			},
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Words(gomock.Any(), int64(1), models.WordList{Deck: models.DeckAll, Page: 1, PageSize: 5}).Return([]models.WordCard{
+					{WordText: "apple", Translation: "яблоко", LastSeen: now},
+				}, 12, nil)
+			},
+			want: fmt.Sprintf(`📚 Страница (2/3) | Всего слов (12):
+
+6. **apple** → *яблоко*
+   📖 last seen: %s`, dateStr),
+			want1: true,
+		},
 		{
 			name: "has next page",
 			args: args{
-				ctx:     context.Background(),
-				userID:  1,
-				page:    0,
-				learned: true,
+				ctx:    context.Background(),
+				userID: 1,
+				list:   models.WordList{Deck: models.DeckLearned},
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{
+				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{
 					{WordText: "test", Translation: "тест", LastSeen: now},
 				}, 15, nil)
 			},
@@ -604,13 +654,12 @@ func TestWordS_Words(t *testing.T) {
 		{
 			name: "error: empty list",
 			args: args{
-				ctx:     context.Background(),
-				userID:  1,
-				page:    0,
-				learned: true,
+				ctx:    context.Background(),
+				userID: 1,
+				list:   models.WordList{Deck: models.DeckLearned},
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{}, 0, nil)
+				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{}, 0, nil)
 			},
 			wantErr: true,
 			want:    "",
@@ -619,13 +668,12 @@ func TestWordS_Words(t *testing.T) {
 		{
 			name: "repo error",
 			args: args{
-				ctx:     context.Background(),
-				userID:  1,
-				page:    0,
-				learned: true,
+				ctx:    context.Background(),
+				userID: 1,
+				list:   models.WordList{Deck: models.DeckLearned},
 			},
 			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
-				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{}, 0, errors.New("db error"))
+				mri.EXPECT().Words(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WordCard{}, 0, errors.New("db error"))
 			},
 			wantErr: true,
 			want:    "",
@@ -641,7 +689,7 @@ func TestWordS_Words(t *testing.T) {
 
 			wordService := newWordServiceMock(t, ctrl, tt.f)
 
-			got, got1, err := wordService.Words(tt.args.ctx, tt.args.userID, tt.args.page, tt.args.learned)
+			got, got1, err := wordService.Words(tt.args.ctx, tt.args.userID, tt.args.list, i18n.Default)
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Empty(t, got)
@@ -656,6 +704,90 @@ func TestWordS_Words(t *testing.T) {
 	}
 }
 
+func TestWordS_SavedWord(t *testing.T) {
+	t.Parallel()
+
+	dictData := dictDefinition("apple", "яблоко", "noun", "A round fruit.")
+	dictData.Pronunciation.SourceTextAudio = "https://example.com/apple.mp3"
+	details := &models.WordDetails{
+		Translation: models.MyMemoryTranslationResult{Text: "яблоко"},
+		Dictionary:  dictData,
+	}
+
+	tests := []struct {
+		name     string
+		word     string
+		f        func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		want     string
+		wantCard models.WordCard
+		wantErr  error
+	}{
+		{
+			name: "with details",
+			word: " Apple ",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Word(gomock.Any(), int64(1), "apple").
+					Return(models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Details: details}, true, nil)
+			},
+			want: formatTranslation(i18n.Default, details.Translation, details.Dictionary),
+			wantCard: models.WordCard{
+				UserID:      1,
+				WordText:    "apple",
+				Translation: "яблоко",
+				Audio:       "https://example.com/apple.mp3",
+				Details:     details,
+			},
+		},
+		{
+			name: "without details",
+			word: "cat",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Word(gomock.Any(), int64(1), "cat").
+					Return(models.WordCard{UserID: 1, WordText: "cat", Translation: "кот"}, true, nil)
+			},
+			want: formatTranslation(i18n.Default, models.MyMemoryTranslationResult{},
+				models.TranslationResponse{SourceText: "cat", DestinationText: "кот"}),
+			wantCard: models.WordCard{UserID: 1, WordText: "cat", Translation: "кот"},
+		},
+		{
+			name: "not saved",
+			word: "dog",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Word(gomock.Any(), int64(1), "dog").Return(models.WordCard{}, false, nil)
+			},
+			wantErr: models.ErrNoWord,
+		},
+		{
+			name: "repo error",
+			word: "dog",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().Word(gomock.Any(), int64(1), "dog").Return(models.WordCard{}, false, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wordService := newWordServiceMock(t, ctrl, tt.f)
+
+			got, card, err := wordService.SavedWord(context.Background(), 1, tt.word, i18n.Default)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCard, card)
+		})
+	}
+}
+
 func TestWordS_WordStat(t *testing.T) {
 	t.Parallel()
 
@@ -760,7 +892,7 @@ func TestWordS_WordStat(t *testing.T) {
 
 			wordService := newWordServiceMock(t, ctrl, tt.f)
 
-			got, err := wordService.WordStat(tt.args.ctx, tt.args.userID)
+			got, err := wordService.WordStat(tt.args.ctx, tt.args.userID, i18n.Default)
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Empty(t, got)
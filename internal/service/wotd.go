@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/i18n"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"go.uber.org/zap"
+)
+
+// wotdLangPair is the only pair the translation APIs are used for so far.
+const wotdLangPair = "en-ru"
+
+//go:embed data/wotd_words.txt
+var wotdWordsFile string
+
+var wotdWords = strings.Fields(wotdWordsFile)
+
+type WotdRI interface {
+	WordOfDay(ctx context.Context, day time.Time, langPair string) (models.WordOfDay, bool, error)
+	SaveWordOfDay(ctx context.Context, wotd models.WordOfDay) error
+	SubscribeWotd(ctx context.Context, userID, chatID int64) error
+	UnsubscribeWotd(ctx context.Context, userID int64) error
+	WotdSubscribed(ctx context.Context, userID int64) (bool, error)
+	WotdSubscribers(ctx context.Context, day time.Time) ([]models.WotdSubscriber, error)
+	MarkWotdSent(ctx context.Context, userID int64, day time.Time) error
+}
+
+type WotdS struct {
+	myMemory       MyMemoryAPII
+	pythonAnyWhere PythonAnyWhereAPII
+	repo           WotdRI
+	log            *zap.Logger
+	now            func() time.Time
+}
+
+func NewWotdService(api APII, repo WotdRI, log *zap.Logger) *WotdS {
+	return &WotdS{
+		myMemory:       api,
+		pythonAnyWhere: api,
+		repo:           repo,
+		log:            log,
+		now:            time.Now,
+	}
+}
+
+// WordOfDay returns today's word as a dictionary card. The word is picked and
+// translated once per day, later calls read it from the database.
+func (s *WotdS) WordOfDay(ctx context.Context, lang string) (string, models.WordCard, error) {
+	day := models.StartOfDay(s.now())
+
+	wotd, found, err := s.repo.WordOfDay(ctx, day, wotdLangPair)
+	if err != nil {
+		s.log.Warn("failed to get word of the day", zap.Error(err))
+		return "", models.WordCard{}, err
+	}
+
+	if !found {
+		wotd, err = s.newWordOfDay(ctx, day)
+		if err != nil {
+			s.log.Error("failed to build word of the day", zap.Error(err))
+			return "", models.WordCard{}, err
+		}
+		if err := s.repo.SaveWordOfDay(ctx, wotd); err != nil {
+			s.log.Warn("failed to save word of the day", zap.Error(err))
+		}
+	}
+
+	text := i18n.T(lang, "wotd.title", day.Format("02.01.2006")) + "\n\n" +
+		formatTranslation(lang, wotd.Card.Translation, wotd.Card.Dictionary)
+
+	card := models.WordCard{
+		WordText:    wotd.Word,
+		Translation: wotd.Translation,
+		Audio:       wotd.Card.Dictionary.Pronunciation.SourceTextAudio,
+		Details:     &wotd.Card,
+	}
+
+	return text, card, nil
+}
+
+func (s *WotdS) newWordOfDay(ctx context.Context, day time.Time) (models.WordOfDay, error) {
+	word := pickWordOfDay(day, wotdLangPair)
+
+	translate, err := s.myMemory.TranslateEnToRu(ctx, word)
+	if err != nil {
+		s.log.Warn("failed to translate word of the day", zap.String("word", word), zap.Error(err))
+	}
+
+	dictData, err := s.pythonAnyWhere.DictionaryData(ctx, word)
+	if err != nil {
+		s.log.Warn("failed to get dictionary data for word of the day", zap.String("word", word), zap.Error(err))
+		dictData.SourceText = word
+	}
+
+	translation := translate.Text
+	if translation == "" {
+		translation = dictData.DestinationText
+	}
+	if translation == "" {
+		return models.WordOfDay{}, fmt.Errorf("failed to translate word '%s'", word)
+	}
+
+	if dictData.DestinationText == "" {
+		dictData.DestinationText = translation
+	}
+
+	return models.WordOfDay{
+		Day:         day,
+		LangPair:    wotdLangPair,
+		Word:        word,
+		Translation: translation,
+		Card: models.WordDetails{
+			Translation: translate,
+			Dictionary:  dictData,
+		},
+	}, nil
+}
+
+// pickWordOfDay walks a permutation of the word list seeded by the year and
+// the language pair: every instance picks the same word for a date and a word
+// doesn't come back within the year.
+func pickWordOfDay(day time.Time, langPair string) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s", day.Year(), langPair)
+
+	perm := rand.New(rand.NewPCG(h.Sum64(), 0)).Perm(len(wotdWords))
+
+	return wotdWords[perm[(day.YearDay()-1)%len(wotdWords)]]
+}
+
+func (s *WotdS) SubscribeWotd(ctx context.Context, userID, chatID int64) error {
+	return s.repo.SubscribeWotd(ctx, userID, chatID)
+}
+
+func (s *WotdS) UnsubscribeWotd(ctx context.Context, userID int64) error {
+	return s.repo.UnsubscribeWotd(ctx, userID)
+}
+
+func (s *WotdS) WotdSubscribed(ctx context.Context, userID int64) (bool, error) {
+	return s.repo.WotdSubscribed(ctx, userID)
+}
+
+// WotdSubscribers returns the subscribers still waiting for today's word.
+func (s *WotdS) WotdSubscribers(ctx context.Context) ([]models.WotdSubscriber, error) {
+	return s.repo.WotdSubscribers(ctx, models.StartOfDay(s.now()))
+}
+
+func (s *WotdS) MarkWotdSent(ctx context.Context, userID int64) error {
+	return s.repo.MarkWotdSent(ctx, userID, models.StartOfDay(s.now()))
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	mock_service "github.com/DanRulev/vocabot.git/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newWotdServiceMock(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI, *mock_service.MockAPII)) *WotdS {
+	api := mock_service.NewMockAPII(ctrl)
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo, api)
+	}
+
+	return &WotdS{
+		myMemory:       api,
+		pythonAnyWhere: api,
+		repo:           repo,
+		log:            zap.NewNop(),
+		now:            func() time.Time { return statsNow },
+	}
+}
+
+func Test_pickWordOfDay(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, pickWordOfDay(day, wotdLangPair), pickWordOfDay(day.Add(15*time.Hour), wotdLangPair), "same word all day")
+
+	seen := make(map[string]bool)
+	for d := day; d.Year() == 2025; d = d.AddDate(0, 0, 1) {
+		word := pickWordOfDay(d, wotdLangPair)
+		assert.False(t, seen[word], "%s repeats on %s", word, d.Format("02.01"))
+		seen[word] = true
+	}
+}
+
+func TestWotdS_WordOfDay(t *testing.T) {
+	t.Parallel()
+
+	today := day(10)
+	word := pickWordOfDay(today, wotdLangPair)
+
+	tests := []struct {
+		name     string
+		f        func(*mock_service.MockRepositoryI, *mock_service.MockAPII)
+		wantWord models.WordCard
+		wantText string
+		wantErr  bool
+	}{
+		{
+			name: "stored",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().WordOfDay(gomock.Any(), today, wotdLangPair).Return(models.WordOfDay{
+					Day: today, LangPair: wotdLangPair, Word: "apple", Translation: "яблоко",
+					Card: models.WordDetails{
+						Translation: models.MyMemoryTranslationResult{Text: "яблоко"},
+						Dictionary:  models.TranslationResponse{SourceText: "apple"},
+					},
+				}, true, nil)
+			},
+			wantWord: models.WordCard{WordText: "apple", Translation: "яблоко"},
+			wantText: "apple",
+		},
+		{
+			name: "picked and saved",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().WordOfDay(gomock.Any(), today, wotdLangPair).Return(models.WordOfDay{}, false, nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), word).Return(models.MyMemoryTranslationResult{Text: "перевод"}, nil)
+				ma.EXPECT().DictionaryData(gomock.Any(), word).Return(models.TranslationResponse{SourceText: word}, nil)
+				mri.EXPECT().SaveWordOfDay(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, wotd models.WordOfDay) error {
+					assert.Equal(t, today, wotd.Day)
+					assert.Equal(t, word, wotd.Word)
+					assert.Equal(t, "перевод", wotd.Card.Dictionary.DestinationText)
+					return nil
+				})
+			},
+			wantWord: models.WordCard{WordText: word, Translation: "перевод"},
+			wantText: word,
+		},
+		{
+			name: "no translation",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().WordOfDay(gomock.Any(), today, wotdLangPair).Return(models.WordOfDay{}, false, nil)
+				ma.EXPECT().TranslateEnToRu(gomock.Any(), word).Return(models.MyMemoryTranslationResult{}, errors.New("api error"))
+				ma.EXPECT().DictionaryData(gomock.Any(), word).Return(models.TranslationResponse{}, errors.New("api error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "repo error",
+			f: func(mri *mock_service.MockRepositoryI, ma *mock_service.MockAPII) {
+				mri.EXPECT().WordOfDay(gomock.Any(), today, wotdLangPair).Return(models.WordOfDay{}, false, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wotd := newWotdServiceMock(t, ctrl, tt.f)
+
+			text, card, err := wotd.WordOfDay(context.Background(), "en")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, card.Details)
+			assert.Equal(t, tt.wantWord.WordText, card.Details.Dictionary.SourceText)
+			card.Details = nil
+			assert.Equal(t, tt.wantWord, card)
+			assert.True(t, strings.HasPrefix(text, "🌟 *Word of the day* — 10.03.2025\n\n"), text)
+			assert.Contains(t, text, tt.wantText)
+		})
+	}
+}
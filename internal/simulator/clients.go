@@ -0,0 +1,111 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+)
+
+type entry struct {
+	word         string
+	translation  string
+	partOfSpeech string
+	definition   string
+	example      string
+	synonyms     []string
+}
+
+// dictionary is all the simulator knows, other words have no translation or
+// dictionary data.
+var dictionary = []entry{
+	{word: "apple", translation: "яблоко", partOfSpeech: "noun", definition: "a round fruit with red or green skin", example: "She ate an apple."},
+	{word: "house", translation: "дом", partOfSpeech: "noun", definition: "a building for people to live in", example: "They bought a new house.", synonyms: []string{"home"}},
+	{word: "walk", translation: "гулять", partOfSpeech: "verb", definition: "to move along on foot", example: "We walk to school every day."},
+	{word: "big", translation: "большой", partOfSpeech: "adjective", definition: "of considerable size", example: "It was a big mistake.", synonyms: []string{"large", "huge"}},
+	{word: "run", translation: "бежать", partOfSpeech: "verb", definition: "to move fast on foot", example: "He can run very fast."},
+	{word: "green", translation: "зелёный", partOfSpeech: "adjective", definition: "of the colour of grass", example: "The leaves are green."},
+	{word: "friend", translation: "друг", partOfSpeech: "noun", definition: "a person you know well and like", example: "He is my best friend.", synonyms: []string{"pal"}},
+	{word: "sing", translation: "петь", partOfSpeech: "verb", definition: "to make music with your voice", example: "Birds sing in the morning."},
+}
+
+var errOffline = errors.New("not available offline")
+
+// Clients stands in for the translation, dictionary, random word and audio
+// APIs with a small built-in dictionary, so the simulator needs no network.
+type Clients struct {
+	mu   sync.Mutex
+	next int
+}
+
+func NewClients() *Clients {
+	return &Clients{}
+}
+
+// RandomWord goes through the dictionary in order.
+func (c *Clients) RandomWord(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	word := dictionary[c.next%len(dictionary)].word
+	c.next++
+	return word, nil
+}
+
+func (c *Clients) TranslateEnToRu(ctx context.Context, text string) (models.MyMemoryTranslationResult, error) {
+	e, ok := lookup(text)
+	if !ok {
+		return models.MyMemoryTranslationResult{}, fmt.Errorf("%q: %w", text, errOffline)
+	}
+	return models.MyMemoryTranslationResult{Text: e.translation, Match: 1, Reliable: true}, nil
+}
+
+func (c *Clients) TranslateEnToRuBatch(ctx context.Context, texts []string) ([]models.MyMemoryTranslationResult, error) {
+	results := make([]models.MyMemoryTranslationResult, len(texts))
+	for i, text := range texts {
+		result, err := c.TranslateEnToRu(ctx, text)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (c *Clients) DictionaryData(ctx context.Context, word string) (models.TranslationResponse, error) {
+	e, ok := lookup(word)
+	if !ok {
+		return models.TranslationResponse{}, fmt.Errorf("%q: %w", word, errOffline)
+	}
+
+	resp := models.TranslationResponse{SourceText: e.word, DestinationText: e.translation}
+	resp.Definitions = append(resp.Definitions, struct {
+		PartOfSpeech  string              `json:"part-of-speech"`
+		Definition    string              `json:"definition"`
+		Example       string              `json:"example"`
+		OtherExamples []string            `json:"other-examples"`
+		Synonyms      map[string][]string `json:"synonyms"`
+	}{
+		PartOfSpeech: e.partOfSpeech,
+		Definition:   e.definition,
+		Example:      e.example,
+		Synonyms:     map[string][]string{e.partOfSpeech: e.synonyms},
+	})
+	return resp, nil
+}
+
+// Audio has no pronunciations, listening quizzes fall back to regular ones.
+func (c *Clients) Audio(ctx context.Context, word, url string) (models.Audio, error) {
+	return models.Audio{}, fmt.Errorf("audio of %q: %w", word, errOffline)
+}
+
+func lookup(word string) (entry, bool) {
+	for _, e := range dictionary {
+		if e.word == word {
+			return e, true
+		}
+	}
+	return entry{}, false
+}
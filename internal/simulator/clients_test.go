@@ -0,0 +1,43 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClients(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clients := NewClients()
+
+	// Every random word can be translated and looked up.
+	for range dictionary {
+		word, err := clients.RandomWord(ctx)
+		require.NoError(t, err)
+
+		translation, err := clients.TranslateEnToRu(ctx, word)
+		require.NoError(t, err)
+		assert.NotEmpty(t, translation.Text)
+
+		data, err := clients.DictionaryData(ctx, word)
+		require.NoError(t, err)
+		assert.Equal(t, translation.Text, data.DestinationText)
+		require.Len(t, data.Definitions, 1)
+		assert.Contains(t, data.Definitions[0].Example, word)
+	}
+
+	_, err := clients.TranslateEnToRu(ctx, "serendipity")
+	assert.ErrorIs(t, err, errOffline)
+
+	results, err := clients.TranslateEnToRuBatch(ctx, []string{"apple", "serendipity"})
+	require.NoError(t, err)
+	assert.Equal(t, "яблоко", results[0].Text)
+	assert.NotEmpty(t, results[1].Error)
+
+	_, err = clients.Audio(ctx, "apple", "")
+	assert.ErrorIs(t, err, errOffline)
+}
@@ -0,0 +1,251 @@
+package simulator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/DanRulev/vocabot.git/internal/chat"
+)
+
+type Dispatcher interface {
+	Handle(event chat.Event)
+}
+
+type choice struct {
+	text      string
+	data      string
+	messageID int
+	inline    bool
+}
+
+// Simulator is an offline chat frontend. It implements bot.BotSender by
+// printing outgoing messages, and turns typed lines into events for the
+// dispatcher, so flows can be tried without a bot token.
+type Simulator struct {
+	mu       sync.Mutex
+	out      io.Writer
+	user     chat.User
+	chatID   int64
+	nextID   int
+	updateID int
+	texts    map[int]string
+	menu     []choice
+	choices  []choice
+}
+
+func New(out io.Writer, userID int64) *Simulator {
+	return &Simulator{
+		out:    out,
+		user:   chat.User{ID: userID, FirstName: "Simulator", LanguageCode: "ru"},
+		chatID: userID,
+		texts:  make(map[int]string),
+	}
+}
+
+func (s *Simulator) Send(msg chat.Message) (chat.Sent, error) {
+	if msg.Poll != nil {
+		return chat.Sent{}, chat.ErrUnsupported
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := s.nextID
+	s.texts[id] = msg.Text
+
+	fmt.Fprintf(s.out, "\n🤖 [#%d]\n", id)
+	if msg.Photo != nil {
+		fmt.Fprintf(s.out, "[photo %s, %d bytes]\n", msg.Photo.Name, len(msg.Photo.Data))
+	}
+	if msg.Audio != nil {
+		fmt.Fprintf(s.out, "[audio %s, %d bytes]\n", msg.Audio.Name, len(msg.Audio.Data))
+	}
+	if msg.Text != "" {
+		fmt.Fprintf(s.out, "%s\n", msg.Text)
+	}
+	if len(msg.Buttons) > 0 {
+		s.setInline(id, msg.Buttons)
+	} else if len(msg.Menu) > 0 {
+		s.setMenu(msg.Menu)
+	}
+
+	return chat.Sent{MessageID: id}, nil
+}
+
+func (s *Simulator) Edit(edit chat.Edit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.texts[edit.MessageID]; !ok {
+		return fmt.Errorf("message %d not found", edit.MessageID)
+	}
+	s.texts[edit.MessageID] = edit.Text
+
+	fmt.Fprintf(s.out, "\n🤖 [#%d edited]\n%s\n", edit.MessageID, edit.Text)
+	if len(edit.Buttons) > 0 {
+		s.setInline(edit.MessageID, edit.Buttons)
+	} else {
+		s.dropChoices(edit.MessageID)
+	}
+
+	return nil
+}
+
+func (s *Simulator) AnswerCallback(callbackID, text string) error {
+	if text != "" {
+		s.mu.Lock()
+		fmt.Fprintf(s.out, "\n💬 %s\n", text)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// AnswerInline is not supported, there are no inline queries here.
+func (s *Simulator) AnswerInline(queryID string, results []chat.InlineResult) error {
+	return chat.ErrUnsupported
+}
+
+// DownloadFile is not supported, users can't send files here.
+func (s *Simulator) DownloadFile(fileID string) ([]byte, error) {
+	return nil, chat.ErrUnsupported
+}
+
+func (s *Simulator) setMenu(menu [][]string) {
+	s.menu = s.menu[:0]
+	for _, row := range menu {
+		for _, text := range row {
+			s.menu = append(s.menu, choice{text: text, data: text})
+		}
+	}
+	s.choices = append([]choice(nil), s.menu...)
+	s.printChoices()
+}
+
+func (s *Simulator) setInline(messageID int, rows [][]chat.Button) {
+	s.choices = s.choices[:0]
+	for _, row := range rows {
+		for _, b := range row {
+			s.choices = append(s.choices, choice{text: b.Text, data: b.Data, messageID: messageID, inline: true})
+		}
+	}
+	s.printChoices()
+}
+
+func (s *Simulator) dropChoices(messageID int) {
+	for _, c := range s.choices {
+		if c.inline && c.messageID == messageID {
+			s.choices = append([]choice(nil), s.menu...)
+			return
+		}
+	}
+}
+
+func (s *Simulator) printChoices() {
+	for i, c := range s.choices {
+		fmt.Fprintf(s.out, "  %d) %s\n", i+1, c.text)
+	}
+}
+
+// Type feeds a line as if the user had typed it into the chat.
+func (s *Simulator) Type(d Dispatcher, text string) {
+	s.mu.Lock()
+	s.nextID++
+	event := chat.Event{
+		Type:      chat.EventMessage,
+		ChatID:    s.chatID,
+		From:      s.user,
+		MessageID: s.nextID,
+		Text:      text,
+	}
+	if strings.HasPrefix(text, "/") {
+		command, args, _ := strings.Cut(strings.TrimPrefix(text, "/"), " ")
+		event.Type = chat.EventCommand
+		event.Command = command
+		event.Args = strings.TrimSpace(args)
+	}
+	s.mu.Unlock()
+
+	d.Handle(event)
+}
+
+// Press chooses the n-th (1-based) currently listed button.
+func (s *Simulator) Press(d Dispatcher, n int) error {
+	s.mu.Lock()
+	if n < 1 || n > len(s.choices) {
+		s.mu.Unlock()
+		return fmt.Errorf("no choice %d", n)
+	}
+	c := s.choices[n-1]
+	s.mu.Unlock()
+
+	if !c.inline {
+		s.Type(d, c.data)
+		return nil
+	}
+
+	s.mu.Lock()
+	s.updateID++
+	event := chat.Event{
+		Type:       chat.EventCallback,
+		ChatID:     s.chatID,
+		From:       s.user,
+		MessageID:  c.messageID,
+		Text:       s.texts[c.messageID],
+		CallbackID: strconv.Itoa(s.updateID),
+		Data:       c.data,
+	}
+	s.mu.Unlock()
+
+	d.Handle(event)
+	return nil
+}
+
+const replHelp = `Type any text or /command to send it to the bot.
+A number presses the listed button with that number.
+  :menu  show the main keyboard again
+  :help  show this help
+  :quit  exit`
+
+// Run reads lines from in until EOF or :quit.
+func (s *Simulator) Run(d Dispatcher, in io.Reader) error {
+	fmt.Fprintln(s.out, replHelp)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(s.out, "\n> ")
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		switch line {
+		case "":
+			continue
+		case ":quit", ":q":
+			return nil
+		case ":help":
+			fmt.Fprintln(s.out, replHelp)
+			continue
+		case ":menu":
+			s.mu.Lock()
+			s.choices = append([]choice(nil), s.menu...)
+			s.printChoices()
+			s.mu.Unlock()
+			continue
+		}
+
+		if n, err := strconv.Atoi(line); err == nil {
+			if err := s.Press(d, n); err != nil {
+				fmt.Fprintln(s.out, "⚠️", err)
+			}
+			continue
+		}
+
+		s.Type(d, line)
+	}
+}
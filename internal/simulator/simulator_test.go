@@ -0,0 +1,131 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/bot"
+	mock_bot "github.com/DanRulev/vocabot.git/internal/bot/mock"
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/storage/cache"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScenario(t *testing.T, setupMock func(*mock_bot.MockServiceI)) (*Simulator, *bot.Handler, *bytes.Buffer) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	service := mock_bot.NewMockServiceI(ctrl)
+	service.EXPECT().Language(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	service.EXPECT().IsBanned(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	service.EXPECT().TouchUser(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RecordActivity(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", nil).AnyTimes()
+	if setupMock != nil {
+		setupMock(service)
+	}
+	service.EXPECT().UserSettings(gomock.Any(), gomock.Any()).Return(models.UserSettings{}, nil).AnyTimes()
+
+	out := &bytes.Buffer{}
+	sim := New(out, 42)
+	sessions := cache.NewCache(time.Hour, 0)
+	t.Cleanup(sessions.Close)
+
+	return sim, bot.NewHandler(sim, service, sessions), out
+}
+
+func TestScenario_NewWordKnow(t *testing.T) {
+	t.Parallel()
+
+	sim, handler, out := newScenario(t, func(ms *mock_bot.MockServiceI) {
+		ms.EXPECT().UserSettings(gomock.Any(), int64(42)).Return(models.UserSettings{Onboarded: true}, nil)
+		ms.EXPECT().RandomWord(gomock.Any(), gomock.Any()).Return("📚 *Слово*: **apple**", models.WordCard{WordText: "apple", Translation: "яблоко"}, nil)
+		ms.EXPECT().AddWord(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, word models.WordCard) error {
+			assert.Equal(t, int64(42), word.UserID)
+			assert.Equal(t, "apple", word.WordText)
+			assert.True(t, word.Known)
+			return nil
+		})
+	})
+
+	sim.Type(handler, "/start")
+	assert.Contains(t, out.String(), "1) 📚 Новое слово")
+
+	require.NoError(t, sim.Press(handler, 1))
+	assert.Contains(t, out.String(), "**apple**")
+	assert.Contains(t, out.String(), "1) ✅ Знаю")
+
+	require.NoError(t, sim.Press(handler, 1))
+	assert.Contains(t, out.String(), "edited]")
+	assert.Contains(t, out.String(), "Слово отмечено как выученное")
+	assert.Contains(t, out.String(), "1) ❓ НОВОЕ СЛОВО")
+}
+
+func TestScenario_Onboarding(t *testing.T) {
+	t.Parallel()
+
+	sim, handler, out := newScenario(t, func(ms *mock_bot.MockServiceI) {
+		gomock.InOrder(
+			ms.EXPECT().UserSettings(gomock.Any(), int64(42)).Return(models.UserSettings{}, nil),
+			ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(42), models.UserSettings{TargetLanguage: "en"}).Return(nil),
+			ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(42), models.UserSettings{Level: "B1"}).Return(nil),
+			ms.EXPECT().UpdateUserSettings(gomock.Any(), int64(42), models.UserSettings{DailyGoal: 10, GoalType: models.GoalWords, Onboarded: true}).Return(nil),
+			ms.EXPECT().UserSettings(gomock.Any(), int64(42)).Return(models.UserSettings{TargetLanguage: "en", Level: "B1", DailyGoal: 10, Onboarded: true}, nil),
+		)
+	})
+
+	sim.Type(handler, "/start")
+	assert.Contains(t, out.String(), "Какой язык ты изучаешь?")
+	assert.Contains(t, out.String(), "1) 🇬🇧 Английский")
+	assert.NotContains(t, out.String(), "📚 Новое слово", "the menu comes after the wizard")
+
+	require.NoError(t, sim.Press(handler, 1))
+	assert.Contains(t, out.String(), "Какой у тебя уровень?")
+	assert.Contains(t, out.String(), "3) B1 · Средний")
+
+	require.NoError(t, sim.Press(handler, 3))
+	assert.Contains(t, out.String(), "2) 10 слов")
+
+	require.NoError(t, sim.Press(handler, 2))
+	assert.Contains(t, out.String(), "Готово: 🇬🇧 Английский, уровень B1 · Средний, 10 слов в день.")
+	assert.Contains(t, out.String(), "1) 📚 Новое слово")
+}
+
+func TestScenario_Quiz(t *testing.T) {
+	t.Parallel()
+
+	sim, handler, out := newScenario(t, func(ms *mock_bot.MockServiceI) {
+		// The simulator can't show polls, the question falls back to buttons.
+		ms.EXPECT().UserSettings(gomock.Any(), int64(42)).Return(models.UserSettings{QuizMode: models.QuizModePoll}, nil)
+		ms.EXPECT().NewQuiz(gomock.Any(), int64(42)).Return("hello", map[string]bool{"привет": true}, nil)
+		ms.EXPECT().AddQuizResult(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, card models.QuizCard) error {
+			assert.True(t, card.IsCorrect)
+			assert.Equal(t, "hello", card.Word)
+			return nil
+		})
+	})
+
+	sim.Type(handler, "🧠 Викторина")
+	assert.Contains(t, out.String(), "Как переводится: hello")
+	assert.Contains(t, out.String(), "1) привет")
+
+	require.NoError(t, sim.Press(handler, 1))
+	assert.Contains(t, out.String(), "✅ Правильно! привет")
+}
+
+func TestSimulator_Run(t *testing.T) {
+	t.Parallel()
+
+	sim, handler, out := newScenario(t, nil)
+
+	in := strings.NewReader("/help\n7\n:quit\n/start\n")
+	require.NoError(t, sim.Run(handler, in))
+
+	assert.Contains(t, out.String(), "Доступные команды")
+	assert.Contains(t, out.String(), "no choice 7")
+	assert.NotContains(t, out.String(), "Привет! Я — бот", "input after :quit is ignored")
+}
@@ -1,58 +1,194 @@
 package cache
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/DanRulev/vocabot.git/internal/models"
 )
 
+type entry struct {
+	value     any
+	expiresAt time.Time
+}
+
+type itemKey struct {
+	kind string
+	key  models.SessionKey
+	poll string
+}
+
 type Cache struct {
 	mu    sync.Mutex
-	words map[int64]models.WordCard
-	quiz  map[int64]models.QuizCard
+	ttl   time.Duration
+	items map[itemKey]entry
+	stop  chan struct{}
+	once  sync.Once
+}
+
+func NewCache(ttl, cleanupInterval time.Duration) *Cache {
+	c := &Cache{
+		ttl:   ttl,
+		items: make(map[itemKey]entry),
+		stop:  make(chan struct{}),
+	}
+
+	if cleanupInterval > 0 {
+		go c.janitor(cleanupInterval)
+	}
+
+	return c
+}
+
+func (c *Cache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *Cache) Close() {
+	c.once.Do(func() { close(c.stop) })
+}
+
+func (c *Cache) DeleteExpired() {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.items {
+		if now.After(e.expiresAt) {
+			delete(c.items, k)
+		}
+	}
+}
+
+func (c *Cache) set(k itemKey, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[k] = entry{
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *Cache) get(k itemKey) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, exists := c.items[k]
+	if !exists {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(c.items, k)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// take returns and removes the item under one lock, so only one caller gets it.
+func (c *Cache) take(k itemKey) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, exists := c.items[k]
+	if !exists {
+		return nil, false
+	}
+	delete(c.items, k)
+	if time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *Cache) delete(k itemKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, k)
+}
+
+func (c *Cache) SetWord(_ context.Context, key models.SessionKey, word models.WordCard) error {
+	c.set(itemKey{kind: models.SessionWord, key: key}, word)
+	return nil
+}
+
+func (c *Cache) GetWord(_ context.Context, key models.SessionKey) (models.WordCard, bool, error) {
+	v, exists := c.get(itemKey{kind: models.SessionWord, key: key})
+	if !exists {
+		return models.WordCard{}, false, nil
+	}
+	return v.(models.WordCard), true, nil
+}
+
+func (c *Cache) TakeWord(_ context.Context, key models.SessionKey) (models.WordCard, bool, error) {
+	v, exists := c.take(itemKey{kind: models.SessionWord, key: key})
+	if !exists {
+		return models.WordCard{}, false, nil
+	}
+	return v.(models.WordCard), true, nil
+}
+
+func (c *Cache) SetQuiz(_ context.Context, key models.SessionKey, quiz models.QuizCard) error {
+	c.set(itemKey{kind: models.SessionQuiz, key: key}, quiz)
+	return nil
+}
+
+func (c *Cache) GetQuiz(_ context.Context, key models.SessionKey) (models.QuizCard, bool, error) {
+	v, exists := c.get(itemKey{kind: models.SessionQuiz, key: key})
+	if !exists {
+		return models.QuizCard{}, false, nil
+	}
+	return v.(models.QuizCard), true, nil
 }
 
-func NewCache() *Cache {
-	return &Cache{
-		words: make(map[int64]models.WordCard),
-		quiz:  make(map[int64]models.QuizCard),
+func (c *Cache) TakeQuiz(_ context.Context, key models.SessionKey) (models.QuizCard, bool, error) {
+	v, exists := c.take(itemKey{kind: models.SessionQuiz, key: key})
+	if !exists {
+		return models.QuizCard{}, false, nil
 	}
+	return v.(models.QuizCard), true, nil
 }
 
-func (w *Cache) SetWord(userID int64, word models.WordCard) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	w.words[userID] = word
+func (c *Cache) SetChecklist(_ context.Context, key models.SessionKey, list models.WordChecklist) error {
+	c.set(itemKey{kind: models.SessionChecklist, key: key}, list)
+	return nil
 }
 
-func (w *Cache) GetWord(userID int64) (models.WordCard, bool) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	word, exists := w.words[userID]
-	return word, exists
+func (c *Cache) GetChecklist(_ context.Context, key models.SessionKey) (models.WordChecklist, bool, error) {
+	v, exists := c.get(itemKey{kind: models.SessionChecklist, key: key})
+	if !exists {
+		return models.WordChecklist{}, false, nil
+	}
+	return v.(models.WordChecklist), true, nil
 }
 
-func (w *Cache) DeleteWord(userID int64) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	delete(w.words, userID)
+func (c *Cache) DeleteChecklist(_ context.Context, key models.SessionKey) error {
+	c.delete(itemKey{kind: models.SessionChecklist, key: key})
+	return nil
 }
 
-func (w *Cache) SetQuiz(userID int64, quiz models.QuizCard) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	w.quiz[userID] = quiz
+func (c *Cache) SetPoll(_ context.Context, pollID string, key models.SessionKey) error {
+	c.set(itemKey{kind: models.SessionPoll, poll: pollID}, key)
+	return nil
 }
 
-func (w *Cache) GetQuiz(userID int64) (models.QuizCard, bool) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	quiz, exists := w.quiz[userID]
-	return quiz, exists
+func (c *Cache) GetPoll(_ context.Context, pollID string) (models.SessionKey, bool, error) {
+	v, exists := c.get(itemKey{kind: models.SessionPoll, poll: pollID})
+	if !exists {
+		return models.SessionKey{}, false, nil
+	}
+	return v.(models.SessionKey), true, nil
 }
 
-func (w *Cache) DeleteQuiz(userID int64) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	delete(w.quiz, userID)
+func (c *Cache) DeletePoll(_ context.Context, pollID string) error {
+	c.delete(itemKey{kind: models.SessionPoll, poll: pollID})
+	return nil
 }
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_WordsKeyedByMessage(t *testing.T) {
+	t.Parallel()
+
+	c := NewCache(time.Hour, 0)
+	defer c.Close()
+
+	ctx := context.Background()
+	first := models.SessionKey{ChatID: 1, MessageID: 1}
+	second := models.SessionKey{ChatID: 1, MessageID: 2}
+
+	require.NoError(t, c.SetWord(ctx, first, models.WordCard{WordText: "apple"}))
+	require.NoError(t, c.SetWord(ctx, second, models.WordCard{WordText: "pear"}))
+
+	word, exists, err := c.GetWord(ctx, first)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "apple", word.WordText)
+
+	word, exists, err = c.TakeWord(ctx, first)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "apple", word.WordText)
+
+	_, exists, _ = c.TakeWord(ctx, first)
+	assert.False(t, exists, "a session is taken once")
+
+	_, exists, _ = c.GetWord(ctx, first)
+	assert.False(t, exists)
+
+	word, exists, _ = c.GetWord(ctx, second)
+	require.True(t, exists)
+	assert.Equal(t, "pear", word.WordText)
+
+	_, exists, _ = c.GetQuiz(ctx, second)
+	assert.False(t, exists)
+}
+
+func TestCache_Expiration(t *testing.T) {
+	t.Parallel()
+
+	c := NewCache(10*time.Millisecond, 5*time.Millisecond)
+	defer c.Close()
+
+	ctx := context.Background()
+	key := models.SessionKey{ChatID: 1, MessageID: 1}
+
+	require.NoError(t, c.SetQuiz(ctx, key, models.QuizCard{Word: "hello"}))
+
+	_, exists, _ := c.GetQuiz(ctx, key)
+	require.True(t, exists)
+
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.items) == 0
+	}, time.Second, 5*time.Millisecond)
+
+	_, exists, _ = c.GetQuiz(ctx, key)
+	assert.False(t, exists)
+}
+
+func TestCache_Poll(t *testing.T) {
+	t.Parallel()
+
+	c := NewCache(time.Hour, 0)
+	defer c.Close()
+
+	ctx := context.Background()
+	key := models.SessionKey{ChatID: 1, MessageID: 1}
+
+	require.NoError(t, c.SetPoll(ctx, "poll", key))
+	require.NoError(t, c.SetQuiz(ctx, key, models.QuizCard{Word: "hello"}))
+
+	got, exists, err := c.GetPoll(ctx, "poll")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, key, got)
+
+	require.NoError(t, c.DeletePoll(ctx, "poll"))
+
+	_, exists, _ = c.GetPoll(ctx, "poll")
+	assert.False(t, exists)
+
+	_, exists, _ = c.GetQuiz(ctx, key)
+	assert.True(t, exists, "deleting a poll keeps its quiz")
+}
+
+func TestCache_Checklist(t *testing.T) {
+	t.Parallel()
+
+	c := NewCache(time.Hour, 0)
+	defer c.Close()
+
+	ctx := context.Background()
+	key := models.SessionKey{ChatID: 1, MessageID: 1}
+	list := models.WordChecklist{
+		UserID:   1,
+		Words:    []models.TextWord{{Word: "meadow", Translation: "луг", Count: 2}},
+		Selected: []bool{true},
+	}
+
+	require.NoError(t, c.SetChecklist(ctx, key, list))
+	require.NoError(t, c.SetWord(ctx, key, models.WordCard{WordText: "hello"}))
+
+	got, exists, err := c.GetChecklist(ctx, key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, list, got)
+
+	require.NoError(t, c.DeleteChecklist(ctx, key))
+
+	_, exists, _ = c.GetChecklist(ctx, key)
+	assert.False(t, exists)
+
+	_, exists, _ = c.GetWord(ctx, key)
+	assert.True(t, exists, "deleting a checklist keeps the word")
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/DanRulev/vocabot.git/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"000002_second.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+				"000002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+				"000001_first.up.sql":    {Data: []byte("CREATE TABLE a ();")},
+				"000001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+				"migrations.go":          {Data: []byte("package migrations")},
+			},
+			want: []Migration{
+				{Version: 1, Name: "first", Up: "CREATE TABLE a ();", Down: "DROP TABLE a;"},
+				{Version: 2, Name: "second", Up: "CREATE TABLE b ();", Down: "DROP TABLE b;"},
+			},
+		},
+		{
+			name: "missing down",
+			fsys: fstest.MapFS{
+				"000001_first.up.sql": {Data: []byte("CREATE TABLE a ();")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflicting names",
+			fsys: fstest.MapFS{
+				"000001_first.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+				"000001_other.down.sql": {Data: []byte("DROP TABLE a;")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadMigrations(tt.fsys)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	t.Parallel()
+
+	got, err := LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, got)
+
+	for i, m := range got {
+		assert.Equal(t, uint(i+1), m.Version, "migration versions must be sequential")
+	}
+}
@@ -12,7 +12,9 @@ import (
 )
 
 func InitDB(cfg config.DBConfig) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf("host=%v port=%v dbname=%v user=%v password=%v sslmode=%v",
+	// Days are counted in UTC by the bot, the session time zone matches it so
+	// NOW() and ::date agree with the day boundaries passed in.
+	dsn := fmt.Sprintf("host=%v port=%v dbname=%v user=%v password=%v sslmode=%v timezone=UTC",
 		cfg.Conn.Host, cfg.Conn.Port, cfg.Conn.Name, cfg.Conn.User, cfg.Conn.Password, cfg.Conn.SSL)
 	db, err := sqlx.Open("postgres", dsn)
 	if err != nil {
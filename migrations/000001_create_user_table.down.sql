@@ -1,3 +1,3 @@
-DROP TABLE IF EXISTS user_words;
+DROP TABLE IF EXISTS user_quiz_results;
 
-DROP TABLE IF EXISTS quiz_results;
\ No newline at end of file
+DROP TABLE IF EXISTS user_words;
\ No newline at end of file
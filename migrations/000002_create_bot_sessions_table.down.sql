@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bot_sessions;
\ No newline at end of file
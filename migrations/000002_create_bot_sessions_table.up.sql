@@ -0,0 +1,10 @@
+CREATE TABLE bot_sessions (
+    chat_id BIGINT NOT NULL,
+    message_id INTEGER NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (chat_id, message_id, kind)
+);
+
+CREATE INDEX bot_sessions_expires_at_idx ON bot_sessions (expires_at);
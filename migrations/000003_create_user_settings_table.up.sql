@@ -0,0 +1,5 @@
+CREATE TABLE user_settings (
+    user_id BIGINT PRIMARY KEY,
+    language VARCHAR(8) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS user_quiz_results_user_created_idx;
+
+ALTER TABLE user_words
+    DROP COLUMN IF EXISTS learned_at,
+    DROP COLUMN IF EXISTS created_at;
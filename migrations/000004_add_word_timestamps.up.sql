@@ -0,0 +1,9 @@
+ALTER TABLE user_words
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ADD COLUMN learned_at TIMESTAMP;
+
+UPDATE user_words
+SET created_at = COALESCE(last_seen, NOW()),
+    learned_at = CASE WHEN known THEN last_seen END;
+
+CREATE INDEX user_quiz_results_user_created_idx ON user_quiz_results (user_id, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS wotd_subscriptions;
+DROP TABLE IF EXISTS word_of_the_day;
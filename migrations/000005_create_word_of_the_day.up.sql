@@ -0,0 +1,16 @@
+CREATE TABLE word_of_the_day (
+    day DATE NOT NULL,
+    lang_pair VARCHAR(16) NOT NULL,
+    word VARCHAR(255) NOT NULL,
+    translation VARCHAR(255) NOT NULL,
+    card JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, lang_pair)
+);
+
+CREATE TABLE wotd_subscriptions (
+    user_id BIGINT PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    last_sent DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
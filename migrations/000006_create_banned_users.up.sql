@@ -0,0 +1,6 @@
+CREATE TABLE banned_users (
+    user_id BIGINT PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    banned_by BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,27 @@
+CREATE TABLE users (
+    user_id BIGINT PRIMARY KEY,
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    language_code VARCHAR(16) NOT NULL DEFAULT '',
+    settings JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_active_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Until now users only existed as ids in the other tables.
+INSERT INTO users (user_id, created_at, last_active_at)
+SELECT user_id, MIN(first_at), MAX(last_at)
+FROM (
+    SELECT user_id, MIN(created_at) AS first_at, MAX(COALESCE(last_seen, created_at)) AS last_at
+    FROM user_words GROUP BY user_id
+    UNION ALL
+    SELECT user_id, MIN(created_at), MAX(created_at)
+    FROM user_quiz_results GROUP BY user_id
+    UNION ALL
+    SELECT user_id, created_at, created_at FROM wotd_subscriptions
+) AS seen
+GROUP BY user_id;
+
+-- Users who already have words used the bot before the /start wizard existed.
+UPDATE users
+SET settings = settings || '{"onboarded": true}'
+WHERE user_id IN (SELECT user_id FROM user_words);
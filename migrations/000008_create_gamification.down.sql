@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS user_achievements;
+DROP TABLE IF EXISTS daily_goals;
+DROP TABLE IF EXISTS user_xp;
+DROP TABLE IF EXISTS xp_events;
+
+ALTER TABLE user_words
+    DROP COLUMN IF EXISTS chosen_at;
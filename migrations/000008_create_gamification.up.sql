@@ -0,0 +1,36 @@
+CREATE TABLE xp_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    activity VARCHAR(32) NOT NULL,
+    xp INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX xp_events_user_created_idx ON xp_events (user_id, created_at);
+
+-- When the user added the word themselves, the daily words goal counts these.
+-- Words saved by a quiz answer or a text import have none.
+ALTER TABLE user_words
+    ADD COLUMN chosen_at TIMESTAMP;
+
+-- Running totals of xp_events, the row lock orders concurrent awards so only
+-- one of them sees a level being crossed.
+CREATE TABLE user_xp (
+    user_id BIGINT PRIMARY KEY,
+    xp INTEGER NOT NULL
+);
+
+CREATE TABLE daily_goals (
+    user_id BIGINT NOT NULL,
+    day DATE NOT NULL,
+    target INTEGER NOT NULL,
+    reached_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, day)
+);
+
+CREATE TABLE user_achievements (
+    user_id BIGINT NOT NULL,
+    achievement VARCHAR(32) NOT NULL,
+    unlocked_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, achievement)
+);
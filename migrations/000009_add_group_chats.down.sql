@@ -0,0 +1,7 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS first_name;
+
+DROP TABLE IF EXISTS group_quiz_results;
+
+ALTER TABLE user_quiz_results
+    DROP COLUMN IF EXISTS chat_id;
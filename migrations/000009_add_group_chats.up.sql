@@ -0,0 +1,26 @@
+ALTER TABLE user_quiz_results
+    ADD COLUMN chat_id BIGINT;
+
+-- Until now the bot only worked in private chats, where the chat id is the
+-- user id.
+UPDATE user_quiz_results SET chat_id = user_id;
+
+ALTER TABLE user_quiz_results
+    ALTER COLUMN chat_id SET NOT NULL;
+
+-- Group quiz answers are kept apart: they make the chat leaderboard and
+-- don't count toward the members' own accuracy, hard words or goals.
+CREATE TABLE group_quiz_results (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    word VARCHAR(255) NOT NULL,
+    translation VARCHAR(255) NOT NULL,
+    is_correct BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX group_quiz_results_chat_created_idx ON group_quiz_results (chat_id, created_at);
+
+ALTER TABLE users
+    ADD COLUMN first_name VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+CREATE TABLE poll_sessions (
+    poll_id VARCHAR(64) PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    message_id INTEGER NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX poll_sessions_expires_at_idx ON poll_sessions (expires_at);
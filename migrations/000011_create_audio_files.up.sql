@@ -0,0 +1,7 @@
+CREATE TABLE audio_files (
+    word VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    file_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (word, kind)
+);
@@ -0,0 +1 @@
+ALTER TABLE user_words DROP COLUMN IF EXISTS details;
@@ -0,0 +1 @@
+ALTER TABLE user_words ADD COLUMN details JSONB;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS user_words_user_translation_prefix_idx;
+DROP INDEX IF EXISTS user_words_user_word_prefix_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX user_words_user_word_prefix_idx ON user_words (user_id, lower(word_text) text_pattern_ops);
+CREATE INDEX user_words_user_translation_prefix_idx ON user_words (user_id, lower(translation) text_pattern_ops);
@@ -0,0 +1,10 @@
+CREATE TABLE user_settings (
+    user_id BIGINT PRIMARY KEY,
+    language VARCHAR(8) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO user_settings (user_id, language, updated_at)
+SELECT user_id, settings->>'language', last_active_at
+FROM users
+WHERE settings->>'language' IS NOT NULL;
@@ -0,0 +1,10 @@
+-- Languages picked before the registry live in user_settings.
+INSERT INTO users (user_id, settings, created_at, last_active_at)
+SELECT user_id, jsonb_build_object('language', language), updated_at, updated_at
+FROM user_settings
+ON CONFLICT (user_id) DO UPDATE
+SET settings = users.settings || EXCLUDED.settings,
+    created_at = LEAST(users.created_at, EXCLUDED.created_at),
+    last_active_at = GREATEST(users.last_active_at, EXCLUDED.last_active_at);
+
+DROP TABLE user_settings;
@@ -0,0 +1,86 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminR(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewAdminRepository(conn)
+	ctx := context.Background()
+
+	_, err := conn.Exec(`INSERT INTO user_words (user_id, word_text, translation, last_seen, known, created_at) VALUES
+		(1, 'apple', 'яблоко', NOW(), true, NOW()),
+		(1, 'pear', 'груша', NOW() - INTERVAL '10 days', false, NOW() - INTERVAL '10 days'),
+		(2, 'plum', 'слива', NOW() - INTERVAL '3 days', false, NOW() - INTERVAL '3 days')`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO user_quiz_results (user_id, chat_id, word, translation, type, is_correct) VALUES
+		(1, 1, 'apple', 'яблоко', 'quiz', true),
+		(3, 3, 'pear', 'груша', 'quiz', false)`)
+	require.NoError(t, err)
+	// User 5 only browsed the menus today, user 2 never started the bot.
+	_, err = conn.Exec(`INSERT INTO users (user_id, settings, last_active_at) VALUES
+		(1, '{"language": "en"}', NOW()),
+		(3, '{}', NOW() - INTERVAL '20 days'),
+		(4, '{"language": "uk"}', NOW() - INTERVAL '20 days'),
+		(5, '{}', NOW())`)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Ban(ctx, 3, 100, "spam"))
+	require.NoError(t, repo.Ban(ctx, 3, 101, "flood"), "ban again updates the reason")
+
+	banned, err := repo.IsBanned(ctx, 3)
+	require.NoError(t, err)
+	assert.True(t, banned)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	stats, err := repo.GlobalStats(ctx, today, today.AddDate(0, 0, -6))
+	require.NoError(t, err)
+	assert.Equal(t, models.GlobalStats{
+		Users:       4,
+		ActiveToday: 3,
+		ActiveWeek:  4,
+		WordsTotal:  3,
+		WordsToday:  1,
+		QuizTotal:   2,
+		QuizToday:   2,
+		Banned:      1,
+	}, stats)
+
+	ids, err := repo.UserIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 4, 5}, ids, "banned and unregistered users get no broadcasts")
+
+	info, err := repo.UserInfo(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "en", info.Language)
+	assert.Equal(t, 2, info.Words)
+	assert.Equal(t, 1, info.Learned)
+	assert.Equal(t, 1, info.Quizzes)
+	assert.Equal(t, 1, info.Correct)
+	assert.NotNil(t, info.LastActive)
+	assert.False(t, info.Banned)
+
+	info, err = repo.UserInfo(ctx, 5)
+	require.NoError(t, err)
+	assert.NotNil(t, info.LastActive, "activity comes from the registry too")
+
+	info, err = repo.UserInfo(ctx, 99)
+	require.NoError(t, err)
+	assert.Equal(t, models.UserInfo{UserID: 99}, info)
+
+	require.NoError(t, repo.Unban(ctx, 3))
+	banned, err = repo.IsBanned(ctx, 3)
+	require.NoError(t, err)
+	assert.False(t, banned)
+}
@@ -0,0 +1,35 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudioR_FileIDs(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewAudioRepository(conn)
+	ctx := context.Background()
+
+	_, found, err := repo.AudioFileID(ctx, models.AudioCard, "apple")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, repo.SaveAudioFileID(ctx, models.AudioCard, "apple", "first"))
+	require.NoError(t, repo.SaveAudioFileID(ctx, models.AudioCard, "apple", "second"))
+
+	fileID, found, err := repo.AudioFileID(ctx, models.AudioCard, "apple")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "second", fileID)
+
+	_, found, err = repo.AudioFileID(ctx, models.AudioListening, "apple")
+	require.NoError(t, err)
+	assert.False(t, found, "the listening clip is cached apart from the card")
+}
@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameR(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewGameRepository(conn)
+	words := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	xp, err := repo.TotalXP(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, xp)
+
+	total, err := repo.AddXP(ctx, 1, models.ActivityWordKnown, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	total, err = repo.AddXP(ctx, 1, models.ActivityQuizRight, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 15, total)
+	_, err = repo.AddXP(ctx, 2, models.ActivityQuizRight, 10)
+	require.NoError(t, err)
+
+	xp, err = repo.TotalXP(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 15, xp)
+
+	since := time.Now().Add(-time.Minute)
+	require.NoError(t, words.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Known: true, Chosen: true}))
+
+	count, err := repo.ActivitiesSince(ctx, 1, since, []models.Activity{models.ActivityQuizRight, models.ActivityQuizWrong})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = repo.ActivitiesSince(ctx, 1, time.Now().Add(time.Hour), []models.Activity{models.ActivityQuizRight})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	require.NoError(t, words.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко"}))
+	count, err = repo.NewWordsSince(ctx, 1, since)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "a word saved again isn't new")
+
+	require.NoError(t, words.AddWord(ctx, models.WordCard{UserID: 1, WordText: "pear", Translation: "груша"}))
+	count, err = repo.NewWordsSince(ctx, 1, since)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "a word saved by a quiz or a text isn't chosen")
+
+	require.NoError(t, words.AddWord(ctx, models.WordCard{UserID: 1, WordText: "pear", Translation: "груша", Chosen: true}))
+	count, err = repo.NewWordsSince(ctx, 1, since)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "choosing a saved word counts it")
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	reached, err := repo.CompleteGoal(ctx, 1, day, 2)
+	require.NoError(t, err)
+	assert.True(t, reached)
+
+	reached, err = repo.CompleteGoal(ctx, 1, day, 2)
+	require.NoError(t, err)
+	assert.False(t, reached, "the goal counts once a day")
+
+	stats, err := repo.GameStats(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.GameStats{Learned: 1, GoalsReached: 1}, stats)
+
+	unlocked, err := repo.UnlockAchievement(ctx, 1, "goal_1")
+	require.NoError(t, err)
+	assert.True(t, unlocked)
+
+	unlocked, err = repo.UnlockAchievement(ctx, 1, "goal_1")
+	require.NoError(t, err)
+	assert.False(t, unlocked)
+
+	achievements, err := repo.Achievements(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, achievements, 1)
+	assert.Equal(t, "goal_1", achievements[0].Code)
+
+	achievements, err = repo.Achievements(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, achievements)
+}
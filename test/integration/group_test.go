@@ -0,0 +1,57 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupR_Leaderboard(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewGroupRepository(conn)
+	quiz := repository.NewQuizRepository(conn)
+	users := repository.NewUsersRepository(conn)
+	ctx := context.Background()
+
+	const group = -100
+	require.NoError(t, users.TouchUser(ctx, models.User{ID: 1, UserName: "ann", FirstName: "Ann"}))
+
+	results := []models.QuizCard{
+		{UserID: 1, ChatID: group, IsCorrect: true},
+		{UserID: 1, ChatID: group, IsCorrect: true},
+		{UserID: 2, ChatID: group, IsCorrect: true},
+		{UserID: 2, ChatID: group},
+		{UserID: 3, ChatID: group},
+		{UserID: 2, ChatID: -200, IsCorrect: true},
+	}
+	for _, r := range results {
+		r.Word, r.Translation, r.Type = "hello", "привет", models.GroupQuizType
+		require.NoError(t, repo.AddGroupResult(ctx, r))
+	}
+	require.NoError(t, quiz.AddQuizResult(ctx, models.QuizCard{UserID: 3, ChatID: group, Word: "hello", Translation: "привет", Type: "quiz", IsCorrect: true}))
+	_, err := conn.Exec(`INSERT INTO group_quiz_results (user_id, chat_id, word, translation, is_correct, created_at)
+		VALUES (3, $1, 'old', 'старый', true, NOW() - INTERVAL '30 days')`, group)
+	require.NoError(t, err)
+
+	entries, err := repo.Leaderboard(ctx, group, time.Now().AddDate(0, 0, -7), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []models.LeaderboardEntry{
+		{UserID: 1, UserName: "ann", FirstName: "Ann", Points: 2, Answers: 2},
+		{UserID: 2, Points: 1, Answers: 2},
+	}, entries, "only group quiz wins in this chat and period count")
+
+	entries, err = repo.Leaderboard(ctx, group, time.Now().AddDate(0, 0, -7), 1)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	stats, err := quiz.QuizStats(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.QuizStats{}, stats, "group answers don't count toward personal stats")
+}
@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/storage/db"
+	"github.com/DanRulev/vocabot.git/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// dsnEnv points at a disposable Postgres, e.g. the one started by
+// `make test-integration`. Every test gets its own schema inside it.
+const dsnEnv = "VOCABOT_TEST_DSN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(dsnEnv) == "" {
+		fmt.Printf("%s is not set, skipping integration tests\n", dsnEnv)
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// newSchema creates an isolated schema, applies all migrations to it and
+// returns a connection whose search_path points there.
+func newSchema(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv(dsnEnv)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	admin, err := sqlx.ConnectContext(ctx, "postgres", dsn)
+	require.NoError(t, err)
+
+	schema := fmt.Sprintf("it_%d_%d", time.Now().UnixNano(), rand.Intn(1_000_000))
+	_, err = admin.ExecContext(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+
+	conn, err := sqlx.ConnectContext(ctx, "postgres", withSchema(dsn, schema))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		_, _ = admin.ExecContext(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close()
+	})
+
+	migrator, err := db.NewMigrator(conn, migrations.FS)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	return conn
+}
+
+// withSchema points the connection at schema and runs it in UTC, like the
+// bot's own connection.
+func withSchema(dsn, schema string) string {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		if strings.Contains(dsn, "?") {
+			return dsn + "&timezone=UTC&search_path=" + schema
+		}
+		return dsn + "?timezone=UTC&search_path=" + schema
+	}
+	return dsn + " timezone=UTC search_path=" + schema
+}
@@ -0,0 +1,69 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/storage/db"
+	"github.com/DanRulev/vocabot.git/migrations"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tableNames(t *testing.T, conn *sqlx.DB) []string {
+	t.Helper()
+
+	var names []string
+	err := conn.Select(&names, `
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_name <> 'schema_migrations'
+		ORDER BY table_name`)
+	require.NoError(t, err)
+	return names
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	conn := newSchema(t)
+	ctx := context.Background()
+
+	migrator, err := db.NewMigrator(conn, migrations.FS)
+	require.NoError(t, err)
+
+	all, err := db.LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, all[len(all)-1].Version, status.Version)
+	assert.Empty(t, status.Pending)
+	assert.NotEmpty(t, tableNames(t, conn))
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, applied, "up must be idempotent")
+
+	for i := len(all) - 1; i >= 0; i-- {
+		reverted, err := migrator.Down(ctx, 1)
+		require.NoError(t, err, "down of %d_%s", all[i].Version, all[i].Name)
+		assert.Equal(t, 1, reverted)
+	}
+
+	assert.Empty(t, tableNames(t, conn), "down migrations must drop everything up created")
+
+	status, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, status.Version)
+	assert.Len(t, status.Pending, len(all))
+
+	reverted, err := migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, reverted)
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(all), applied)
+}
@@ -0,0 +1,63 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuizR_AddQuizResult_QuizStats(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewQuizRepository(conn)
+	ctx := context.Background()
+
+	stats, err := repo.QuizStats(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.QuizStats{}, stats)
+
+	results := []models.QuizCard{
+		{UserID: 1, Word: "apple", Translation: "яблоко", Type: "quiz", IsCorrect: true},
+		{UserID: 1, Word: "apple", Translation: "яблоко", Type: "quiz", IsCorrect: false},
+		{UserID: 1, Word: "pear", Translation: "груша", Type: "quiz", IsCorrect: true},
+		{UserID: 2, Word: "pear", Translation: "груша", Type: "quiz", IsCorrect: false},
+	}
+	for _, r := range results {
+		require.NoError(t, repo.AddQuizResult(ctx, r))
+	}
+
+	stats, err = repo.QuizStats(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.QuizStats{TotalCount: 3, RightCount: 2, WrongCount: 1}, stats)
+
+	var createdNull int
+	require.NoError(t, conn.Get(&createdNull, `SELECT COUNT(*) FROM user_quiz_results WHERE created_at IS NULL`))
+	assert.Zero(t, createdNull)
+}
+
+func TestQuizR_HardWords(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewQuizRepository(conn)
+	ctx := context.Background()
+
+	_, err := conn.Exec(`INSERT INTO user_quiz_results (user_id, chat_id, word, translation, type, is_correct, created_at) VALUES
+		(1, 1, 'apple', 'яблоко', 'quiz', false, NOW() - INTERVAL '3 hours'),
+		(1, 1, 'apple', 'яблоко', 'drill', true, NOW() - INTERVAL '2 hours'),
+		(1, 1, 'apple', 'яблоко', 'drill', true, NOW() - INTERVAL '1 hour'),
+		(1, 1, 'pear', 'груша', 'quiz', true, NOW()),
+		(2, 2, 'apple', 'яблоко', 'quiz', false, NOW())`)
+	require.NoError(t, err)
+
+	words, err := repo.HardWords(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, words, 1, "words without misses are not hard")
+	assert.Equal(t, "apple", words[0].Word)
+	assert.Equal(t, 3, words[0].Attempts)
+	assert.Equal(t, 1, words[0].Misses)
+	assert.Equal(t, 2, words[0].Streak)
+}
@@ -0,0 +1,132 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionR_RoundTrip(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewSessionRepository(conn, time.Hour)
+	ctx := context.Background()
+
+	key := models.SessionKey{ChatID: 1, MessageID: 10}
+
+	_, exists, err := repo.GetWord(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, repo.SetWord(ctx, key, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко"}))
+	require.NoError(t, repo.SetWord(ctx, key, models.WordCard{UserID: 1, WordText: "pear", Translation: "груша"}))
+	require.NoError(t, repo.SetQuiz(ctx, key, models.QuizCard{UserID: 1, Word: "cat", Translation: "кот"}))
+
+	word, exists, err := repo.GetWord(ctx, key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "pear", word.WordText, "set overwrites the same message")
+
+	quiz, exists, err := repo.GetQuiz(ctx, key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "cat", quiz.Word, "word and quiz sessions do not collide")
+
+	word, exists, err = repo.TakeWord(ctx, key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "pear", word.WordText)
+
+	_, exists, err = repo.TakeWord(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists, "a session is taken once")
+
+	_, exists, err = repo.GetWord(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	quiz, exists, err = repo.TakeQuiz(ctx, key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "cat", quiz.Word)
+}
+
+func TestSessionR_Expiration(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewSessionRepository(conn, -time.Minute)
+	ctx := context.Background()
+
+	key := models.SessionKey{ChatID: 1, MessageID: 10}
+	require.NoError(t, repo.SetQuiz(ctx, key, models.QuizCard{Word: "cat"}))
+
+	_, exists, err := repo.GetQuiz(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists, "expired sessions are invisible")
+
+	require.NoError(t, repo.DeleteExpired(ctx))
+
+	var count int
+	require.NoError(t, conn.Get(&count, `SELECT COUNT(*) FROM bot_sessions`))
+	assert.Zero(t, count)
+
+	var columnType string
+	require.NoError(t, conn.Get(&columnType, `
+		SELECT data_type FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'bot_sessions' AND column_name = 'expires_at'`))
+	assert.Equal(t, "timestamp with time zone", columnType)
+}
+
+func TestSessionR_Poll(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewSessionRepository(conn, time.Hour)
+	ctx := context.Background()
+
+	key := models.SessionKey{ChatID: 1, MessageID: 10}
+
+	_, exists, err := repo.GetPoll(ctx, "poll")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, repo.SetPoll(ctx, "poll", key))
+
+	got, exists, err := repo.GetPoll(ctx, "poll")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, key, got)
+
+	require.NoError(t, repo.DeletePoll(ctx, "poll"))
+	_, exists, err = repo.GetPoll(ctx, "poll")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestSessionR_Checklist(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewSessionRepository(conn, time.Hour)
+	ctx := context.Background()
+
+	key := models.SessionKey{ChatID: 1, MessageID: 10}
+	list := models.WordChecklist{
+		UserID:   1,
+		Words:    []models.TextWord{{Word: "meadow", Translation: "луг", Count: 2}, {Word: "brook", Translation: "ручей", Count: 1}},
+		Selected: []bool{false, true},
+	}
+
+	require.NoError(t, repo.SetChecklist(ctx, key, list))
+
+	got, exists, err := repo.GetChecklist(ctx, key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, list, got)
+
+	require.NoError(t, repo.DeleteChecklist(ctx, key))
+	_, exists, err = repo.GetChecklist(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,29 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsR_Language(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewSettingsRepository(conn)
+	ctx := context.Background()
+
+	lang, err := repo.Language(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, lang)
+
+	require.NoError(t, repo.SetLanguage(ctx, 1, "en"))
+	require.NoError(t, repo.SetLanguage(ctx, 1, "uk"))
+
+	lang, err = repo.Language(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "uk", lang)
+}
@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsR_Queries(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewStatsRepository(conn)
+	words := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	require.NoError(t, words.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко"}))
+	require.NoError(t, words.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Known: true}))
+	require.NoError(t, words.AddWord(ctx, models.WordCard{UserID: 1, WordText: "pear", Translation: "груша", Known: true}))
+	_, err := conn.Exec(`UPDATE user_words SET created_at = learned_at - INTERVAL '2 hours' WHERE user_id = 1`)
+	require.NoError(t, err)
+
+	_, err = conn.Exec(`INSERT INTO user_quiz_results (user_id, chat_id, word, translation, type, is_correct, created_at) VALUES
+		(1, 1, 'cat', 'кот', 'quiz', false, NOW()),
+		(1, 1, 'cat', 'кот', 'quiz', true, NOW()),
+		(1, 1, 'dog', 'собака', 'quiz', false, NOW() - INTERVAL '1 day'),
+		(1, 1, 'dog', 'собака', 'quiz', false, NOW() - INTERVAL '40 days'),
+		(2, 2, 'cat', 'кот', 'quiz', false, NOW())`)
+	require.NoError(t, err)
+
+	since := models.PeriodWeek.Since(time.Now())
+
+	days, err := repo.ActivityDays(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, days, 3)
+	assert.True(t, days[0].After(days[1]), "days are sorted newest first")
+
+	learned, err := repo.LearnedWords(ctx, 1, since, "day")
+	require.NoError(t, err)
+	require.Len(t, learned, 1)
+	assert.Equal(t, 2, learned[0].Count)
+
+	accuracy, err := repo.QuizAccuracy(ctx, 1, since, "day")
+	require.NoError(t, err)
+	require.Len(t, accuracy, 2)
+	assert.Equal(t, models.DayAccuracy{Day: accuracy[1].Day, Total: 2, Correct: 1}, accuracy[1])
+
+	byType, err := repo.QuizAccuracyByType(ctx, 1, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []models.TypeAccuracy{{Type: "quiz", Total: 4, Correct: 1}}, byType)
+
+	missed, err := repo.MostMissedWords(ctx, 1, time.Time{}, 5)
+	require.NoError(t, err)
+	require.Len(t, missed, 2)
+	assert.Equal(t, models.MissedWord{Word: "dog", Translation: "собака", Misses: 2, Attempts: 2}, missed[0])
+	assert.Equal(t, models.MissedWord{Word: "cat", Translation: "кот", Misses: 1, Attempts: 2}, missed[1])
+
+	learnTime, err := repo.TimeToLearn(ctx, 1, since)
+	require.NoError(t, err)
+	assert.Equal(t, 2, learnTime.Count)
+	assert.InDelta(t, 7200, learnTime.AvgSeconds, 1)
+
+	activity, err := repo.ActivityCounts(ctx, 1, since)
+	require.NoError(t, err)
+	require.Len(t, activity, 2)
+	assert.Equal(t, 1, activity[0].Count)
+	assert.Equal(t, 4, activity[1].Count, "two quiz answers and two word cards today")
+}
+
+func TestWordsR_AddWord_LearnedAt(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко"}))
+
+	var learnedAt *time.Time
+	require.NoError(t, conn.Get(&learnedAt, `SELECT learned_at FROM user_words WHERE user_id = 1`))
+	assert.Nil(t, learnedAt)
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Known: true}))
+	require.NoError(t, conn.Get(&learnedAt, `SELECT learned_at FROM user_words WHERE user_id = 1`))
+	require.NotNil(t, learnedAt)
+	first := *learnedAt
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Known: true}))
+	require.NoError(t, conn.Get(&learnedAt, `SELECT learned_at FROM user_words WHERE user_id = 1`))
+	assert.Equal(t, first, *learnedAt, "learned_at keeps the first time the word was learned")
+}
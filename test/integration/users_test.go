@@ -0,0 +1,97 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/DanRulev/vocabot.git/internal/storage/db"
+	"github.com/DanRulev/vocabot.git/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersR(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewUsersRepository(conn)
+	settingsRepo := repository.NewSettingsRepository(conn)
+	ctx := context.Background()
+
+	settings, err := repo.UserSettings(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.UserSettings{}, settings)
+
+	require.NoError(t, repo.TouchUser(ctx, models.User{ID: 1, UserName: "bob", LanguageCode: "en"}))
+	require.NoError(t, repo.TouchUser(ctx, models.User{ID: 1, UserName: "bobby", LanguageCode: "uk"}))
+
+	var user models.User
+	require.NoError(t, conn.Get(&user, `SELECT user_id, username, language_code, created_at, last_active_at FROM users WHERE user_id = 1`))
+	assert.Equal(t, "bobby", user.UserName)
+	assert.Equal(t, "uk", user.LanguageCode)
+	assert.False(t, user.LastActiveAt.Before(user.CreatedAt))
+
+	require.NoError(t, settingsRepo.SetLanguage(ctx, 1, "en"))
+	require.NoError(t, repo.UpdateUserSettings(ctx, 1, models.UserSettings{TargetLanguage: "en"}))
+	require.NoError(t, repo.UpdateUserSettings(ctx, 1, models.UserSettings{Level: "B1", DailyGoal: 10, Onboarded: true}))
+
+	settings, err = repo.UserSettings(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.UserSettings{Language: "en", TargetLanguage: "en", Level: "B1", DailyGoal: 10, Onboarded: true}, settings)
+
+	lang, err := settingsRepo.Language(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "en", lang, "updates merge into the stored settings")
+
+	require.NoError(t, repo.UpdateUserSettings(ctx, 2, models.UserSettings{Level: "A2"}), "unknown users are registered")
+	settings, err = repo.UserSettings(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "A2", settings.Level)
+}
+
+func TestUsersMigration_Backfill(t *testing.T) {
+	conn := newSchema(t)
+	ctx := context.Background()
+
+	migrator, err := db.NewMigrator(conn, migrations.FS)
+	require.NoError(t, err)
+
+	for {
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		if status.Version < 7 {
+			break
+		}
+		_, err = migrator.Down(ctx, 1)
+		require.NoError(t, err)
+	}
+
+	_, err = conn.Exec(`INSERT INTO user_words (user_id, word_text, translation, last_seen) VALUES (1, 'apple', 'яблоко', NOW())`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO user_quiz_results (user_id, word, translation, type, is_correct) VALUES (2, 'apple', 'яблоко', 'quiz', true)`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO user_settings (user_id, language) VALUES (1, 'uk'), (3, 'en')`)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	var ids []int64
+	require.NoError(t, conn.Select(&ids, `SELECT user_id FROM users ORDER BY user_id`))
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+
+	lang, err := repository.NewSettingsRepository(conn).Language(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "uk", lang)
+
+	users := repository.NewUsersRepository(conn)
+	settings, err := users.UserSettings(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, settings.Onboarded, "users with words skip the wizard")
+
+	settings, err = users.UserSettings(ctx, 3)
+	require.NoError(t, err)
+	assert.False(t, settings.Onboarded)
+}
@@ -0,0 +1,263 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordsR_AddWord_Upsert(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	word := models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко"}
+	require.NoError(t, repo.AddWord(ctx, word))
+
+	var first models.WordCard
+	require.NoError(t, conn.Get(&first, `SELECT user_id, word_text, translation, last_seen, known FROM user_words WHERE user_id = 1`))
+	assert.False(t, first.Known)
+
+	word.Known = true
+	require.NoError(t, repo.AddWord(ctx, word))
+
+	word.Known = false
+	word.Translation = "другой перевод"
+	require.NoError(t, repo.AddWord(ctx, word))
+
+	var rows []models.WordCard
+	require.NoError(t, conn.Select(&rows, `SELECT user_id, word_text, translation, last_seen, known FROM user_words WHERE user_id = 1`))
+	require.Len(t, rows, 1, "conflict on (user_id, word_text) must not insert a duplicate")
+	assert.True(t, rows[0].Known, "known must never be reset by a later 'repeat'")
+	assert.Equal(t, "яблоко", rows[0].Translation)
+	assert.False(t, rows[0].LastSeen.Before(first.LastSeen))
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 2, WordText: "apple", Translation: "яблоко"}))
+
+	var total int
+	require.NoError(t, conn.Get(&total, `SELECT COUNT(*) FROM user_words`))
+	assert.Equal(t, 2, total, "the same word is stored separately per user")
+}
+
+func TestWordsR_Word_Details(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	_, ok, err := repo.Word(ctx, 1, "apple")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	details := &models.WordDetails{
+		Translation: models.MyMemoryTranslationResult{Text: "яблоко", Alternatives: []string{"яблоня"}},
+		Dictionary:  models.TranslationResponse{SourceText: "apple", DestinationText: "яблоко"},
+	}
+	details.Dictionary.Pronunciation.SourceTextPhonetic = "ˈæpəl"
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Details: details}))
+
+	word, ok, err := repo.Word(ctx, 1, "apple")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, details, word.Details)
+
+	// A quiz answer saves the word again without details.
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко", Known: true}))
+
+	word, ok, err = repo.Word(ctx, 1, "apple")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, word.Known)
+	assert.Equal(t, details, word.Details, "details must survive an update without them")
+
+	words, _, err := repo.Words(ctx, 1, models.WordList{Deck: models.DeckLearned, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, words, 1)
+	assert.Equal(t, details, words[0].Details)
+
+	_, ok, err = repo.Word(ctx, 2, "apple")
+	require.NoError(t, err)
+	assert.False(t, ok, "words of other users are not found")
+}
+
+func TestWordsR_RandomUnknownWord(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	_, err := repo.RandomUnknownWord(ctx, 1)
+	require.Error(t, err)
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "known", Translation: "известно", Known: true}))
+	_, err = repo.RandomUnknownWord(ctx, 1)
+	require.Error(t, err, "known words are never picked")
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "cat", Translation: "кот"}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 2, WordText: "dog", Translation: "собака"}))
+
+	for i := 0; i < 10; i++ {
+		word, err := repo.RandomUnknownWord(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "cat", word.WordText)
+		assert.Equal(t, "кот", word.Translation)
+	}
+}
+
+func TestWordsR_Words_Pagination(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	const total = 25
+	for i := 0; i < total; i++ {
+		word := fmt.Sprintf("word%02d", i)
+		require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: word, Translation: "перевод"}))
+		_, err := conn.Exec(`UPDATE user_words SET last_seen = NOW() - ($1 * INTERVAL '1 minute') WHERE word_text = $2`, i, word)
+		require.NoError(t, err)
+	}
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "learned", Translation: "выучено", Known: true}))
+
+	words, count, err := repo.Words(ctx, 1, models.WordList{Deck: models.DeckLearning, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, total, count)
+	require.Len(t, words, 10)
+	assert.Equal(t, "word00", words[0].WordText, "most recently seen first")
+	assert.Equal(t, "word09", words[9].WordText)
+
+	words, _, err = repo.Words(ctx, 1, models.WordList{Deck: models.DeckLearning, PageSize: 10, Page: 2})
+	require.NoError(t, err)
+	require.Len(t, words, 5, "last page is partial")
+	assert.Equal(t, "word24", words[4].WordText)
+
+	words, count, err = repo.Words(ctx, 1, models.WordList{Deck: models.DeckLearning, PageSize: 10, Page: 3})
+	require.NoError(t, err)
+	assert.Equal(t, total, count)
+	assert.Empty(t, words, "offset past the end returns nothing")
+
+	words, count, err = repo.Words(ctx, 1, models.WordList{Deck: models.DeckLearned, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, words, 1)
+	assert.Equal(t, "learned", words[0].WordText)
+
+	words, count, err = repo.Words(ctx, 99, models.WordList{Deck: models.DeckLearning, PageSize: 10})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, words)
+}
+
+func TestWordsR_Words_Filters(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	noun := &models.WordDetails{Dictionary: dictionaryWithPOS("noun")}
+	verb := &models.WordDetails{Dictionary: dictionaryWithPOS("verb")}
+
+	for _, w := range []models.WordCard{
+		{UserID: 1, WordText: "apple", Translation: "яблоко", Details: noun},
+		{UserID: 1, WordText: "apply", Translation: "применять", Details: verb, Known: true},
+		{UserID: 1, WordText: "a_b", Translation: "аб"},
+		{UserID: 1, WordText: "banana", Translation: "банан", Details: noun},
+		{UserID: 1, WordText: "yard", Translation: "двор"},
+		{UserID: 2, WordText: "apricot", Translation: "абрикос"},
+	} {
+		require.NoError(t, repo.AddWord(ctx, w))
+	}
+	_, err := conn.Exec(`UPDATE user_words SET created_at = NOW() - INTERVAL '60 days', last_seen = NOW() - INTERVAL '60 days' WHERE word_text = 'banana'`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO user_quiz_results (user_id, word, translation, type, is_correct) VALUES
+		(1, 'yard', 'двор', 'quiz', false),
+		(1, 'banana', 'банан', 'quiz', false),
+		(1, 'banana', 'банан', 'quiz', true),
+		(2, 'apple', 'яблоко', 'quiz', false)`)
+	require.NoError(t, err)
+
+	texts := func(list models.WordList) []string {
+		t.Helper()
+		list.PageSize = 10
+		words, _, err := repo.Words(ctx, 1, list)
+		require.NoError(t, err)
+		texts := make([]string, 0, len(words))
+		for _, w := range words {
+			texts = append(texts, w.WordText)
+		}
+		return texts
+	}
+
+	assert.Equal(t, []string{"apple", "apply"}, texts(models.WordList{Prefix: "APP", Sort: models.WordSortAlpha}), "prefix is case insensitive, both decks")
+	assert.Equal(t, []string{"apple"}, texts(models.WordList{Deck: models.DeckLearning, Prefix: "app"}))
+	assert.Equal(t, []string{"a_b"}, texts(models.WordList{Prefix: "a_"}), "wildcards in the prefix match themselves")
+	assert.Equal(t, []string{"banana"}, texts(models.WordList{Prefix: "бан"}), "translations are searched too")
+	assert.Equal(t, []string{"apple", "banana"}, texts(models.WordList{PartOfSpeech: "noun", Sort: models.WordSortAlpha}))
+	assert.NotContains(t, texts(models.WordList{AddedDays: 30}), "banana")
+	assert.Equal(t, "banana", texts(models.WordList{Sort: models.WordSortDue})[0], "not seen for the longest time first")
+	assert.Equal(t, []string{"yard", "banana"}, texts(models.WordList{Sort: models.WordSortErrors})[:2], "highest error rate first")
+}
+
+func dictionaryWithPOS(pos string) models.TranslationResponse {
+	var dict models.TranslationResponse
+	dict.Definitions = append(dict.Definitions, struct {
+		PartOfSpeech  string              `json:"part-of-speech"`
+		Definition    string              `json:"definition"`
+		Example       string              `json:"example"`
+		OtherExamples []string            `json:"other-examples"`
+		Synonyms      map[string][]string `json:"synonyms"`
+	}{PartOfSpeech: pos})
+	return dict
+}
+
+func TestWordsR_WordStat(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	stats, err := repo.WordStat(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.WordStats{}, stats, "COALESCE must turn an empty SUM into zero")
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "a", Translation: "а", Known: true}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "b", Translation: "б"}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "c", Translation: "в"}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 2, WordText: "d", Translation: "г", Known: true}))
+
+	stats, err = repo.WordStat(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.WordStats{TotalCount: 3, LearnedCount: 1, UnlearnedCount: 2}, stats)
+}
+
+func TestWordsR_RandomWords(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "apple", Translation: "яблоко"}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "cat", Translation: "кот"}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "thing"}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 2, WordText: "dog", Translation: "собака"}))
+
+	words, err := repo.RandomWords(ctx, 1, "apple", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []models.QuizWord{{Word: "cat", Translation: "кот"}}, words)
+}
+
+func TestWordsR_SavedWords(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWordsRepository(conn)
+	ctx := context.Background()
+
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "Meadow", Translation: "луг"}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 1, WordText: "brook", Translation: "ручей", Known: true}))
+	require.NoError(t, repo.AddWord(ctx, models.WordCard{UserID: 2, WordText: "hill", Translation: "холм"}))
+
+	saved, err := repo.SavedWords(ctx, 1, []string{"meadow", "brook", "hill", "forest"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"meadow", "brook"}, saved)
+}
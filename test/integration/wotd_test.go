@@ -0,0 +1,87 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanRulev/vocabot.git/internal/models"
+	"github.com/DanRulev/vocabot.git/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWotdR_WordOfDay(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWotdRepository(conn)
+	ctx := context.Background()
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	_, found, err := repo.WordOfDay(ctx, day, "en-ru")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	wotd := models.WordOfDay{
+		Day:         day,
+		LangPair:    "en-ru",
+		Word:        "apple",
+		Translation: "яблоко",
+		Card: models.WordDetails{
+			Translation: models.MyMemoryTranslationResult{Text: "яблоко", Match: 0.9},
+			Dictionary:  models.TranslationResponse{SourceText: "apple", DestinationText: "яблоко"},
+		},
+	}
+	require.NoError(t, repo.SaveWordOfDay(ctx, wotd))
+
+	other := wotd
+	other.Word = "pear"
+	require.NoError(t, repo.SaveWordOfDay(ctx, other), "a second word for the day is ignored")
+
+	got, found, err := repo.WordOfDay(ctx, day, "en-ru")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "apple", got.Word)
+	assert.Equal(t, wotd.Card, got.Card)
+}
+
+func TestWotdR_Subscriptions(t *testing.T) {
+	conn := newSchema(t)
+	repo := repository.NewWotdRepository(conn)
+	settings := repository.NewSettingsRepository(conn)
+	ctx := context.Background()
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.SubscribeWotd(ctx, 1, 1))
+	require.NoError(t, repo.SubscribeWotd(ctx, 2, 20))
+	require.NoError(t, settings.SetLanguage(ctx, 2, "en"))
+
+	subscribed, err := repo.WotdSubscribed(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, subscribed)
+
+	subscribers, err := repo.WotdSubscribers(ctx, day)
+	require.NoError(t, err)
+	assert.Equal(t, []models.WotdSubscriber{
+		{UserID: 1, ChatID: 1},
+		{UserID: 2, ChatID: 20, Language: "en"},
+	}, subscribers)
+
+	require.NoError(t, repo.MarkWotdSent(ctx, 1, day))
+	subscribers, err = repo.WotdSubscribers(ctx, day)
+	require.NoError(t, err)
+	require.Len(t, subscribers, 1, "users who got today's word are skipped")
+	assert.Equal(t, int64(2), subscribers[0].UserID)
+
+	subscribers, err = repo.WotdSubscribers(ctx, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Len(t, subscribers, 2)
+
+	require.NoError(t, repo.UnsubscribeWotd(ctx, 1))
+	subscribed, err = repo.WotdSubscribed(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, subscribed)
+}